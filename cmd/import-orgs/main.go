@@ -0,0 +1,97 @@
+// Command import-orgs creates ministries, departments and offices in bulk
+// from a CSV or JSON file in the database selected by DATABASE_TYPE. Every
+// row is checked first and written to the report; the rows are created in
+// one transaction only when all of them are valid, and never in a dry run.
+//
+// Usage:
+//
+//	go run ./cmd/import-orgs -file organisations.csv [-format csv|json] [-report import_report.csv] [-dry-run]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-mysql-backend/config"
+	"go-mysql-backend/internal/db"
+	"go-mysql-backend/internal/importer"
+	"go-mysql-backend/internal/repository"
+)
+
+func main() {
+	path := flag.String("file", "", "CSV or JSON file to import")
+	format := flag.String("format", "", "format of the file: csv or json (default from its extension)")
+	reportPath := flag.String("report", "import_report.csv", "CSV file the per-row report is written to")
+	dryRun := flag.Bool("dry-run", false, "check and report without writing")
+	flag.Parse()
+
+	if *path == "" {
+		log.Fatal("-file is required")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*path)), ".")
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("Cannot open import file: %v", err)
+	}
+	defer file.Close()
+	batch, err := importer.Parse(file, importer.Format(*format))
+	if err != nil {
+		log.Fatalf("Cannot read %s: %v", *path, err)
+	}
+
+	var store importer.Store
+	switch dbType := config.LoadType(); dbType {
+	case "postgres":
+		store = repository.NewOrganizationRepository(db.InitPostgres(db.RequireSchema))
+	case "neo4j":
+		driver, err := db.InitNeo4j(db.RequireSchema)
+		if err != nil {
+			log.Fatal("Failed to connect to Neo4j:", err)
+		}
+		defer driver.Close(context.Background())
+		store = repository.NewNeo4jRepository(driver)
+	case "sqlite":
+		sqliteDB, err := db.InitSQLite()
+		if err != nil {
+			log.Fatal("Failed to open SQLite:", err)
+		}
+		defer sqliteDB.Close()
+		store = repository.NewSQLiteRepository(sqliteDB)
+	default:
+		log.Fatalf("Unsupported DATABASE_TYPE %q", dbType)
+	}
+
+	report, runErr := importer.Run(store, batch, importer.Options{DryRun: *dryRun})
+	if runErr != nil && !errors.Is(runErr, importer.ErrInvalidRows) {
+		log.Fatalf("Import failed, nothing was created: %v", runErr)
+	}
+
+	out, err := os.Create(*reportPath)
+	if err != nil {
+		log.Fatalf("Cannot create report: %v", err)
+	}
+	defer out.Close()
+	if err := importer.WriteReport(out, report.Rows); err != nil {
+		log.Fatalf("Cannot write report: %v", err)
+	}
+
+	switch {
+	case runErr != nil:
+		log.Fatalf("%d invalid rows, nothing was created (see %s)", report.Invalid, *reportPath)
+	case *dryRun:
+		fmt.Printf("Would create %d ministries, %d departments and %d offices (see %s)\n",
+			report.Ministries, report.Departments, report.Offices, *reportPath)
+	default:
+		fmt.Printf("Created %d ministries, %d departments and %d offices (see %s)\n",
+			report.Ministries, report.Departments, report.Offices, *reportPath)
+	}
+}
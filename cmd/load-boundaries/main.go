@@ -0,0 +1,87 @@
+// Command load-boundaries reads administrative area polygons from a GeoJSON
+// FeatureCollection and stores them in the database selected by
+// DATABASE_TYPE. Areas are matched on level and code, so a file can be
+// loaded again to update names and boundaries. Load provinces first, then
+// districts, DS divisions and GN divisions, since each level refers to the
+// one above it.
+//
+// Usage:
+//
+//	go run ./cmd/load-boundaries -level district -file districts.geojson [-code-prop ADM2_PCODE] [-name-prop ADM2_EN] [-parent-prop ADM1_PCODE]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go-mysql-backend/config"
+	"go-mysql-backend/internal/boundaries"
+	"go-mysql-backend/internal/db"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+)
+
+func main() {
+	level := flag.String("level", "", "administrative level of the file: province, district, ds_division or gn_division")
+	path := flag.String("file", "", "GeoJSON FeatureCollection to load")
+	codeProp := flag.String("code-prop", "", "feature property holding the area code (default <level>_code)")
+	nameProp := flag.String("name-prop", "", "feature property holding the area name (default <level>_name)")
+	parentProp := flag.String("parent-prop", "", "feature property holding the parent area code (default <parent level>_code)")
+	flag.Parse()
+
+	if *path == "" {
+		log.Fatal("-file is required")
+	}
+
+	opts := boundaries.DefaultOptions(models.AdminLevel(*level))
+	if *codeProp != "" {
+		opts.CodeProperty = *codeProp
+	}
+	if *nameProp != "" {
+		opts.NameProperty = *nameProp
+	}
+	if *parentProp != "" {
+		opts.ParentCodeProperty = *parentProp
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("Cannot open boundary file: %v", err)
+	}
+	defer file.Close()
+
+	areas, err := boundaries.Parse(file, opts)
+	if err != nil {
+		log.Fatalf("Cannot parse %s: %v", *path, err)
+	}
+
+	var repo repository.AdminAreaRepo
+	switch dbType := config.LoadType(); dbType {
+	case "postgres":
+		repo = repository.NewAdminAreaRepository(db.InitPostgres(db.RequireSchema))
+	case "neo4j":
+		driver, err := db.InitNeo4j(db.RequireSchema)
+		if err != nil {
+			log.Fatal("Failed to connect to Neo4j:", err)
+		}
+		defer driver.Close(context.Background())
+		repo = repository.NewNeo4jAdminAreaRepository(driver)
+	case "sqlite":
+		sqliteDB, err := db.InitSQLite()
+		if err != nil {
+			log.Fatal("Failed to open SQLite:", err)
+		}
+		defer sqliteDB.Close()
+		repo = repository.NewAdminAreaRepository(sqliteDB)
+	default:
+		log.Fatalf("Unsupported DATABASE_TYPE %q", dbType)
+	}
+
+	if err := repo.SaveAreas(areas); err != nil {
+		log.Fatalf("Loading failed: %v", err)
+	}
+	fmt.Printf("Loaded %d %s areas from %s\n", len(areas), opts.Level, *path)
+}
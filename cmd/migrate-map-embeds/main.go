@@ -0,0 +1,72 @@
+// Command migrate-map-embeds parses the Google Maps embeds stored in
+// google_map_script and fills in the structured latitude/longitude of
+// ministries and departments. It talks to the database selected by
+// DATABASE_TYPE directly, so the API server does not need to be running.
+//
+// Usage:
+//
+//	go run ./cmd/migrate-map-embeds -report unparsed.csv [-dry-run] [-overwrite]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go-mysql-backend/config"
+	"go-mysql-backend/internal/db"
+	"go-mysql-backend/internal/mapembed"
+	"go-mysql-backend/internal/repository"
+)
+
+func main() {
+	reportPath := flag.String("report", "map_embed_report.csv", "CSV file for rows whose map script could not be parsed")
+	dryRun := flag.Bool("dry-run", false, "parse and report without writing coordinates")
+	overwrite := flag.Bool("overwrite", false, "replace coordinates that are already set")
+	flag.Parse()
+
+	var store mapembed.LocationStore
+	switch dbType := config.LoadType(); dbType {
+	case "postgres":
+		store = repository.NewOrganizationRepository(db.InitPostgres(db.RequireSchema))
+	case "neo4j":
+		driver, err := db.InitNeo4j(db.RequireSchema)
+		if err != nil {
+			log.Fatal("Failed to connect to Neo4j:", err)
+		}
+		defer driver.Close(context.Background())
+		store = repository.NewNeo4jRepository(driver)
+	case "sqlite":
+		sqliteDB, err := db.InitSQLite()
+		if err != nil {
+			log.Fatal("Failed to open SQLite:", err)
+		}
+		defer sqliteDB.Close()
+		store = repository.NewSQLiteRepository(sqliteDB)
+	default:
+		log.Fatalf("Unsupported DATABASE_TYPE %q", dbType)
+	}
+
+	result, err := mapembed.Migrate(store, mapembed.Options{DryRun: *dryRun, Overwrite: *overwrite})
+	if err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+
+	report, err := os.Create(*reportPath)
+	if err != nil {
+		log.Fatalf("Cannot create report: %v", err)
+	}
+	defer report.Close()
+	if err := mapembed.WriteReport(report, result.Failures); err != nil {
+		log.Fatalf("Cannot write report: %v", err)
+	}
+
+	verb := "Updated"
+	if *dryRun {
+		verb = "Would update"
+	}
+	fmt.Printf("%s %d rows, skipped %d with coordinates, %d without a map script, %d unparsed (see %s)\n",
+		verb, result.Updated, result.Skipped, result.Unchanged, len(result.Failures), *reportPath)
+}
@@ -0,0 +1,113 @@
+// Command migrate applies, reverts or lists the schema migrations of the
+// database selected by DATABASE_TYPE. The SQLite and in-memory backends
+// create their schema when they start and have no migrations.
+//
+// Usage:
+//
+//	go run ./cmd/migrate up
+//	go run ./cmd/migrate down [-steps 1]
+//	go run ./cmd/migrate status
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go-mysql-backend/config"
+	"go-mysql-backend/internal/db"
+	"go-mysql-backend/internal/migrations"
+)
+
+func main() {
+	steps := flag.Int("steps", 1, "number of migrations down reverts")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: migrate [-steps n] up|down|status")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var target migrations.Target
+	var dialect migrations.Dialect
+	switch dbType := config.LoadType(); dbType {
+	case "postgres":
+		sqlDB, err := db.ConnectPostgres()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer sqlDB.Close()
+		target, dialect = migrations.NewPostgresTarget(sqlDB), migrations.Postgres
+	case "neo4j":
+		driver, err := db.ConnectNeo4j()
+		if err != nil {
+			log.Fatal("Failed to connect to Neo4j:", err)
+		}
+		defer driver.Close(context.Background())
+		target, dialect = migrations.NewNeo4jTarget(driver), migrations.Neo4j
+	case "sqlite", "memory":
+		fmt.Printf("The %s backend creates its schema when it starts; there is nothing to migrate.\n", dbType)
+		return
+	default:
+		log.Fatalf("Unknown DATABASE_TYPE %q: use postgres, neo4j, sqlite or memory", dbType)
+	}
+
+	all, err := migrations.Load(dialect)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch command := flag.Arg(0); command {
+	case "up":
+		applied, err := migrations.Up(target, all)
+		for _, m := range applied {
+			fmt.Printf("Applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("Schema is up to date")
+		}
+	case "down":
+		reverted, err := migrations.Down(target, all, *steps)
+		for _, m := range reverted {
+			fmt.Printf("Reverted %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("No migrations are applied")
+		}
+	case "status":
+		states, err := migrations.Status(target, all)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for i, s := range states {
+			fmt.Printf("%04d  %-24s  %s\n", s.Migration.Version, s.Migration.Name, describe(s, i >= len(all)))
+		}
+	default:
+		log.Fatalf("Unknown command %q: use up, down or status", command)
+	}
+}
+
+// describe returns the state of a migration for the status listing.
+func describe(s migrations.State, unknown bool) string {
+	switch {
+	case unknown:
+		return "unknown to this version, applied " + s.Applied.AppliedAt.Format("2006-01-02 15:04:05")
+	case s.Modified():
+		return "modified since it was applied " + s.Applied.AppliedAt.Format("2006-01-02 15:04:05")
+	case s.Applied != nil:
+		return "applied " + s.Applied.AppliedAt.Format("2006-01-02 15:04:05")
+	default:
+		return "pending"
+	}
+}
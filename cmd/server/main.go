@@ -1,9 +1,16 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"go-mysql-backend/config"
 	"go-mysql-backend/internal/db"
@@ -18,45 +25,120 @@ import (
 )
 
 func main() {
+	migrate := flag.Bool("migrate", false, "apply pending schema migrations on start")
+	flag.Parse()
+	schema := db.RequireSchema
+	if *migrate {
+		schema = db.MigrateSchema
+	}
 
-	dbType := config.LoadType()
-	if dbType == "postgres" {
-
-		db := db.InitPostgres()
-
-		orgRepo := repository.NewOrganizationRepository(db)
-		orgService := service.NewOrganizationService(orgRepo)
-		orgHandler := handlers.NewOrganizationHandler(orgService)
+	var orgRepo repository.OrganizationRepo
+	var areaRepo repository.AdminAreaRepo
+	var positionRepo repository.PositionRepo
+	legacy := routes.LegacyPostgres
+	// onShutdown runs once the server has stopped.
+	onShutdown := func() {}
 
-		router := mux.NewRouter()
-		routes.SetupOrgRoutes(router, orgHandler)
+	dbType := config.LoadType()
+	switch dbType {
+	case "postgres":
+		db := db.InitPostgres(schema)
+		orgRepo = repository.NewOrganizationRepository(db)
+		areaRepo = repository.NewAdminAreaRepository(db)
+		positionRepo = repository.NewPositionRepository(db)
 
-		startServer(router)
+	case "neo4j":
+		neo4jDriver, err := db.InitNeo4j(schema)
+		if err != nil {
+			log.Fatal("Failed to connect to Neo4j:", err)
+		}
+		orgRepo = repository.NewNeo4jRepository(neo4jDriver)
+		areaRepo = repository.NewNeo4jAdminAreaRepository(neo4jDriver)
+		positionRepo = repository.NewNeo4jPositionRepository(neo4jDriver)
+		legacy = routes.LegacyNeo4j
 
-	} else if dbType == "neo4j" {
+	case "sqlite":
+		sqliteDB, err := db.InitSQLite()
+		if err != nil {
+			log.Fatal("Failed to open SQLite:", err)
+		}
+		orgRepo = repository.NewSQLiteRepository(sqliteDB)
+		areaRepo = repository.NewAdminAreaRepository(sqliteDB)
+		positionRepo = repository.NewPositionRepository(sqliteDB)
 
-		neo4jDriver, err := db.InitNeo4j()
+	case "memory":
+		store, snapshot, err := db.InitMemory()
 		if err != nil {
-			log.Fatal("Failed to connect to Neo4j:", err)
+			log.Fatal("Failed to load the in-memory data:", err)
+		}
+		orgRepo = repository.NewMemoryRepository(store)
+		areaRepo = repository.NewMemoryAdminAreaRepository(store)
+		positionRepo = repository.NewMemoryPositionRepository(store)
+		if snapshot != "" {
+			onShutdown = func() {
+				if err := store.Save(snapshot); err != nil {
+					log.Println("Failed to save the in-memory data:", err)
+					return
+				}
+				log.Println("Saved the in-memory data to", snapshot)
+			}
 		}
-		neoRepo := repository.NewNeo4jRepository(neo4jDriver)
-		neoService := service.NewNeo4JService(neoRepo)
-		neoHandler := handlers.NewNeo4JHandler(neoService)
-		router := mux.NewRouter()
-		routes.SetupNeo4JRoutes(router, neoHandler)
 
-		startServer(router)
+	default:
+		log.Fatalf("Unknown DATABASE_TYPE %q: use postgres, neo4j, sqlite or memory", dbType)
 	}
+
+	orgService := service.NewOrganizationService(orgRepo)
+	if err := orgService.LoadAutocomplete(); err != nil {
+		log.Fatal("Failed to build the autocomplete index:", err)
+	}
+	orgHandler := handlers.NewOrganizationHandler(orgService)
+
+	areaService := service.NewAdminAreaService(areaRepo)
+	areaHandler := handlers.NewAdminAreaHandler(areaService)
+
+	jurisdictionService := service.NewJurisdictionService(areaRepo, orgRepo)
+	orgService.Jurisdiction = jurisdictionService
+	areaService.Jurisdiction = jurisdictionService
+	jurisdictionHandler := handlers.NewJurisdictionHandler(jurisdictionService)
+
+	positionService := service.NewPositionService(positionRepo)
+	positionHandler := handlers.NewPositionHandler(positionService)
+
+	router := mux.NewRouter()
+	routes.SetupOrgRoutes(router, orgHandler, legacy)
+	routes.SetupAdminAreaRoutes(router, areaHandler)
+	routes.SetupJurisdictionRoutes(router, jurisdictionHandler)
+	routes.SetupPositionRoutes(router, positionHandler)
+
+	startServer(router)
+	onShutdown()
 }
 
 func startServer(router *mux.Router) {
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:5173"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Content-Type"},
 		AllowCredentials: true,
 	}).Handler(router)
 
-	fmt.Println("Server running at http://localhost:8080")
-	log.Fatal(http.ListenAndServe(":8080", corsHandler))
+	server := &http.Server{Addr: ":8080", Handler: corsHandler}
+	go func() {
+		fmt.Println("Server running at http://localhost:8080")
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	// Stop on Ctrl-C or SIGTERM, letting requests in flight finish.
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Println("Shutdown:", err)
+	}
 }
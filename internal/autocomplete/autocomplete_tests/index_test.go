@@ -0,0 +1,103 @@
+package autocomplete_test
+
+import (
+	"fmt"
+	"testing"
+
+	"go-mysql-backend/internal/autocomplete"
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int { return &v }
+
+func newIndex() *autocomplete.Index {
+	index := autocomplete.New()
+	index.Load([]models.Suggestion{
+		{Entity: models.SearchMinistry, ID: 1, Name: "Ministry of Education",
+			Names: models.LocalizedNames{"en": "Ministry of Education", "si": "අධ්‍යාපන අමාත්‍යාංශය"}},
+		{Entity: models.SearchMinistry, ID: 2, Name: "Ministry of Health"},
+		{Entity: models.SearchDepartment, ID: 7, Name: "Department of Motor Traffic", ParentID: intPtr(3)},
+		{Entity: models.SearchDepartment, ID: 8, Name: "Department of Examinations", ParentID: intPtr(1)},
+		{Entity: models.SearchDepartment, ID: 9, Name: "Educational Publications Department", ParentID: intPtr(1)},
+	})
+	return index
+}
+
+func ids(suggestions []models.Suggestion) []int {
+	out := make([]int, len(suggestions))
+	for i, s := range suggestions {
+		out[i] = s.ID
+	}
+	return out
+}
+
+func lookup(index *autocomplete.Index, text string, entities ...models.SearchEntity) []models.Suggestion {
+	if len(entities) == 0 {
+		entities = models.AutocompleteEntities
+	}
+	return index.Lookup(models.AutocompleteQuery{Text: text, Entities: entities, Limit: 10})
+}
+
+func TestLookupRanksNamePrefixesFirst(t *testing.T) {
+	index := newIndex()
+
+	// "Educational ..." starts with the query; the ministry only has a
+	// word starting with it.
+	assert.Equal(t, []int{9, 1}, ids(lookup(index, "educ")))
+	assert.Equal(t, []int{2}, ids(lookup(index, "ministry hea")))
+	assert.Equal(t, []int{8, 7}, ids(lookup(index, "department of")))
+	assert.Empty(t, lookup(index, "ports"))
+}
+
+func TestLookupFiltersEntitiesAndLimits(t *testing.T) {
+	index := newIndex()
+
+	// Equal ranks go shortest name first.
+	assert.Equal(t, []int{2, 1}, ids(lookup(index, "ministry", models.SearchMinistry)))
+	assert.Equal(t, []int{9}, ids(lookup(index, "educ", models.SearchDepartment)))
+
+	limited := index.Lookup(models.AutocompleteQuery{Text: "of", Entities: models.AutocompleteEntities, Limit: 2})
+	assert.Len(t, limited, 2)
+}
+
+func TestLookupMatchesLocalizedAndRomanizedNames(t *testing.T) {
+	index := newIndex()
+
+	assert.Equal(t, []int{1}, ids(lookup(index, "අධ්‍යා")))
+	assert.Equal(t, []int{1}, ids(lookup(index, "adhyapana amath")))
+}
+
+func TestPutAndRemove(t *testing.T) {
+	index := newIndex()
+
+	index.Put(models.Suggestion{Entity: models.SearchMinistry, ID: 2, Name: "Ministry of Health and Mass Media"})
+	assert.Equal(t, []int{2}, ids(lookup(index, "mass")))
+	assert.Equal(t, 5, index.Len())
+
+	index.Put(models.Suggestion{Entity: models.SearchMinistry, ID: 2, Name: "Ministry of Health"})
+	assert.Empty(t, lookup(index, "mass"))
+
+	index.Remove(models.SearchDepartment, 7)
+	assert.Empty(t, lookup(index, "motor"))
+	assert.Equal(t, 4, index.Len())
+}
+
+// BenchmarkLookup matches every entry of a large index, the worst case
+// for a one-letter query; it should stay well under 10 ms an operation.
+func BenchmarkLookup(b *testing.B) {
+	suggestions := make([]models.Suggestion, 0, 20000)
+	for i := 0; i < 20000; i++ {
+		suggestions = append(suggestions, models.Suggestion{
+			Entity: models.SearchDepartment, ID: i, Name: fmt.Sprintf("Divisional Secretariat %d of Region %d", i, i%50),
+		})
+	}
+	index := autocomplete.New()
+	index.Load(suggestions)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lookup(index, "div sec")
+	}
+}
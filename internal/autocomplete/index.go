@@ -0,0 +1,274 @@
+// Package autocomplete keeps the names of ministries and departments in
+// memory for typeahead, so a keystroke is answered without a database
+// round trip. The index is built from the repository at startup and kept
+// current by the services as they write.
+package autocomplete
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/search"
+	"go-mysql-backend/internal/translit"
+)
+
+// Suggestions are ranked by how they matched, then shortest name first.
+const (
+	// rankName: a name starts with the query.
+	rankName = iota
+	// rankWords: every word of the query starts a word of a name.
+	rankWords
+	// rankKeys: every phonetic key of the query starts a key of a name,
+	// as when the query is romanized Sinhala or Tamil.
+	rankKeys
+	// unmatched marks the slots a lookup has not matched.
+	unmatched
+)
+
+type ref struct {
+	entity models.SearchEntity
+	id     int
+}
+
+type entry struct {
+	suggestion models.Suggestion
+	order      int
+	length     int
+	// phrases holds each name as its search terms joined by spaces.
+	phrases []string
+	words   []string
+	keys    []string
+}
+
+// Index is a prefix index over the names of ministries and departments,
+// in every language and as phonetic keys. It is safe for concurrent use.
+//
+// Entries live in numbered slots so lookups can work on plain slices;
+// the slots of removed entries are reused.
+type Index struct {
+	mu      sync.RWMutex
+	entries []*entry
+	slots   map[ref]int
+	free    []int
+	words   prefixIndex
+	keys    prefixIndex
+}
+
+// New returns an empty index.
+func New() *Index {
+	x := &Index{}
+	x.reset()
+	return x
+}
+
+func (x *Index) reset() {
+	x.entries = nil
+	x.slots = map[ref]int{}
+	x.free = nil
+	x.words = newPrefixIndex()
+	x.keys = newPrefixIndex()
+}
+
+// Load replaces the contents of the index with suggestions. Of several
+// suggestions with the same entity and id, the last is kept.
+func (x *Index) Load(suggestions []models.Suggestion) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	last := make(map[ref]int, len(suggestions))
+	for i, s := range suggestions {
+		last[ref{s.Entity, s.ID}] = i
+	}
+
+	x.reset()
+	x.words.unsorted, x.keys.unsorted = true, true
+	for i, s := range suggestions {
+		if last[ref{s.Entity, s.ID}] == i {
+			x.put(s)
+		}
+	}
+	x.words.finishLoad()
+	x.keys.finishLoad()
+}
+
+// Put adds s, replacing any entry with the same entity and id.
+func (x *Index) Put(s models.Suggestion) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(ref{s.Entity, s.ID})
+	x.put(s)
+}
+
+// Remove drops the entry for entity and id, if there is one.
+func (x *Index) Remove(entity models.SearchEntity, id int) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(ref{entity, id})
+}
+
+// Len returns the number of entries.
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return len(x.slots)
+}
+
+func (x *Index) put(s models.Suggestion) {
+	slot := len(x.entries)
+	if n := len(x.free); n > 0 {
+		slot, x.free = x.free[n-1], x.free[:n-1]
+	} else {
+		x.entries = append(x.entries, nil)
+	}
+
+	e := newEntry(s)
+	x.entries[slot] = e
+	x.slots[ref{s.Entity, s.ID}] = slot
+	for _, word := range e.words {
+		x.words.add(word, slot)
+	}
+	for _, key := range e.keys {
+		x.keys.add(key, slot)
+	}
+}
+
+func (x *Index) remove(r ref) {
+	slot, ok := x.slots[r]
+	if !ok {
+		return
+	}
+	e := x.entries[slot]
+	for _, word := range e.words {
+		x.words.remove(word, slot)
+	}
+	for _, key := range e.keys {
+		x.keys.remove(key, slot)
+	}
+	delete(x.slots, r)
+	x.entries[slot] = nil
+	x.free = append(x.free, slot)
+}
+
+func newEntry(s models.Suggestion) *entry {
+	e := &entry{
+		suggestion: s,
+		order:      entityOrder(s.Entity),
+		length:     utf8.RuneCountInString(s.Name),
+	}
+	names := []string{s.Name}
+	for _, name := range s.Names {
+		names = append(names, name)
+	}
+	for _, name := range names {
+		terms := search.Terms(name)
+		if len(terms) == 0 {
+			continue
+		}
+		e.phrases = append(e.phrases, strings.Join(terms, " "))
+		e.words = appendNew(e.words, terms...)
+		e.keys = appendNew(e.keys, translit.Keys(name)...)
+	}
+	return e
+}
+
+// Lookup returns the best q.Limit suggestions for q.
+func (x *Index) Lookup(q models.AutocompleteQuery) []models.Suggestion {
+	terms := search.Terms(q.Text)
+	phrase := strings.Join(terms, " ")
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	ranks := make([]int, len(x.entries))
+	for i := range ranks {
+		ranks[i] = unmatched
+	}
+	for _, slot := range x.words.matchAll(terms, len(x.entries)) {
+		ranks[slot] = rankWords
+		for _, p := range x.entries[slot].phrases {
+			if strings.HasPrefix(p, phrase) {
+				ranks[slot] = rankName
+				break
+			}
+		}
+	}
+	for _, slot := range x.keys.matchAll(translit.Keys(q.Text), len(x.entries)) {
+		if ranks[slot] == unmatched {
+			ranks[slot] = rankKeys
+		}
+	}
+
+	type match struct {
+		entry *entry
+		rank  int
+	}
+	var matches []match
+	for slot, rank := range ranks {
+		if rank == unmatched || !hasEntity(q.Entities, x.entries[slot].suggestion.Entity) {
+			continue
+		}
+		matches = append(matches, match{x.entries[slot], rank})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if a.entry.length != b.entry.length {
+			return a.entry.length < b.entry.length
+		}
+		if a.entry.order != b.entry.order {
+			return a.entry.order < b.entry.order
+		}
+		return a.entry.suggestion.ID < b.entry.suggestion.ID
+	})
+	if len(matches) > q.Limit {
+		matches = matches[:q.Limit]
+	}
+
+	suggestions := make([]models.Suggestion, len(matches))
+	for i, m := range matches {
+		suggestions[i] = m.entry.suggestion
+	}
+	return suggestions
+}
+
+func hasEntity(entities []models.SearchEntity, entity models.SearchEntity) bool {
+	for _, e := range entities {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
+
+func entityOrder(entity models.SearchEntity) int {
+	for i, e := range models.AutocompleteEntities {
+		if e == entity {
+			return i
+		}
+	}
+	return len(models.AutocompleteEntities)
+}
+
+func appendNew(list []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, existing := range list {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
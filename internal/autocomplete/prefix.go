@@ -0,0 +1,89 @@
+package autocomplete
+
+import (
+	"sort"
+	"strings"
+)
+
+// prefixIndex maps words to the slots of the entries holding them and
+// keeps the words sorted, so the words starting with a prefix are one
+// contiguous run.
+type prefixIndex struct {
+	slots  map[string][]int
+	sorted []string
+	// unsorted is set while loading: new words are appended and sorted
+	// once by finishLoad rather than inserted in place one by one.
+	unsorted bool
+}
+
+func newPrefixIndex() prefixIndex {
+	return prefixIndex{slots: map[string][]int{}}
+}
+
+func (p *prefixIndex) add(word string, slot int) {
+	slots, ok := p.slots[word]
+	if !ok {
+		if p.unsorted {
+			p.sorted = append(p.sorted, word)
+		} else {
+			i := sort.SearchStrings(p.sorted, word)
+			p.sorted = append(p.sorted, "")
+			copy(p.sorted[i+1:], p.sorted[i:])
+			p.sorted[i] = word
+		}
+	}
+	p.slots[word] = append(slots, slot)
+}
+
+func (p *prefixIndex) finishLoad() {
+	sort.Strings(p.sorted)
+	p.unsorted = false
+}
+
+func (p *prefixIndex) remove(word string, slot int) {
+	slots := p.slots[word]
+	for i, s := range slots {
+		if s == slot {
+			slots[i] = slots[len(slots)-1]
+			slots = slots[:len(slots)-1]
+			break
+		}
+	}
+	if len(slots) > 0 {
+		p.slots[word] = slots
+		return
+	}
+	delete(p.slots, word)
+	i := sort.SearchStrings(p.sorted, word)
+	if i < len(p.sorted) && p.sorted[i] == word {
+		p.sorted = append(p.sorted[:i], p.sorted[i+1:]...)
+	}
+}
+
+// matchAll returns the slots, below n, of the entries holding a word
+// starting with each of prefixes. No prefixes match nothing.
+func (p *prefixIndex) matchAll(prefixes []string, n int) []int {
+	if len(prefixes) == 0 {
+		return nil
+	}
+	// matched[slot] counts the prefixes matched so far; a slot only
+	// counts for a prefix when it matched every one before it.
+	matched := make([]int, n)
+	for j, prefix := range prefixes {
+		for i := sort.SearchStrings(p.sorted, prefix); i < len(p.sorted) && strings.HasPrefix(p.sorted[i], prefix); i++ {
+			for _, slot := range p.slots[p.sorted[i]] {
+				if matched[slot] == j {
+					matched[slot] = j + 1
+				}
+			}
+		}
+	}
+
+	var slots []int
+	for slot, count := range matched {
+		if count == len(prefixes) {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
@@ -0,0 +1,125 @@
+// Package boundaries reads administrative area polygons from GeoJSON files
+// so they can be stored with repository.AdminAreaRepo.SaveAreas.
+package boundaries
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"go-mysql-backend/internal/models"
+)
+
+var (
+	ErrNotFeatureCollection = errors.New("boundary file is not a GeoJSON FeatureCollection")
+	ErrInvalidLevel         = errors.New("unknown administrative level")
+)
+
+// Options says which level a file holds and which feature properties carry
+// the code, name and parent code of each area. ParentCodeProperty is
+// ignored for provinces and required for every other level.
+type Options struct {
+	Level              models.AdminLevel
+	CodeProperty       string
+	NameProperty       string
+	ParentCodeProperty string
+}
+
+// DefaultOptions returns the property names used by the Survey Department
+// exports: <prefix>_code, <prefix>_name and the code of the parent level.
+func DefaultOptions(level models.AdminLevel) Options {
+	opts := Options{
+		Level:        level,
+		CodeProperty: string(level) + "_code",
+		NameProperty: string(level) + "_name",
+	}
+	if parent := level.Parent(); parent != "" {
+		opts.ParentCodeProperty = string(parent) + "_code"
+	}
+	return opts
+}
+
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	Geometry   json.RawMessage        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Parse reads a FeatureCollection of Polygon or MultiPolygon features and
+// returns one area per feature. The first invalid feature stops parsing
+// and is reported by index.
+func Parse(r io.Reader, opts Options) ([]models.AdminArea, error) {
+	if !opts.Level.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidLevel, opts.Level)
+	}
+
+	var fc featureCollection
+	if err := json.NewDecoder(r).Decode(&fc); err != nil {
+		return nil, err
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, ErrNotFeatureCollection
+	}
+
+	areas := make([]models.AdminArea, 0, len(fc.Features))
+	for i, f := range fc.Features {
+		area, err := toArea(f, opts)
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %w", i, err)
+		}
+		areas = append(areas, area)
+	}
+	return areas, nil
+}
+
+func toArea(f feature, opts Options) (models.AdminArea, error) {
+	area := models.AdminArea{Level: opts.Level, Geometry: f.Geometry}
+
+	var ok bool
+	if area.Code, ok = property(f.Properties, opts.CodeProperty); !ok {
+		return area, fmt.Errorf("missing property %q", opts.CodeProperty)
+	}
+	if area.Name, ok = property(f.Properties, opts.NameProperty); !ok {
+		return area, fmt.Errorf("missing property %q", opts.NameProperty)
+	}
+	if opts.Level.Parent() != "" {
+		if area.ParentCode, ok = property(f.Properties, opts.ParentCodeProperty); !ok {
+			return area, fmt.Errorf("missing property %q", opts.ParentCodeProperty)
+		}
+	}
+
+	if len(f.Geometry) == 0 || string(f.Geometry) == "null" {
+		return area, fmt.Errorf("%w: boundary has no geometry", models.ErrInvalidGeometry)
+	}
+	loc := models.Location{Geometry: f.Geometry}
+	if err := loc.Validate(); err != nil {
+		return area, err
+	}
+	var geometry struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(f.Geometry, &geometry); err != nil {
+		return area, err
+	}
+	if geometry.Type != "Polygon" && geometry.Type != "MultiPolygon" {
+		return area, fmt.Errorf("%w: boundaries must be Polygon or MultiPolygon, got %q", models.ErrInvalidGeometry, geometry.Type)
+	}
+	return area, nil
+}
+
+// property returns a string or numeric property as a non-empty string.
+func property(props map[string]interface{}, name string) (string, bool) {
+	switch v := props[name].(type) {
+	case string:
+		return v, v != ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
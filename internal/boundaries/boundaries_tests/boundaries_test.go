@@ -0,0 +1,69 @@
+package boundaries_test
+
+import (
+	"strings"
+	"testing"
+
+	"go-mysql-backend/internal/boundaries"
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const square = `{"type":"Polygon","coordinates":[[[79.8,6.8],[80.0,6.8],[80.0,7.0],[79.8,7.0],[79.8,6.8]]]}`
+
+func TestParse_Districts(t *testing.T) {
+	input := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":` + square + `,"properties":{"district_code":"LK-11","district_name":"Colombo","province_code":"LK-1"}},
+		{"type":"Feature","geometry":` + square + `,"properties":{"district_code":12,"district_name":"Gampaha","province_code":1}}
+	]}`
+
+	areas, err := boundaries.Parse(strings.NewReader(input), boundaries.DefaultOptions(models.LevelDistrict))
+
+	require.NoError(t, err)
+	require.Len(t, areas, 2)
+	assert.Equal(t, "LK-11", areas[0].Code)
+	assert.Equal(t, "Colombo", areas[0].Name)
+	assert.Equal(t, "LK-1", areas[0].ParentCode)
+	assert.Equal(t, models.LevelDistrict, areas[0].Level)
+	assert.JSONEq(t, square, string(areas[0].Geometry))
+	assert.Equal(t, "12", areas[1].Code)
+	assert.Equal(t, "1", areas[1].ParentCode)
+}
+
+func TestParse_CustomProperties(t *testing.T) {
+	input := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":` + square + `,"properties":{"ADM1_PCODE":"LK1","ADM1_EN":"Western"}}
+	]}`
+	opts := boundaries.Options{Level: models.LevelProvince, CodeProperty: "ADM1_PCODE", NameProperty: "ADM1_EN"}
+
+	areas, err := boundaries.Parse(strings.NewReader(input), opts)
+
+	require.NoError(t, err)
+	assert.Equal(t, "LK1", areas[0].Code)
+	assert.Equal(t, "Western", areas[0].Name)
+	assert.Empty(t, areas[0].ParentCode)
+}
+
+func TestParse_Errors(t *testing.T) {
+	point := `{"type":"Point","coordinates":[79.8,6.9]}`
+	tests := []struct {
+		name  string
+		input string
+		opts  boundaries.Options
+	}{
+		{"not a collection", `{"type":"Feature"}`, boundaries.DefaultOptions(models.LevelProvince)},
+		{"unknown level", `{"type":"FeatureCollection","features":[]}`, boundaries.DefaultOptions("county")},
+		{"missing parent", `{"type":"FeatureCollection","features":[{"geometry":` + square + `,"properties":{"district_code":"1","district_name":"A"}}]}`, boundaries.DefaultOptions(models.LevelDistrict)},
+		{"point geometry", `{"type":"FeatureCollection","features":[{"geometry":` + point + `,"properties":{"province_code":"1","province_name":"A"}}]}`, boundaries.DefaultOptions(models.LevelProvince)},
+		{"no geometry", `{"type":"FeatureCollection","features":[{"geometry":null,"properties":{"province_code":"1","province_name":"A"}}]}`, boundaries.DefaultOptions(models.LevelProvince)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := boundaries.Parse(strings.NewReader(tt.input), tt.opts)
+			assert.Error(t, err)
+		})
+	}
+}
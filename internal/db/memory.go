@@ -0,0 +1,30 @@
+package db
+
+import (
+	"log"
+	"os"
+
+	"go-mysql-backend/internal/repository"
+
+	"github.com/joho/godotenv"
+)
+
+// InitMemory returns the store of the in-memory backend, loaded from the
+// MEMORY_FIXTURE file when it is set and empty otherwise, together with
+// MEMORY_SNAPSHOT, the file the store is saved to on shutdown. An empty
+// snapshot path means nothing is saved.
+func InitMemory() (*repository.MemoryStore, string, error) {
+	err := godotenv.Load()
+	if err != nil {
+		log.Println("No .env file found. Using system environment variables.")
+	}
+
+	store := repository.NewMemoryStore()
+	if fixture := os.Getenv("MEMORY_FIXTURE"); fixture != "" {
+		if store, err = repository.LoadMemoryStore(fixture); err != nil {
+			return nil, "", err
+		}
+		log.Println("✅ Loaded in-memory data from", fixture)
+	}
+	return store, os.Getenv("MEMORY_SNAPSHOT"), nil
+}
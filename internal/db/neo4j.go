@@ -5,11 +5,28 @@ import (
 	"log"
 	"os"
 
+	"go-mysql-backend/internal/migrations"
+
 	"github.com/joho/godotenv"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
-func InitNeo4j() (neo4j.DriverWithContext, error) {
+// InitNeo4j connects to Neo4j and, as schema says, applies or checks the
+// migrations before returning.
+func InitNeo4j(schema Schema) (neo4j.DriverWithContext, error) {
+	driver, err := ConnectNeo4j()
+	if err != nil {
+		return nil, err
+	}
+	if err := schema.prepare(migrations.NewNeo4jTarget(driver), migrations.Neo4j); err != nil {
+		driver.Close(context.Background())
+		return nil, err
+	}
+	return driver, nil
+}
+
+// ConnectNeo4j connects to Neo4j without looking at the schema.
+func ConnectNeo4j() (neo4j.DriverWithContext, error) {
 	ctx := context.Background()
 
 	err := godotenv.Load()
@@ -2,15 +2,32 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 
+	"go-mysql-backend/internal/migrations"
+
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 )
 
-func InitPostgres() *sql.DB {
+// InitPostgres connects to DATABASE_URL and, as schema says, applies or
+// checks the migrations before returning. It exits on failure.
+func InitPostgres(schema Schema) *sql.DB {
+	db, err := ConnectPostgres()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := schema.prepare(migrations.NewPostgresTarget(db), migrations.Postgres); err != nil {
+		log.Fatalf("PostgreSQL schema: %v", err)
+	}
+	return db
+}
+
+// ConnectPostgres connects to DATABASE_URL without looking at the schema.
+func ConnectPostgres() (*sql.DB, error) {
 	err := godotenv.Load()
 	if err != nil {
 		log.Println("No .env file found. Using system environment variables.")
@@ -18,19 +35,20 @@ func InitPostgres() *sql.DB {
 
 	dsn := os.Getenv("DATABASE_URL")
 	if dsn == "" {
-		log.Fatal("DATABASE_URL not set in environment variables")
+		return nil, errors.New("DATABASE_URL not set in environment variables")
 	}
 
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
-		log.Fatalf("Failed to open DB connection: %v", err)
+		return nil, fmt.Errorf("failed to open DB connection: %w", err)
 	}
 
 	// Verify DB connection
 	if err := db.Ping(); err != nil {
-		log.Fatalf("Cannot connect to DB: %v", err)
+		db.Close()
+		return nil, fmt.Errorf("cannot connect to DB: %w", err)
 	}
 
 	fmt.Println("✅ Connected to PostgreSQL")
-	return db
+	return db, nil
 }
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"go-mysql-backend/internal/migrations"
+)
+
+// Schema says what InitPostgres and InitNeo4j do about migrations the
+// database has not had yet.
+type Schema int
+
+const (
+	// RequireSchema refuses a database whose schema is older than the
+	// code, or was migrated by newer code.
+	RequireSchema Schema = iota
+	// MigrateSchema applies the pending migrations first.
+	MigrateSchema
+)
+
+// prepare applies or checks the migrations of dialect on target.
+func (s Schema) prepare(target migrations.Target, dialect migrations.Dialect) error {
+	all, err := migrations.Load(dialect)
+	if err != nil {
+		return err
+	}
+
+	if s == MigrateSchema {
+		applied, err := migrations.Up(target, all)
+		for _, m := range applied {
+			log.Printf("Applied migration %04d_%s", m.Version, m.Name)
+		}
+		return err
+	}
+
+	err = migrations.Check(target, all)
+	if errors.Is(err, migrations.ErrPending) {
+		return fmt.Errorf("%w; run `go run ./cmd/migrate up` or start the server with -migrate", err)
+	}
+	return err
+}
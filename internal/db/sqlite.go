@@ -0,0 +1,32 @@
+package db
+
+import (
+	"database/sql"
+	"log"
+	"os"
+
+	"go-mysql-backend/internal/repository"
+
+	"github.com/joho/godotenv"
+)
+
+// InitSQLite opens the SQLite database file named by SQLITE_PATH,
+// gov_geo.db by default, creating it and its schema when needed.
+func InitSQLite() (*sql.DB, error) {
+	err := godotenv.Load()
+	if err != nil {
+		log.Println("No .env file found. Using system environment variables.")
+	}
+
+	path := os.Getenv("SQLITE_PATH")
+	if path == "" {
+		path = "gov_geo.db"
+	}
+
+	db, err := repository.OpenSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Println("✅ Opened SQLite database", path)
+	return db, nil
+}
@@ -19,4 +19,41 @@ var (
 	ErrMissingField       = &APIError{Code: http.StatusBadRequest, Message: "Missing required field"}
 	ErrInvalidInput       = &APIError{Code: http.StatusBadRequest, Message: "Invalid input"}
 	ErrInternal           = &APIError{Code: http.StatusInternalServerError, Message: "Internal server error"}
+
+	ErrMinistryHasDepartments = &APIError{Code: http.StatusConflict, Message: "Ministry still has departments"}
+	ErrInvalidReassignTarget  = &APIError{Code: http.StatusBadRequest, Message: "Invalid reassignment target ministry"}
+	ErrUnknownMinistry        = &APIError{Code: http.StatusBadRequest, Message: "Referenced ministry does not exist"}
+	ErrUnknownReference       = &APIError{Code: http.StatusBadRequest, Message: "Referenced ministry, parent or administrative area does not exist"}
+	ErrInvalidParent          = &APIError{Code: http.StatusBadRequest, Message: "Parent would create a cycle or belongs to another ministry"}
+	ErrOrganizationNotFound   = &APIError{Code: http.StatusNotFound, Message: "Organization not found"}
+	ErrInvalidAsOf            = &APIError{Code: http.StatusBadRequest, Message: "as_of must be a date in YYYY-MM-DD format"}
+	ErrEffectiveDateOrder     = &APIError{Code: http.StatusConflict, Message: "Effective date is before the latest recorded change"}
+
+	ErrAdminAreaNotFound = &APIError{Code: http.StatusNotFound, Message: "Administrative area not found"}
+	ErrInvalidAdminLevel = &APIError{Code: http.StatusBadRequest, Message: "Invalid administrative level"}
+	ErrUnknownAdminArea  = &APIError{Code: http.StatusBadRequest, Message: "Referenced administrative area does not exist"}
+
+	ErrPersonNotFound     = &APIError{Code: http.StatusNotFound, Message: "Person not found"}
+	ErrPositionNotFound   = &APIError{Code: http.StatusNotFound, Message: "Position not found"}
+	ErrUnknownPositionRef = &APIError{Code: http.StatusBadRequest, Message: "Referenced person or organization does not exist"}
+	ErrInvalidTerm        = &APIError{Code: http.StatusBadRequest, Message: "A term must end after it starts"}
+
+	ErrOfficeNotFound = &APIError{Code: http.StatusNotFound, Message: "Office not found"}
+	ErrInvalidOpenAt  = &APIError{Code: http.StatusBadRequest, Message: "open_at must be an RFC 3339 time, e.g. 2024-03-04T10:00:00+05:30"}
+
+	ErrInvalidLang         = &APIError{Code: http.StatusBadRequest, Message: "lang must be si, ta or en"}
+	ErrInvalidSearch       = &APIError{Code: http.StatusBadRequest, Message: "q must be between 1 and 200 characters"}
+	ErrInvalidAutocomplete = &APIError{Code: http.StatusBadRequest, Message: "q must be between 1 and 100 characters"}
+
+	ErrInvalidCursor = &APIError{Code: http.StatusBadRequest, Message: "cursor is not valid; use next_cursor or prev_cursor from a page"}
+
+	ErrInvalidDryRun  = &APIError{Code: http.StatusBadRequest, Message: "dry_run must be true or false"}
+	ErrImportTooLarge = &APIError{Code: http.StatusRequestEntityTooLarge, Message: "Import file is larger than 10 MiB"}
+
+	ErrSeedUnsupported = &APIError{Code: http.StatusNotImplemented, Message: "Seeding is not supported by this database"}
 )
+
+// NewBadRequest wraps a validation message in a 400 API error.
+func NewBadRequest(message string) *APIError {
+	return &APIError{Code: http.StatusBadRequest, Message: message}
+}
@@ -0,0 +1,24 @@
+// Package geo holds the spherical geometry used by the location endpoints.
+package geo
+
+import "math"
+
+// EarthRadiusKm is the mean Earth radius (IUGG) used for great-circle
+// distances. The Postgres nearby query uses the same value.
+const EarthRadiusKm = 6371.0088
+
+// HaversineKm returns the great-circle distance in kilometres between two
+// points given in decimal degrees.
+func HaversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := radians(lat1)
+	phi2 := radians(lat2)
+	dPhi := radians(lat2 - lat1)
+	dLambda := radians(lon2 - lon1)
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
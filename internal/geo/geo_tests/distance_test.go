@@ -0,0 +1,38 @@
+package geo_test
+
+import (
+	"testing"
+
+	"go-mysql-backend/internal/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHaversineKm_KnownDistances(t *testing.T) {
+	cases := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		km                     float64
+	}{
+		{"London to Paris", 51.5074, -0.1278, 48.8566, 2.3522, 343.56},
+		{"Colombo to Kandy", 6.9271, 79.8612, 7.2906, 80.6337, 94.34},
+		{"Colombo to Jaffna", 6.9271, 79.8612, 9.6615, 80.0255, 304.59},
+		{"Colombo Fort to Colombo 01", 6.9344, 79.8428, 6.9271, 79.8612, 2.19},
+		{"Quarter meridian", 0, 0, 90, 0, 10007.56},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.km, geo.HaversineKm(tc.lat1, tc.lon1, tc.lat2, tc.lon2), 0.01)
+		})
+	}
+}
+
+func TestHaversineKm_Properties(t *testing.T) {
+	assert.Equal(t, 0.0, geo.HaversineKm(6.9271, 79.8612, 6.9271, 79.8612))
+	assert.InDelta(t,
+		geo.HaversineKm(6.9271, 79.8612, 7.2906, 80.6337),
+		geo.HaversineKm(7.2906, 80.6337, 6.9271, 79.8612), 1e-9)
+	// Across the antimeridian the short way round is used.
+	assert.InDelta(t, 222.39, geo.HaversineKm(0, 179, 0, -179), 0.01)
+}
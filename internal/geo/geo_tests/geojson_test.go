@@ -0,0 +1,108 @@
+package geo_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go-mysql-backend/internal/geo"
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinistriesFeatureCollection(t *testing.T) {
+	lat, lon := 6.9271, 79.8612
+	ministries := []models.MinistryWithDepartments{
+		{
+			Ministry: models.Ministry{ID: 1, Name: "Ministry of Health",
+				Location: models.Location{Latitude: &lat, Longitude: &lon}},
+			Departments: []models.Department{
+				{ID: 10, Name: "Medical Supplies", MinistryID: 1,
+					Location: models.Location{Geometry: json.RawMessage(`{"type":"Polygon","coordinates":[[[79.8,6.9],[79.9,6.9],[79.9,7.0],[79.8,6.9]]]}`)}},
+				{ID: 11, Name: "Epidemiology", MinistryID: 1},
+			},
+		},
+	}
+
+	data, err := json.Marshal(geo.MinistriesFeatureCollection(ministries))
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"type": "FeatureCollection",
+		"features": [
+			{
+				"type": "Feature",
+				"id": "ministry/1",
+				"geometry": {"type": "Point", "coordinates": [79.8612, 6.9271]},
+				"properties": {"entity_type": "ministry", "id": 1, "name": "Ministry of Health", "address": "", "google_map_script": ""}
+			},
+			{
+				"type": "Feature",
+				"id": "department/10",
+				"geometry": {"type":"Polygon","coordinates":[[[79.8,6.9],[79.9,6.9],[79.9,7.0],[79.8,6.9]]]},
+				"properties": {"entity_type": "department", "id": 10, "name": "Medical Supplies", "ministry_id": 1, "address": "", "google_map_script": ""}
+			},
+			{
+				"type": "Feature",
+				"id": "department/11",
+				"geometry": null,
+				"properties": {"entity_type": "department", "id": 11, "name": "Epidemiology", "ministry_id": 1, "address": "", "google_map_script": ""}
+			}
+		]
+	}`, string(data))
+}
+
+func TestToGeoJSON_Viewport(t *testing.T) {
+	lat, lon := 7.2906, 80.6337
+	viewport := models.DepartmentViewport{
+		Departments: []models.Department{{ID: 5, Name: "Kandy Office", MinistryID: 2,
+			Location: models.Location{Latitude: &lat, Longitude: &lon}}},
+		Count:     1,
+		Limit:     1,
+		Truncated: true,
+	}
+
+	payload, ok := geo.ToGeoJSON(viewport)
+	assert.True(t, ok)
+
+	data, err := json.Marshal(payload)
+	assert.NoError(t, err)
+
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "FeatureCollection", decoded["type"])
+	assert.Equal(t, true, decoded["truncated"])
+	assert.Len(t, decoded["features"], 1)
+}
+
+func TestToGeoJSON_Unsupported(t *testing.T) {
+	_, ok := geo.ToGeoJSON(map[string]string{"message": "ok"})
+	assert.False(t, ok)
+
+	payload, ok := geo.ToGeoJSON([]models.Department{})
+	assert.True(t, ok)
+	data, _ := json.Marshal(payload)
+	assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, string(data))
+}
+
+func TestToGeoJSON_Offices(t *testing.T) {
+	lat, lon := 7.2906, 80.6337
+	offices := []models.Office{{ID: 3, DepartmentID: 5, Name: "Kandy Office", PhoneNumbers: []string{"0812223344"},
+		Location: models.Location{Latitude: &lat, Longitude: &lon}}}
+
+	payload, ok := geo.ToGeoJSON(offices)
+	assert.True(t, ok)
+
+	data, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "FeatureCollection",
+		"features": [{
+			"type": "Feature",
+			"id": "office/3",
+			"geometry": {"type": "Point", "coordinates": [80.6337, 7.2906]},
+			"properties": {"entity_type": "office", "id": 3, "name": "Kandy Office", "department_id": 5,
+				"address": "", "phone_numbers": ["0812223344"], "email": ""}
+		}]
+	}`, string(data))
+}
@@ -0,0 +1,62 @@
+package geo_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go-mysql-backend/internal/geo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A 2x2 degree square with a 1x1 degree hole in the middle.
+const squareWithHole = `{"type":"Polygon","coordinates":[
+	[[79,6],[81,6],[81,8],[79,8],[79,6]],
+	[[79.5,6.5],[80.5,6.5],[80.5,7.5],[79.5,7.5],[79.5,6.5]]
+]}`
+
+func TestParseMultiPolygon_Contains(t *testing.T) {
+	shape, err := geo.ParseMultiPolygon(json.RawMessage(squareWithHole))
+	require.NoError(t, err)
+
+	assert.True(t, shape.Contains(79.2, 6.2))
+	assert.False(t, shape.Contains(80, 7), "point in the hole")
+	assert.False(t, shape.Contains(82, 7), "point outside")
+}
+
+func TestParseMultiPolygon_MultiPolygon(t *testing.T) {
+	shape, err := geo.ParseMultiPolygon(json.RawMessage(`{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[1,0],[1,1],[0,1],[0,0]]],
+		[[[5,5],[6,5],[6,6],[5,6],[5,5]]]
+	]}`))
+	require.NoError(t, err)
+
+	assert.True(t, shape.Contains(0.5, 0.5))
+	assert.True(t, shape.Contains(5.5, 5.5))
+	assert.False(t, shape.Contains(3, 3))
+	assert.Equal(t, 0.0, shape.Bounds().MinLon)
+	assert.Equal(t, 6.0, shape.Bounds().MaxLat)
+}
+
+func TestParseMultiPolygon_RejectsPoints(t *testing.T) {
+	_, err := geo.ParseMultiPolygon(json.RawMessage(`{"type":"Point","coordinates":[80,7]}`))
+	assert.ErrorIs(t, err, geo.ErrNotPolygonal)
+}
+
+func TestIndex_Query(t *testing.T) {
+	outer, err := geo.ParseMultiPolygon(json.RawMessage(`{"type":"Polygon","coordinates":[[[79,6],[82,6],[82,10],[79,10],[79,6]]]}`))
+	require.NoError(t, err)
+	inner, err := geo.ParseMultiPolygon(json.RawMessage(`{"type":"Polygon","coordinates":[[[79.8,6.8],[80.0,6.8],[80.0,7.0],[79.8,7.0],[79.8,6.8]]]}`))
+	require.NoError(t, err)
+
+	ix := geo.NewIndex(0.25)
+	ix.Insert(1, outer)
+	ix.Insert(2, inner)
+	ix.Insert(3, geo.MultiPolygon{})
+
+	assert.Equal(t, 2, ix.Len())
+	assert.Equal(t, []int{1, 2}, ix.Query(79.9, 6.9))
+	assert.Equal(t, []int{1}, ix.Query(81.5, 9.5))
+	assert.Empty(t, ix.Query(78, 6.9))
+}
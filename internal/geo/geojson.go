@@ -0,0 +1,247 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+)
+
+// MediaType is the RFC 7946 media type for GeoJSON.
+const MediaType = "application/geo+json"
+
+// FeatureCollection is an RFC 7946 FeatureCollection. Members holds extra
+// top-level members such as the truncation flag of viewport queries.
+type FeatureCollection struct {
+	Features []Feature
+	Members  map[string]interface{}
+}
+
+// Feature is an RFC 7946 Feature. A nil Geometry is encoded as null.
+type Feature struct {
+	ID         string                 `json:"id"`
+	Geometry   json.RawMessage        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+func (f Feature) MarshalJSON() ([]byte, error) {
+	geometry := f.Geometry
+	if len(geometry) == 0 {
+		geometry = json.RawMessage("null")
+	}
+	return json.Marshal(struct {
+		Type       string                 `json:"type"`
+		ID         string                 `json:"id"`
+		Geometry   json.RawMessage        `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}{"Feature", f.ID, geometry, f.Properties})
+}
+
+func (fc FeatureCollection) MarshalJSON() ([]byte, error) {
+	out := make(map[string]interface{}, len(fc.Members)+2)
+	for k, v := range fc.Members {
+		out[k] = v
+	}
+	features := fc.Features
+	if features == nil {
+		features = []Feature{}
+	}
+	out["type"] = "FeatureCollection"
+	out["features"] = features
+	return json.Marshal(out)
+}
+
+// LocationGeometry returns the stored geometry of loc, or a Point built from
+// its coordinates, or nil when the location is unknown.
+func LocationGeometry(loc models.Location) json.RawMessage {
+	if len(loc.Geometry) > 0 && string(loc.Geometry) != "null" {
+		return loc.Geometry
+	}
+	if loc.HasCoordinates() {
+		point, _ := json.Marshal(map[string]interface{}{
+			"type":        "Point",
+			"coordinates": []float64{*loc.Longitude, *loc.Latitude},
+		})
+		return point
+	}
+	return nil
+}
+
+// MinistryFeature converts a ministry to a Feature.
+func MinistryFeature(m models.Ministry) Feature {
+	return Feature{
+		ID:       fmt.Sprintf("ministry/%d", m.ID),
+		Geometry: LocationGeometry(m.Location),
+		Properties: map[string]interface{}{
+			"entity_type":       "ministry",
+			"id":                m.ID,
+			"name":              m.Name,
+			"address":           m.Address,
+			"google_map_script": m.Google_map_script,
+		},
+	}
+}
+
+// DepartmentFeature converts a department to a Feature carrying its parent
+// ministry id.
+func DepartmentFeature(d models.Department) Feature {
+	return Feature{
+		ID:       fmt.Sprintf("department/%d", d.ID),
+		Geometry: LocationGeometry(d.Location),
+		Properties: map[string]interface{}{
+			"entity_type":       "department",
+			"id":                d.ID,
+			"name":              d.Name,
+			"ministry_id":       d.MinistryID,
+			"address":           d.Address,
+			"google_map_script": d.Google_map_script,
+		},
+	}
+}
+
+// OfficeFeature converts a department office to a Feature carrying its
+// department id, contact details and, when known, open_now.
+func OfficeFeature(o models.Office) Feature {
+	properties := map[string]interface{}{
+		"entity_type":   "office",
+		"id":            o.ID,
+		"name":          o.Name,
+		"department_id": o.DepartmentID,
+		"address":       o.Address,
+		"phone_numbers": o.PhoneNumbers,
+		"email":         o.Email,
+	}
+	if o.OpenNow != nil {
+		properties["open_now"] = *o.OpenNow
+	}
+	return Feature{
+		ID:         fmt.Sprintf("office/%d", o.ID),
+		Geometry:   LocationGeometry(o.Location),
+		Properties: properties,
+	}
+}
+
+// MinistriesFeatureCollection returns one Feature per ministry followed by
+// one per department.
+func MinistriesFeatureCollection(ministries []models.MinistryWithDepartments) FeatureCollection {
+	var fc FeatureCollection
+	for _, m := range ministries {
+		fc.Features = append(fc.Features, MinistryFeature(m.Ministry))
+		for _, d := range m.Departments {
+			fc.Features = append(fc.Features, DepartmentFeature(d))
+		}
+	}
+	return fc
+}
+
+// DepartmentsFeatureCollection returns one Feature per department.
+func DepartmentsFeatureCollection(departments []models.Department) FeatureCollection {
+	var fc FeatureCollection
+	for _, d := range departments {
+		fc.Features = append(fc.Features, DepartmentFeature(d))
+	}
+	return fc
+}
+
+// AdminAreaFeature converts an administrative area to a Feature. Areas
+// listed without geometry get a null geometry.
+func AdminAreaFeature(a models.AdminArea) Feature {
+	properties := map[string]interface{}{
+		"entity_type": "admin_area",
+		"id":          a.ID,
+		"code":        a.Code,
+		"name":        a.Name,
+		"level":       a.Level,
+	}
+	if a.ParentID != nil {
+		properties["parent_id"] = *a.ParentID
+	}
+	return Feature{
+		ID:         fmt.Sprintf("area/%d", a.ID),
+		Geometry:   a.Geometry,
+		Properties: properties,
+	}
+}
+
+// ToGeoJSON converts the payload of a read endpoint to GeoJSON. The second
+// result is false for payloads that have no geographic representation.
+func ToGeoJSON(payload interface{}) (interface{}, bool) {
+	switch v := payload.(type) {
+	case models.Ministry:
+		return MinistryFeature(v), true
+	case models.MinistryWithDepartments:
+		return MinistriesFeatureCollection([]models.MinistryWithDepartments{v}), true
+	case []models.MinistryWithDepartments:
+		return MinistriesFeatureCollection(v), true
+	case models.Department:
+		return DepartmentFeature(v), true
+	case *models.Department:
+		return DepartmentFeature(*v), true
+	case []models.Department:
+		return DepartmentsFeatureCollection(v), true
+	case []models.NearbyDepartment:
+		var fc FeatureCollection
+		for _, d := range v {
+			feature := DepartmentFeature(d.Department)
+			feature.Properties["distance_km"] = d.DistanceKm
+			fc.Features = append(fc.Features, feature)
+		}
+		return fc, true
+	case models.MinistryPage:
+		fc := MinistriesFeatureCollection(v.Items)
+		fc.Members = pageMembers(v.Total, v.Limit, v.Offset, v.Next, v.Prev, v.NextCursor, v.PrevCursor)
+		return fc, true
+	case models.DepartmentPage:
+		fc := DepartmentsFeatureCollection(v.Items)
+		fc.Members = pageMembers(v.Total, v.Limit, v.Offset, v.Next, v.Prev, v.NextCursor, v.PrevCursor)
+		return fc, true
+	case models.DepartmentViewport:
+		fc := DepartmentsFeatureCollection(v.Departments)
+		fc.Members = map[string]interface{}{"count": v.Count, "limit": v.Limit, "truncated": v.Truncated}
+		return fc, true
+	case *models.Office:
+		return OfficeFeature(*v), true
+	case []models.Office:
+		var fc FeatureCollection
+		for _, o := range v {
+			fc.Features = append(fc.Features, OfficeFeature(o))
+		}
+		return fc, true
+	case []models.NearbyOffice:
+		var fc FeatureCollection
+		for _, o := range v {
+			feature := OfficeFeature(o.Office)
+			feature.Properties["distance_km"] = o.DistanceKm
+			fc.Features = append(fc.Features, feature)
+		}
+		return fc, true
+	case *models.AdminArea:
+		return AdminAreaFeature(*v), true
+	case []models.AdminArea:
+		var fc FeatureCollection
+		for _, a := range v {
+			fc.Features = append(fc.Features, AdminAreaFeature(a))
+		}
+		return fc, true
+	case models.MinistryViewport:
+		var fc FeatureCollection
+		for _, m := range v.Ministries {
+			fc.Features = append(fc.Features, MinistryFeature(m))
+		}
+		fc.Members = map[string]interface{}{"count": v.Count, "limit": v.Limit, "truncated": v.Truncated}
+		return fc, true
+	}
+	return nil, false
+}
+
+// pageMembers carries the paging fields of a page as foreign members of its
+// feature collection, leaving out the empty links and cursors.
+func pageMembers(total, limit, offset int, next, prev, nextCursor, prevCursor string) map[string]interface{} {
+	members := map[string]interface{}{"total": total, "limit": limit, "offset": offset}
+	for key, value := range map[string]string{"next": next, "prev": prev, "next_cursor": nextCursor, "prev_cursor": prevCursor} {
+		if value != "" {
+			members[key] = value
+		}
+	}
+	return members
+}
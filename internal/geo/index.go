@@ -0,0 +1,83 @@
+package geo
+
+import (
+	"math"
+
+	"go-mysql-backend/internal/models"
+)
+
+// DefaultCellSizeDeg is the grid spacing used by NewIndex when none is
+// given. A quarter degree is roughly 28 km, so a GN division touches one or
+// two cells and a province a few dozen.
+const DefaultCellSizeDeg = 0.25
+
+// Index is an in-memory grid index of polygonal shapes keyed by an int id.
+// Each shape is registered in every cell its bounding box overlaps, and a
+// point query runs the exact containment test only on the shapes of the
+// cell the point falls in. An Index is not safe for concurrent Insert, but
+// any number of Query calls may run once it is built.
+type Index struct {
+	cellSize float64
+	cells    map[[2]int][]int
+	entries  []indexEntry
+}
+
+type indexEntry struct {
+	key    int
+	shape  MultiPolygon
+	bounds models.BBox
+}
+
+// NewIndex returns an empty index with the given cell size in degrees.
+func NewIndex(cellSizeDeg float64) *Index {
+	if cellSizeDeg <= 0 {
+		cellSizeDeg = DefaultCellSizeDeg
+	}
+	return &Index{cellSize: cellSizeDeg, cells: make(map[[2]int][]int)}
+}
+
+// Insert adds a shape under key. Empty shapes are ignored.
+func (ix *Index) Insert(key int, shape MultiPolygon) {
+	bounds := shape.Bounds()
+	if bounds.MinLon > bounds.MaxLon || bounds.MinLat > bounds.MaxLat {
+		return
+	}
+
+	entry := len(ix.entries)
+	ix.entries = append(ix.entries, indexEntry{key: key, shape: shape, bounds: bounds})
+
+	minX, minY := ix.cell(bounds.MinLon, bounds.MinLat)
+	maxX, maxY := ix.cell(bounds.MaxLon, bounds.MaxLat)
+	for x := minX; x <= maxX; x++ {
+		for y := minY; y <= maxY; y++ {
+			ix.cells[[2]int{x, y}] = append(ix.cells[[2]int{x, y}], entry)
+		}
+	}
+}
+
+// Query returns the keys of the shapes containing the point, in insertion
+// order.
+func (ix *Index) Query(lon, lat float64) []int {
+	var keys []int
+	for _, entry := range ix.cells[ix.cellOf(lon, lat)] {
+		e := ix.entries[entry]
+		if e.bounds.Contains(lat, lon) && e.shape.Contains(lon, lat) {
+			keys = append(keys, e.key)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of shapes in the index.
+func (ix *Index) Len() int {
+	return len(ix.entries)
+}
+
+func (ix *Index) cell(lon, lat float64) (int, int) {
+	return int(math.Floor(lon / ix.cellSize)), int(math.Floor(lat / ix.cellSize))
+}
+
+func (ix *Index) cellOf(lon, lat float64) [2]int {
+	x, y := ix.cell(lon, lat)
+	return [2]int{x, y}
+}
@@ -0,0 +1,98 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+
+	"go-mysql-backend/internal/models"
+)
+
+// ErrNotPolygonal is returned by ParseMultiPolygon for geometries that do
+// not enclose an area, such as points and lines.
+var ErrNotPolygonal = errors.New("geometry is not a Polygon or MultiPolygon")
+
+// Ring is a closed linear ring of [lon, lat] positions.
+type Ring [][2]float64
+
+// MultiPolygon is a list of polygons, each an outer ring followed by its
+// holes. A GeoJSON Polygon is parsed as a MultiPolygon of one.
+type MultiPolygon [][]Ring
+
+// ParseMultiPolygon decodes a GeoJSON Polygon or MultiPolygon.
+func ParseMultiPolygon(geometry json.RawMessage) (MultiPolygon, error) {
+	var g struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(geometry, &g); err != nil {
+		return nil, err
+	}
+
+	switch g.Type {
+	case "Polygon":
+		var polygon []Ring
+		if err := json.Unmarshal(g.Coordinates, &polygon); err != nil {
+			return nil, err
+		}
+		return MultiPolygon{polygon}, nil
+	case "MultiPolygon":
+		var mp MultiPolygon
+		if err := json.Unmarshal(g.Coordinates, &mp); err != nil {
+			return nil, err
+		}
+		return mp, nil
+	}
+	return nil, ErrNotPolygonal
+}
+
+// Contains reports whether the point lies inside the shape and outside its
+// holes. Points exactly on an edge may fall either side.
+func (mp MultiPolygon) Contains(lon, lat float64) bool {
+	for _, polygon := range mp {
+		if len(polygon) == 0 || !polygon[0].contains(lon, lat) {
+			continue
+		}
+		inHole := false
+		for _, hole := range polygon[1:] {
+			if hole.contains(lon, lat) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// Bounds returns the bounding box of the outer rings.
+func (mp MultiPolygon) Bounds() models.BBox {
+	b := models.BBox{MinLon: math.Inf(1), MinLat: math.Inf(1), MaxLon: math.Inf(-1), MaxLat: math.Inf(-1)}
+	for _, polygon := range mp {
+		if len(polygon) == 0 {
+			continue
+		}
+		for _, p := range polygon[0] {
+			b.MinLon = math.Min(b.MinLon, p[0])
+			b.MaxLon = math.Max(b.MaxLon, p[0])
+			b.MinLat = math.Min(b.MinLat, p[1])
+			b.MaxLat = math.Max(b.MaxLat, p[1])
+		}
+	}
+	return b
+}
+
+// contains is the even-odd ray casting test.
+func (r Ring) contains(lon, lat float64) bool {
+	inside := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		xi, yi := r[i][0], r[i][1]
+		xj, yj := r[j][0], r[j][1]
+		if (yi > lat) != (yj > lat) && lon < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+	"go-mysql-backend/internal/service"
+)
+
+type AdminAreaHandler struct {
+	Service *service.AdminAreaService
+}
+
+func NewAdminAreaHandler(service *service.AdminAreaService) *AdminAreaHandler {
+	return &AdminAreaHandler{Service: service}
+}
+
+// GetAreas lists the areas of one level, provinces by default.
+func (h *AdminAreaHandler) GetAreas(w http.ResponseWriter, r *http.Request) {
+	level := models.LevelProvince
+	if v := r.URL.Query().Get("level"); v != "" {
+		level = models.AdminLevel(v)
+	}
+	if !level.Valid() {
+		respondWithError(w, apierrors.ErrInvalidAdminLevel)
+		return
+	}
+
+	areas, err := h.Service.GetAreas(level)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, areas)
+}
+
+func (h *AdminAreaHandler) GetAreaByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	area, err := h.Service.GetAreaByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		respondWithError(w, apierrors.ErrAdminAreaNotFound)
+		return
+	} else if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, area)
+}
+
+func (h *AdminAreaHandler) GetChildAreas(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	areas, err := h.Service.GetChildAreas(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		respondWithError(w, apierrors.ErrAdminAreaNotFound)
+		return
+	} else if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, areas)
+}
+
+// GetAreaDepartments lists the departments in the area and in every area
+// below it.
+func (h *AdminAreaHandler) GetAreaDepartments(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	departments, err := h.Service.GetAreaDepartments(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		respondWithError(w, apierrors.ErrAdminAreaNotFound)
+		return
+	} else if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, departments)
+}
+
+// SetDepartmentArea links a department to an area, or unlinks it when
+// admin_area_id is null.
+func (h *AdminAreaHandler) SetDepartmentArea(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	var body struct {
+		AdminAreaID *int `json:"admin_area_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+	defer r.Body.Close()
+
+	err = h.Service.SetDepartmentArea(id, body.AdminAreaID)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		respondWithError(w, apierrors.ErrDepartmentNotFound)
+		return
+	case errors.Is(err, repository.ErrInvalidReference):
+		respondWithError(w, apierrors.ErrUnknownAdminArea)
+		return
+	case err != nil:
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message":       "Department area updated successfully",
+		"id":            id,
+		"admin_area_id": body.AdminAreaID,
+	})
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/translit"
+)
+
+const (
+	defaultAutocompleteLimit = 10
+	maxAutocompleteLimit     = 50
+	maxAutocompleteLength    = 100
+)
+
+// getAutocompleteQueryFromRequest reads q, an optional comma-separated
+// types filter and limit from the query string.
+func getAutocompleteQueryFromRequest(r *http.Request) (models.AutocompleteQuery, error) {
+	query := r.URL.Query()
+
+	q := models.AutocompleteQuery{
+		Text:     translit.Normalize(strings.TrimSpace(query.Get("q"))),
+		Entities: models.AutocompleteEntities,
+		Limit:    defaultAutocompleteLimit,
+	}
+	if q.Text == "" || utf8.RuneCountInString(q.Text) > maxAutocompleteLength {
+		return models.AutocompleteQuery{}, apierrors.ErrInvalidAutocomplete
+	}
+
+	if types := query.Get("types"); types != "" {
+		q.Entities = nil
+		for _, value := range strings.Split(types, ",") {
+			entity := models.SearchEntity(strings.TrimSpace(value))
+			if entity != models.SearchMinistry && entity != models.SearchDepartment {
+				return models.AutocompleteQuery{}, apierrors.NewBadRequest("types must list ministry or department")
+			}
+			q.Entities = append(q.Entities, entity)
+		}
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > maxAutocompleteLimit {
+			return models.AutocompleteQuery{}, apierrors.NewBadRequest("limit must be between 1 and 50")
+		}
+		q.Limit = limit
+	}
+	return q, nil
+}
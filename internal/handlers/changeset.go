@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+)
+
+// getChangeSetFromRequest decodes a change set and validates each of its
+// operations before any is applied.
+func getChangeSetFromRequest(r *http.Request) (models.ChangeSet, error) {
+	var cs models.ChangeSet
+	if err := json.NewDecoder(r.Body).Decode(&cs); err != nil {
+		return models.ChangeSet{}, apierrors.ErrInvalidInput
+	}
+	defer r.Body.Close()
+
+	if err := cs.Validate(); err != nil {
+		return models.ChangeSet{}, err
+	}
+	return cs, nil
+}
+
+// respondWithChangeSetError reports a rejected change set, naming the
+// operation that failed when there is one.
+func respondWithChangeSetError(w http.ResponseWriter, err error) {
+	var opErr *models.ChangeOpError
+	if !errors.As(err, &opErr) {
+		if errors.Is(err, models.ErrInvalidChange) {
+			err = apierrors.NewBadRequest(err.Error())
+		}
+		respondWithError(w, err)
+		return
+	}
+
+	var apiErr *apierrors.APIError
+	switch {
+	case errors.Is(err, models.ErrInvalidChange), errors.Is(err, models.ErrInvalidKind):
+		apiErr = apierrors.NewBadRequest(opErr.Err.Error())
+	case errors.Is(err, repository.ErrNotFound):
+		apiErr = apierrors.ErrOrganizationNotFound
+	case errors.Is(err, repository.ErrInvalidReference):
+		apiErr = apierrors.ErrUnknownReference
+	case errors.Is(err, repository.ErrInvalidParent):
+		apiErr = apierrors.ErrInvalidParent
+	case errors.Is(err, repository.ErrEffectiveDateOrder):
+		apiErr = apierrors.ErrEffectiveDateOrder
+	default:
+		apiErr = apierrors.ErrInternal
+	}
+	respondWithJSON(w, apiErr.Code, map[string]interface{}{
+		"error":     apiErr.Message,
+		"operation": opErr.Index,
+		"type":      opErr.Type,
+	})
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+)
+
+const maxOrgTreeDepth = 10
+
+// getOrgTreeQueryFromRequest reads the organisation as getOrgRefFromRequest
+// does, and the optional depth query parameter (maxOrgTreeDepth by
+// default).
+func getOrgTreeQueryFromRequest(r *http.Request) (models.OrgRef, int, error) {
+	root, err := getOrgRefFromRequest(r)
+	if err != nil {
+		return models.OrgRef{}, 0, err
+	}
+
+	depth := maxOrgTreeDepth
+	if depthStr := r.URL.Query().Get("depth"); depthStr != "" {
+		depth, err = strconv.Atoi(depthStr)
+		if err != nil || depth < 0 || depth > maxOrgTreeDepth {
+			return models.OrgRef{}, 0, apierrors.NewBadRequest("depth must be between 0 and 10")
+		}
+	}
+	return root, depth, nil
+}
+
+// getOrgRefFromRequest reads the {id} path variable and the optional
+// entity query parameter (ministry by default).
+func getOrgRefFromRequest(r *http.Request) (models.OrgRef, error) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		return models.OrgRef{}, apierrors.ErrInvalidInput
+	}
+
+	ref := models.OrgRef{Entity: models.EntityMinistry, ID: id}
+	if entity := r.URL.Query().Get("entity"); entity != "" {
+		ref.Entity = models.OrgEntity(entity)
+		if !ref.Entity.Valid() {
+			return models.OrgRef{}, apierrors.NewBadRequest("entity must be ministry or department")
+		}
+	}
+	return ref, nil
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"net/http"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+)
+
+// getAsOfFromRequest reads the optional as_of=YYYY-MM-DD query parameter.
+// It returns nil when the current structure was asked for.
+func getAsOfFromRequest(r *http.Request) (*models.Date, error) {
+	value := r.URL.Query().Get("as_of")
+	if value == "" {
+		return nil, nil
+	}
+	date, err := models.ParseDate(value)
+	if err != nil {
+		return nil, apierrors.ErrInvalidAsOf
+	}
+	return &date, nil
+}
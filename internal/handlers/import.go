@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/importer"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+)
+
+// maxImportBytes caps the size of an import file. The whole file is held in
+// memory while its rows are checked.
+const maxImportBytes = 10 << 20
+
+// getImportFromRequest reads an import file from the body, as CSV when the
+// Content-Type is text/csv and as JSON otherwise, and whether it is a dry
+// run.
+func getImportFromRequest(w http.ResponseWriter, r *http.Request) (importer.Batch, bool, error) {
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			return importer.Batch{}, false, apierrors.ErrInvalidDryRun
+		}
+	}
+
+	format := importer.JSON
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/csv" {
+		format = importer.CSV
+	}
+	defer r.Body.Close()
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportBytes))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return importer.Batch{}, false, apierrors.ErrImportTooLarge
+	} else if err != nil {
+		return importer.Batch{}, false, apierrors.ErrInvalidInput
+	}
+	batch, err := importer.Parse(bytes.NewReader(body), format)
+	if err != nil {
+		return importer.Batch{}, false, apierrors.NewBadRequest(err.Error())
+	}
+	return batch, dryRun, nil
+}
+
+// Import creates ministries, departments and offices from a CSV or JSON
+// file, all of them or, when a row is invalid, none. The response reports
+// on every row; with dry_run=true nothing is written.
+func (h *OrganizationHandler) Import(w http.ResponseWriter, r *http.Request) {
+	batch, dryRun, err := getImportFromRequest(w, r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	report, err := h.Service.Import(batch, importer.Options{DryRun: dryRun})
+	if errors.Is(err, importer.ErrInvalidRows) {
+		respondWithJSON(w, http.StatusUnprocessableEntity, report)
+		return
+	} else if err != nil {
+		respondWithImportError(w, err)
+		return
+	}
+
+	code := http.StatusCreated
+	if dryRun {
+		code = http.StatusOK
+	}
+	respondWithJSON(w, code, report)
+}
+
+// respondWithImportError reports a valid import that could not be
+// written, naming the row that failed when there is one. That happens when
+// a referenced record is deleted after the rows were checked.
+func respondWithImportError(w http.ResponseWriter, err error) {
+	var rowErr *models.ImportRowError
+	if !errors.As(err, &rowErr) {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+
+	apiErr := apierrors.ErrInternal
+	switch {
+	case errors.Is(err, repository.ErrInvalidReference):
+		apiErr = apierrors.ErrUnknownReference
+	case errors.Is(err, repository.ErrInvalidParent):
+		apiErr = apierrors.ErrInvalidParent
+	}
+	respondWithJSON(w, apiErr.Code, map[string]interface{}{
+		"error":  apiErr.Message,
+		"entity": rowErr.Entity,
+		"row":    rowErr.Row,
+	})
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/service"
+)
+
+type JurisdictionHandler struct {
+	Service *service.JurisdictionService
+}
+
+func NewJurisdictionHandler(service *service.JurisdictionService) *JurisdictionHandler {
+	return &JurisdictionHandler{Service: service}
+}
+
+// Lookup returns the administrative areas containing lat/lon and the
+// departments responsible for them.
+func (h *JurisdictionHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	lat, lon, err := getPointFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	jurisdiction, err := h.Service.Lookup(lat, lon)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, jurisdiction)
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+)
+
+// getLocaleFromRequest picks the language of a response: the lang query
+// parameter when given, otherwise the most preferred supported language of
+// the Accept-Language header, otherwise the default.
+func getLocaleFromRequest(r *http.Request) (models.Locale, error) {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		locale, ok := models.ParseLocale(lang)
+		if !ok {
+			return "", apierrors.ErrInvalidLang
+		}
+		return locale, nil
+	}
+	return negotiateLocale(r.Header.Get("Accept-Language")), nil
+}
+
+// negotiateLocale reads an Accept-Language header such as
+// "ta-LK, si;q=0.8, *;q=0.1". Ranges are tried by decreasing quality, in
+// header order when equal; "*" stands for the default locale.
+func negotiateLocale(header string) models.Locale {
+	type languageRange struct {
+		tag     string
+		quality float64
+	}
+
+	var ranges []languageRange
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			ranges = append(ranges, languageRange{tag, quality})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	for _, lr := range ranges {
+		if lr.tag == "*" {
+			return models.DefaultLocale
+		}
+		if locale, ok := models.ParseLocale(lr.tag); ok {
+			return locale
+		}
+	}
+	return models.DefaultLocale
+}
+
+// localize sets the names in payload to locale. Slices and pointers are
+// updated in place; other values are returned localized.
+func localize(payload interface{}, locale models.Locale) interface{} {
+	switch v := payload.(type) {
+	case models.Ministry:
+		v.Localize(locale)
+		return v
+	case *models.Ministry:
+		if v != nil {
+			v.Localize(locale)
+		}
+	case models.MinistryWithDepartments:
+		v.Localize(locale)
+		return v
+	case []models.MinistryWithDepartments:
+		for i := range v {
+			v[i].Localize(locale)
+		}
+	case models.Department:
+		v.Localize(locale)
+		return v
+	case *models.Department:
+		if v != nil {
+			v.Localize(locale)
+		}
+	case []models.Department:
+		localizeDepartments(v, locale)
+	case []models.NearbyDepartment:
+		for i := range v {
+			v[i].Localize(locale)
+		}
+	case models.DepartmentViewport:
+		localizeDepartments(v.Departments, locale)
+	case models.MinistryViewport:
+		for i := range v.Ministries {
+			v.Ministries[i].Localize(locale)
+		}
+	case models.Jurisdiction:
+		localizeDepartments(v.Departments, locale)
+	case models.OrgNode:
+		v.Localize(locale)
+		return v
+	case models.SearchResult:
+		v.Localize(locale)
+	case models.AutocompleteResult:
+		v.Localize(locale)
+	case models.MinistryPage:
+		v.Localize(locale)
+	case models.DepartmentPage:
+		v.Localize(locale)
+	}
+	return payload
+}
+
+func localizeDepartments(departments []models.Department, locale models.Locale) {
+	for i := range departments {
+		departments[i].Localize(locale)
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+)
+
+const (
+	defaultNearbyRadiusKm = 10.0
+	maxNearbyRadiusKm     = 500.0
+	defaultNearbyLimit    = 20
+	maxNearbyLimit        = 100
+
+	defaultViewportLimit = 200
+	maxViewportLimit     = 500
+)
+
+// getNearbyQueryFromRequest reads lat, lon, radius_km and limit from the
+// query string. lat and lon are required; the others have defaults and are
+// capped so a single request cannot scan the whole table.
+func getNearbyQueryFromRequest(r *http.Request) (models.NearbyQuery, error) {
+	query := r.URL.Query()
+
+	lat, lon, err := getPointFromRequest(r)
+	if err != nil {
+		return models.NearbyQuery{}, err
+	}
+
+	nearby := models.NearbyQuery{
+		Latitude:  lat,
+		Longitude: lon,
+		RadiusKm:  defaultNearbyRadiusKm,
+		Limit:     defaultNearbyLimit,
+	}
+
+	if radiusStr := query.Get("radius_km"); radiusStr != "" {
+		radius, err := strconv.ParseFloat(radiusStr, 64)
+		if err != nil || math.IsNaN(radius) || radius <= 0 || radius > maxNearbyRadiusKm {
+			return models.NearbyQuery{}, apierrors.NewBadRequest("radius_km must be greater than 0 and at most 500")
+		}
+		nearby.RadiusKm = radius
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > maxNearbyLimit {
+			return models.NearbyQuery{}, apierrors.NewBadRequest("limit must be between 1 and 100")
+		}
+		nearby.Limit = limit
+	}
+
+	return nearby, nil
+}
+
+// getPointFromRequest reads the required lat and lon query parameters.
+// ParseFloat accepts "NaN", which no range check rejects, so it is
+// rejected explicitly.
+func getPointFromRequest(r *http.Request) (float64, float64, error) {
+	query := r.URL.Query()
+
+	lat, err := strconv.ParseFloat(query.Get("lat"), 64)
+	if err != nil || math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return 0, 0, apierrors.NewBadRequest("lat must be a number between -90 and 90")
+	}
+	lon, err := strconv.ParseFloat(query.Get("lon"), 64)
+	if err != nil || math.IsNaN(lon) || lon < -180 || lon > 180 {
+		return 0, 0, apierrors.NewBadRequest("lon must be a number between -180 and 180")
+	}
+	return lat, lon, nil
+}
+
+// getViewportFromRequest reads bbox=minLon,minLat,maxLon,maxLat and an
+// optional limit (capped at maxViewportLimit) from the query string.
+func getViewportFromRequest(r *http.Request) (models.BBox, int, error) {
+	query := r.URL.Query()
+
+	parts := strings.Split(query.Get("bbox"), ",")
+	if len(parts) != 4 {
+		return models.BBox{}, 0, apierrors.NewBadRequest("bbox must be minLon,minLat,maxLon,maxLat")
+	}
+	var values [4]float64
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || math.IsNaN(value) {
+			return models.BBox{}, 0, apierrors.NewBadRequest("bbox must be minLon,minLat,maxLon,maxLat")
+		}
+		values[i] = value
+	}
+
+	bbox := models.BBox{MinLon: values[0], MinLat: values[1], MaxLon: values[2], MaxLat: values[3]}
+	if bbox.MinLon < -180 || bbox.MinLon > 180 || bbox.MaxLon < -180 || bbox.MaxLon > 180 {
+		return models.BBox{}, 0, apierrors.NewBadRequest("bbox longitudes must be between -180 and 180")
+	}
+	if bbox.MinLat < -90 || bbox.MaxLat > 90 || bbox.MinLat > bbox.MaxLat {
+		return models.BBox{}, 0, apierrors.NewBadRequest("bbox latitudes must be between -90 and 90 with minLat <= maxLat")
+	}
+
+	limit := defaultViewportLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		value, err := strconv.Atoi(limitStr)
+		if err != nil || value <= 0 {
+			return models.BBox{}, 0, apierrors.NewBadRequest("limit must be a positive integer; values above 500 are capped")
+		}
+		limit = min(value, maxViewportLimit)
+	}
+
+	return bbox, limit, nil
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+)
+
+// getOfficeFromRequest decodes and validates an office. departmentID, when
+// not zero, is the department named in the path and takes precedence over
+// the body.
+func getOfficeFromRequest(r *http.Request, departmentID int) (models.Office, error) {
+	var office models.Office
+	if err := json.NewDecoder(r.Body).Decode(&office); err != nil {
+		return models.Office{}, apierrors.ErrInvalidInput
+	}
+	defer r.Body.Close()
+
+	if departmentID != 0 {
+		office.DepartmentID = departmentID
+	}
+	if err := validateOffice(office); err != nil {
+		return models.Office{}, err
+	}
+	return office, nil
+}
+
+func validateOffice(office models.Office) error {
+	if office.Name == "" || office.DepartmentID <= 0 {
+		return apierrors.ErrMissingField
+	}
+	if err := office.Validate(); err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	return nil
+}
+
+// officeError maps a repository error from an office write to an API
+// error. The only reference an office has is its department.
+func officeError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return apierrors.ErrOfficeNotFound
+	case errors.Is(err, repository.ErrInvalidReference):
+		return apierrors.ErrDepartmentNotFound
+	}
+	return apierrors.ErrInternal
+}
+
+// getOpenAtFromRequest reads the optional open_at=RFC3339 query parameter.
+// It returns nil when offices should not be filtered by opening hours.
+func getOpenAtFromRequest(r *http.Request) (*time.Time, error) {
+	value := r.URL.Query().Get("open_at")
+	if value == "" {
+		return nil, nil
+	}
+	openAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, apierrors.ErrInvalidOpenAt
+	}
+	return &openAt, nil
+}
@@ -0,0 +1,831 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+	"go-mysql-backend/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type OrganizationHandler struct {
+	Service *service.OrganizationService
+}
+
+func NewOrganizationHandler(service *service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{Service: service}
+}
+
+func (h *OrganizationHandler) GetMinistriesWithDepartments(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("bbox") {
+		h.getMinistriesInViewport(w, r)
+		return
+	}
+	if isPageRequest(r) {
+		h.GetMinistriesPage(w, r)
+		return
+	}
+
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	ministries, err := h.Service.AsOf(asOf).GetMinistriesWithDepartments()
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, ministries)
+}
+
+// GetMinistriesPage returns one page of ministries, each with all of its
+// departments, by offset or cursor.
+func (h *OrganizationHandler) GetMinistriesPage(w http.ResponseWriter, r *http.Request) {
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	p, err := getPageRequestFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	page, err := h.Service.AsOf(asOf).GetMinistriesPage(p)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	setPageLinks(r, p, &page)
+	respondWithResource(w, r, http.StatusOK, page)
+}
+
+func (h *OrganizationHandler) CreateMinistry(w http.ResponseWriter, r *http.Request) {
+	var ministry models.Ministry
+	if err := json.NewDecoder(r.Body).Decode(&ministry); err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := ministry.NormalizeNames(); err != nil {
+		respondWithError(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+	if err := validateMinistry(ministry); err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	id, err := h.Service.CreateMinistry(ministry)
+	if errors.Is(err, repository.ErrInvalidReference) {
+		respondWithError(w, apierrors.ErrUnknownMinistry)
+		return
+	} else if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"message": "Ministry created successfully",
+		"id":      id,
+	})
+}
+
+func (h *OrganizationHandler) GetAllDepartments(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("bbox") {
+		h.getDepartmentsInViewport(w, r)
+		return
+	}
+	if isPageRequest(r) {
+		h.getDepartmentsPage(w, r)
+		return
+	}
+
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	departments, err := h.Service.AsOf(asOf).GetAllDepartments()
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, departments)
+}
+
+func (h *OrganizationHandler) CreateDepartment(w http.ResponseWriter, r *http.Request) {
+	var dept models.Department
+	if err := json.NewDecoder(r.Body).Decode(&dept); err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := dept.NormalizeNames(); err != nil {
+		respondWithError(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+	if err := validateDepartment(dept); err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	id, err := h.Service.CreateDepartment(dept)
+	if errors.Is(err, repository.ErrInvalidReference) {
+		respondWithError(w, apierrors.ErrUnknownReference)
+		return
+	} else if errors.Is(err, repository.ErrInvalidParent) {
+		respondWithError(w, apierrors.ErrInvalidParent)
+		return
+	} else if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"message": "Department created successfully",
+		"id":      id,
+	})
+}
+
+func (h *OrganizationHandler) GetMinistryByID(w http.ResponseWriter, r *http.Request) {
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	ministry, err := h.Service.AsOf(asOf).GetMinistryByID(id)
+	if err != nil {
+		respondWithError(w, apierrors.ErrMinistryNotFound)
+		return
+	}
+	if ministry.ID == 0 {
+		respondWithError(w, apierrors.ErrMinistryNotFound)
+		return
+	}
+
+	respondWithResource(w, r, http.StatusOK, ministry)
+}
+
+func (h *OrganizationHandler) GetMinistryByIDWithDepartments(w http.ResponseWriter, r *http.Request) {
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	ministry, err := h.Service.AsOf(asOf).GetMinistryByIDWithDepartments(id)
+	if err != nil {
+		respondWithError(w, apierrors.ErrMinistryNotFound)
+		return
+	}
+	if ministry.ID == 0 {
+		respondWithError(w, apierrors.ErrMinistryNotFound)
+		return
+	}
+
+	respondWithResource(w, r, http.StatusOK, ministry)
+}
+
+func (h *OrganizationHandler) GetDepartmentByID(w http.ResponseWriter, r *http.Request) {
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	dept, err := h.Service.AsOf(asOf).GetDepartmentByID(id)
+	if err != nil {
+		respondWithError(w, apierrors.ErrDepartmentNotFound)
+		return
+	}
+	if dept == nil {
+		respondWithError(w, apierrors.ErrDepartmentNotFound)
+		return
+	}
+
+	respondWithResource(w, r, http.StatusOK, dept)
+}
+
+func (h *OrganizationHandler) UpdateMinistry(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	var ministry models.Ministry
+	if err := json.NewDecoder(r.Body).Decode(&ministry); err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+	defer r.Body.Close()
+
+	h.saveMinistry(w, id, ministry)
+}
+
+// PatchMinistry applies only the fields present in the request body on top
+// of the stored ministry. Names given in the body replace those of their
+// language; the English one follows name.
+func (h *OrganizationHandler) PatchMinistry(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	ministry, err := h.Service.GetMinistryByID(id)
+	if err != nil || ministry.ID == 0 {
+		respondWithError(w, apierrors.ErrMinistryNotFound)
+		return
+	}
+	delete(ministry.Names, models.LocaleEnglish)
+
+	if err := json.NewDecoder(r.Body).Decode(&ministry); err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+	defer r.Body.Close()
+
+	h.saveMinistry(w, id, ministry)
+}
+
+func (h *OrganizationHandler) saveMinistry(w http.ResponseWriter, id int, ministry models.Ministry) {
+	ministry.ID = id
+	if err := ministry.NormalizeNames(); err != nil {
+		respondWithError(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+	if err := validateMinistry(ministry); err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err := h.Service.UpdateMinistry(ministry)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		respondWithError(w, apierrors.ErrMinistryNotFound)
+		return
+	case errors.Is(err, repository.ErrInvalidReference):
+		respondWithError(w, apierrors.ErrUnknownMinistry)
+		return
+	case errors.Is(err, repository.ErrInvalidParent):
+		respondWithError(w, apierrors.ErrInvalidParent)
+		return
+	case errors.Is(err, repository.ErrEffectiveDateOrder):
+		respondWithError(w, apierrors.ErrEffectiveDateOrder)
+		return
+	case err != nil:
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Ministry updated successfully",
+		"id":      id,
+	})
+}
+
+// DeleteMinistry deletes a ministry. The departments query parameter picks
+// what happens to its departments: reject (default), cascade, or reassign
+// together with reassign_to=<ministry id>.
+func (h *OrganizationHandler) DeleteMinistry(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	mode, reassignTo, err := getDeleteModeFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err = h.Service.DeleteMinistry(id, mode, reassignTo)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		respondWithError(w, apierrors.ErrMinistryNotFound)
+		return
+	case errors.Is(err, repository.ErrMinistryHasDepartments):
+		respondWithError(w, apierrors.ErrMinistryHasDepartments)
+		return
+	case errors.Is(err, repository.ErrInvalidReassignTarget):
+		respondWithError(w, apierrors.ErrInvalidReassignTarget)
+		return
+	case errors.Is(err, repository.ErrEffectiveDateOrder):
+		respondWithError(w, apierrors.ErrEffectiveDateOrder)
+		return
+	case err != nil:
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Ministry deleted successfully",
+		"id":      id,
+	})
+}
+
+func (h *OrganizationHandler) UpdateDepartment(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	var dept models.Department
+	if err := json.NewDecoder(r.Body).Decode(&dept); err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+	defer r.Body.Close()
+
+	h.saveDepartment(w, id, dept)
+}
+
+// PatchDepartment applies only the fields present in the request body on
+// top of the stored department. Names are patched as for PatchMinistry.
+func (h *OrganizationHandler) PatchDepartment(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	dept, err := h.Service.GetDepartmentByID(id)
+	if err != nil || dept == nil {
+		respondWithError(w, apierrors.ErrDepartmentNotFound)
+		return
+	}
+	delete(dept.Names, models.LocaleEnglish)
+
+	if err := json.NewDecoder(r.Body).Decode(dept); err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+	defer r.Body.Close()
+
+	h.saveDepartment(w, id, *dept)
+}
+
+func (h *OrganizationHandler) saveDepartment(w http.ResponseWriter, id int, dept models.Department) {
+	dept.ID = id
+	if err := dept.NormalizeNames(); err != nil {
+		respondWithError(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+	if err := validateDepartment(dept); err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	err := h.Service.UpdateDepartment(dept)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		respondWithError(w, apierrors.ErrDepartmentNotFound)
+		return
+	case errors.Is(err, repository.ErrInvalidReference):
+		respondWithError(w, apierrors.ErrUnknownReference)
+		return
+	case errors.Is(err, repository.ErrInvalidParent):
+		respondWithError(w, apierrors.ErrInvalidParent)
+		return
+	case errors.Is(err, repository.ErrEffectiveDateOrder):
+		respondWithError(w, apierrors.ErrEffectiveDateOrder)
+		return
+	case err != nil:
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Department updated successfully",
+		"id":      id,
+	})
+}
+
+func (h *OrganizationHandler) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	err = h.Service.DeleteDepartment(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		respondWithError(w, apierrors.ErrDepartmentNotFound)
+		return
+	} else if errors.Is(err, repository.ErrEffectiveDateOrder) {
+		respondWithError(w, apierrors.ErrEffectiveDateOrder)
+		return
+	} else if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Department deleted successfully",
+		"id":      id,
+	})
+}
+
+// FindDepartmentsNearby lists the departments closest to lat/lon, nearest
+// first, with their distance in kilometres.
+func (h *OrganizationHandler) FindDepartmentsNearby(w http.ResponseWriter, r *http.Request) {
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	query, err := getNearbyQueryFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	departments, err := h.Service.AsOf(asOf).FindDepartmentsNearby(query)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, departments)
+}
+
+// GetOrganizationTree returns a ministry or department with its
+// descendants, e.g. /organizations/3/tree?entity=ministry&depth=2.
+func (h *OrganizationHandler) GetOrganizationTree(w http.ResponseWriter, r *http.Request) {
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	root, depth, err := getOrgTreeQueryFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	tree, err := h.Service.AsOf(asOf).GetOrganizationTree(root, depth)
+	if errors.Is(err, repository.ErrNotFound) {
+		respondWithError(w, apierrors.ErrOrganizationNotFound)
+		return
+	} else if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, tree)
+}
+
+// ApplyChangeSet applies a gazette's structural changes all together, or
+// none of them.
+func (h *OrganizationHandler) ApplyChangeSet(w http.ResponseWriter, r *http.Request) {
+	cs, err := getChangeSetFromRequest(r)
+	if err != nil {
+		respondWithChangeSetError(w, err)
+		return
+	}
+
+	result, err := h.Service.ApplyChangeSet(cs)
+	if err != nil {
+		respondWithChangeSetError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// GetDepartmentOffices lists the offices of a department, only those open
+// at open_at when it is given.
+func (h *OrganizationHandler) GetDepartmentOffices(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+	openAt, err := getOpenAtFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	offices, err := h.Service.GetDepartmentOffices(id, openAt)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	if len(offices) == 0 {
+		// Tell an unknown department apart from one without offices.
+		dept, err := h.Service.GetDepartmentByID(id)
+		if err != nil || dept == nil {
+			respondWithError(w, apierrors.ErrDepartmentNotFound)
+			return
+		}
+	}
+	respondWithResource(w, r, http.StatusOK, offices)
+}
+
+// FindOfficesNearby lists the offices closest to lat/lon, nearest first,
+// only those open at open_at when it is given.
+func (h *OrganizationHandler) FindOfficesNearby(w http.ResponseWriter, r *http.Request) {
+	q, err := getNearbyQueryFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	openAt, err := getOpenAtFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	offices, err := h.Service.FindOfficesNearby(q, openAt)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, offices)
+}
+
+// CreateOffice adds an office to the department in the path.
+func (h *OrganizationHandler) CreateOffice(w http.ResponseWriter, r *http.Request) {
+	departmentID, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	office, err := getOfficeFromRequest(r, departmentID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	id, err := h.Service.CreateOffice(office)
+	if err != nil {
+		respondWithError(w, officeError(err))
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"message": "Office created successfully",
+		"id":      id,
+	})
+}
+
+func (h *OrganizationHandler) GetOfficeByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	office, err := h.Service.GetOfficeByID(id)
+	if err != nil {
+		respondWithError(w, officeError(err))
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, office)
+}
+
+// UpdateOffice replaces an office. Setting department_id moves it to
+// another department.
+func (h *OrganizationHandler) UpdateOffice(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	office, err := getOfficeFromRequest(r, 0)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	office.ID = id
+
+	if err := h.Service.UpdateOffice(office); err != nil {
+		respondWithError(w, officeError(err))
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Office updated successfully",
+		"id":      id,
+	})
+}
+
+func (h *OrganizationHandler) DeleteOffice(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	if err := h.Service.DeleteOffice(id); err != nil {
+		respondWithError(w, officeError(err))
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Office deleted successfully",
+		"id":      id,
+	})
+}
+
+func (h *OrganizationHandler) getDepartmentsInViewport(w http.ResponseWriter, r *http.Request) {
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	bbox, limit, err := getViewportFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	viewport, err := h.Service.AsOf(asOf).GetDepartmentsInViewport(bbox, limit)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, viewport)
+}
+
+func (h *OrganizationHandler) getDepartmentsPage(w http.ResponseWriter, r *http.Request) {
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	p, err := getPageRequestFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	page, err := h.Service.AsOf(asOf).GetDepartmentsPage(p)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	setPageLinks(r, p, &page)
+	respondWithResource(w, r, http.StatusOK, page)
+}
+
+func (h *OrganizationHandler) getMinistriesInViewport(w http.ResponseWriter, r *http.Request) {
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	bbox, limit, err := getViewportFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	viewport, err := h.Service.AsOf(asOf).GetMinistriesInViewport(bbox, limit)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, viewport)
+}
+
+// SeedDummyData fills the database with random ministries and departments,
+// on backends that support it.
+func (h *OrganizationHandler) SeedDummyData(w http.ResponseWriter, r *http.Request) {
+	err := h.Service.SeedDummyData()
+	if errors.Is(err, service.ErrSeedUnsupported) {
+		respondWithError(w, apierrors.ErrSeedUnsupported)
+		return
+	} else if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Dummy data seeded successfully"})
+}
+
+// Helper functions
+func getIDFromRequest(r *http.Request) (int, error) {
+	vars := mux.Vars(r)
+	return strconv.Atoi(vars["id"])
+}
+
+func getDeleteModeFromRequest(r *http.Request) (models.MinistryDeleteMode, int, error) {
+	query := r.URL.Query()
+
+	mode := models.MinistryDeleteMode(query.Get("departments"))
+	switch mode {
+	case "":
+		return models.DeleteReject, 0, nil
+	case models.DeleteReject, models.DeleteCascade:
+		return mode, 0, nil
+	case models.DeleteReassign:
+		reassignTo, err := strconv.Atoi(query.Get("reassign_to"))
+		if err != nil || reassignTo <= 0 {
+			return "", 0, apierrors.ErrInvalidReassignTarget
+		}
+		return mode, reassignTo, nil
+	}
+	return "", 0, apierrors.ErrInvalidInput
+}
+
+func validateMinistry(ministry models.Ministry) error {
+	if ministry.Name == "" {
+		return apierrors.ErrMissingField
+	}
+	if err := models.ValidateKind(ministry.Kind, models.MinistryKinds); err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	if ministry.ParentID != nil && *ministry.ParentID <= 0 {
+		return apierrors.ErrInvalidInput
+	}
+	if err := ministry.Location.Validate(); err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	return nil
+}
+
+func validateDepartment(dept models.Department) error {
+	if dept.Name == "" {
+		return apierrors.ErrMissingField
+	}
+	if dept.MinistryID == 0 {
+		return apierrors.ErrMissingField
+	}
+	if err := models.ValidateKind(dept.Kind, models.DepartmentKinds); err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	if dept.ParentID != nil && *dept.ParentID <= 0 {
+		return apierrors.ErrInvalidInput
+	}
+	if err := dept.Location.Validate(); err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	return nil
+}
+
+// Search finds ministries, departments and offices by name or address.
+func (h *OrganizationHandler) Search(w http.ResponseWriter, r *http.Request) {
+	q, err := getSearchQueryFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	result, err := h.Service.Search(q)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, result)
+}
+
+// Autocomplete suggests ministry and department names for a search box as
+// the user types, from the service's in-memory index.
+func (h *OrganizationHandler) Autocomplete(w http.ResponseWriter, r *http.Request) {
+	q, err := getAutocompleteQueryFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithResource(w, r, http.StatusOK, h.Service.Autocomplete(q))
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// isPageRequest reports whether a list endpoint is asked for one page
+// rather than the whole list.
+func isPageRequest(r *http.Request) bool {
+	query := r.URL.Query()
+	return query.Has("limit") || query.Has("offset") || query.Has("cursor")
+}
+
+// getPageRequestFromRequest reads limit and either offset or cursor from
+// the query string.
+func getPageRequestFromRequest(r *http.Request) (models.PageRequest, error) {
+	query := r.URL.Query()
+	p := models.PageRequest{Limit: defaultPageLimit}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > maxPageLimit {
+			return models.PageRequest{}, apierrors.NewBadRequest("limit must be between 1 and 100")
+		}
+		p.Limit = limit
+	}
+
+	if query.Has("offset") && query.Has("cursor") {
+		return models.PageRequest{}, apierrors.NewBadRequest("offset and cursor cannot be combined")
+	}
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return models.PageRequest{}, apierrors.NewBadRequest("offset must be zero or more")
+		}
+		p.Offset = offset
+	}
+	if query.Has("cursor") {
+		cursor, err := models.DecodeCursor(query.Get("cursor"))
+		if err != nil {
+			return models.PageRequest{}, apierrors.ErrInvalidCursor
+		}
+		p.Cursor = &cursor
+	}
+	return p, nil
+}
+
+// setPageLinks fills in the Next and Prev links of page: the request URL
+// moved to the neighbouring pages, by cursor when the request used one and
+// by offset otherwise.
+func setPageLinks[T any](r *http.Request, p models.PageRequest, page *models.Page[T]) {
+	link := func(param, value string) string {
+		query := r.URL.Query()
+		query.Del("offset")
+		query.Del("cursor")
+		query.Set("limit", strconv.Itoa(page.Limit))
+		query.Set(param, value)
+		return (&url.URL{Path: r.URL.Path, RawQuery: query.Encode()}).String()
+	}
+
+	if p.Cursor != nil {
+		if page.NextCursor != "" {
+			page.Next = link("cursor", page.NextCursor)
+		}
+		if page.PrevCursor != "" {
+			page.Prev = link("cursor", page.PrevCursor)
+		}
+		return
+	}
+	if page.HasNext() {
+		page.Next = link("offset", strconv.Itoa(page.Offset+page.Limit))
+	}
+	if page.HasPrev() {
+		page.Prev = link("offset", strconv.Itoa(max(page.Offset-page.Limit, 0)))
+	}
+}
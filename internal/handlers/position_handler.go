@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+	"go-mysql-backend/internal/service"
+)
+
+type PositionHandler struct {
+	Service *service.PositionService
+}
+
+func NewPositionHandler(service *service.PositionService) *PositionHandler {
+	return &PositionHandler{Service: service}
+}
+
+func (h *PositionHandler) CreatePerson(w http.ResponseWriter, r *http.Request) {
+	var person models.Person
+	if err := json.NewDecoder(r.Body).Decode(&person); err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+	defer r.Body.Close()
+
+	if person.Name == "" {
+		respondWithError(w, apierrors.ErrMissingField)
+		return
+	}
+
+	id, err := h.Service.CreatePerson(person)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"message": "Person created successfully",
+		"id":      id,
+	})
+}
+
+// GetPerson returns a person with every term they have held.
+func (h *PositionHandler) GetPerson(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	person, err := h.Service.GetPerson(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		respondWithError(w, apierrors.ErrPersonNotFound)
+		return
+	} else if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, person)
+}
+
+func (h *PositionHandler) CreatePosition(w http.ResponseWriter, r *http.Request) {
+	var position models.Position
+	if err := json.NewDecoder(r.Body).Decode(&position); err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := validatePosition(position); err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	id, err := h.Service.CreatePosition(position)
+	if errors.Is(err, repository.ErrInvalidReference) {
+		respondWithError(w, apierrors.ErrUnknownPositionRef)
+		return
+	} else if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"message": "Position created successfully",
+		"id":      id,
+	})
+}
+
+// EndPosition closes a term on end_date, or moves the end of a term that
+// has already ended.
+func (h *PositionHandler) EndPosition(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(r)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+
+	var body struct {
+		EndDate models.Date `json:"end_date"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondWithError(w, apierrors.ErrInvalidInput)
+		return
+	}
+	defer r.Body.Close()
+
+	if body.EndDate.IsZero() {
+		respondWithError(w, apierrors.ErrMissingField)
+		return
+	}
+
+	err = h.Service.EndPosition(id, body.EndDate)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		respondWithError(w, apierrors.ErrPositionNotFound)
+		return
+	case errors.Is(err, repository.ErrInvalidTerm):
+		respondWithError(w, apierrors.ErrInvalidTerm)
+		return
+	case err != nil:
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message":  "Position updated successfully",
+		"id":       id,
+		"end_date": body.EndDate,
+	})
+}
+
+// GetOfficeHolders lists who holds office in a ministry or department
+// today, or on as_of, e.g.
+// /organizations/3/office-holders?entity=ministry&role=minister.
+func (h *PositionHandler) GetOfficeHolders(w http.ResponseWriter, r *http.Request) {
+	org, role, err := getPositionQueryFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	asOf, err := getAsOfFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	date := models.Today()
+	if asOf != nil {
+		date = *asOf
+	}
+
+	positions, err := h.Service.GetOfficeHolders(org, role, date)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, positions)
+}
+
+// GetPositionHistory lists every term held in a ministry or department,
+// oldest first.
+func (h *PositionHandler) GetPositionHistory(w http.ResponseWriter, r *http.Request) {
+	org, role, err := getPositionQueryFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	positions, err := h.Service.GetPositionHistory(org, role)
+	if err != nil {
+		respondWithError(w, apierrors.ErrInternal)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, positions)
+}
+
+// getPositionQueryFromRequest reads the organisation and the optional role
+// query parameter.
+func getPositionQueryFromRequest(r *http.Request) (models.OrgRef, models.PositionRole, error) {
+	org, err := getOrgRefFromRequest(r)
+	if err != nil {
+		return models.OrgRef{}, "", err
+	}
+
+	role := models.PositionRole(r.URL.Query().Get("role"))
+	if role != "" {
+		if err := models.ValidateRole(role, org.Entity); err != nil {
+			return models.OrgRef{}, "", apierrors.NewBadRequest(err.Error())
+		}
+	}
+	return org, role, nil
+}
+
+func validatePosition(position models.Position) error {
+	if position.PersonID <= 0 || position.OrganizationID <= 0 || position.StartDate.IsZero() {
+		return apierrors.ErrMissingField
+	}
+	if !position.Entity.Valid() {
+		return apierrors.NewBadRequest("entity must be ministry or department")
+	}
+	if err := models.ValidateRole(position.Role, position.Entity); err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	if !position.EndDate.IsZero() && !position.EndDate.After(position.StartDate) {
+		return apierrors.ErrInvalidTerm
+	}
+	return nil
+}
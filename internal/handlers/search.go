@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/translit"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	maxSearchLength    = 200
+)
+
+// getSearchQueryFromRequest reads q, an optional comma-separated type
+// filter and limit from the query string.
+func getSearchQueryFromRequest(r *http.Request) (models.SearchQuery, error) {
+	query := r.URL.Query()
+
+	q := models.SearchQuery{
+		Text:     translit.Normalize(strings.TrimSpace(query.Get("q"))),
+		Entities: models.SearchEntities,
+		Limit:    defaultSearchLimit,
+	}
+	if q.Text == "" || utf8.RuneCountInString(q.Text) > maxSearchLength {
+		return models.SearchQuery{}, apierrors.ErrInvalidSearch
+	}
+
+	if types := query.Get("type"); types != "" {
+		q.Entities = nil
+		for _, value := range strings.Split(types, ",") {
+			entity := models.SearchEntity(strings.TrimSpace(value))
+			if !validSearchEntity(entity) {
+				return models.SearchQuery{}, apierrors.NewBadRequest("type must list ministry, department or office")
+			}
+			q.Entities = append(q.Entities, entity)
+		}
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > maxSearchLimit {
+			return models.SearchQuery{}, apierrors.NewBadRequest("limit must be between 1 and 100")
+		}
+		q.Limit = limit
+	}
+	return q, nil
+}
+
+func validSearchEntity(entity models.SearchEntity) bool {
+	for _, e := range models.SearchEntities {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
@@ -3,8 +3,10 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	apierrors "go-mysql-backend/internal/errors"
+	"go-mysql-backend/internal/geo"
 )
 
 // respondWithError sends a structured error response
@@ -22,3 +24,36 @@ func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(payload)
 }
+
+// respondWithResource sends the payload of a read endpoint in the language
+// the client asked for, as GeoJSON when it asked for that and as plain JSON
+// otherwise.
+func respondWithResource(w http.ResponseWriter, r *http.Request, code int, payload interface{}) {
+	locale, err := getLocaleFromRequest(r)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	payload = localize(payload, locale)
+	w.Header().Set("Content-Language", string(locale))
+	w.Header().Add("Vary", "Accept-Language")
+
+	if wantsGeoJSON(r) {
+		if geojson, ok := geo.ToGeoJSON(payload); ok {
+			w.Header().Set("Content-Type", geo.MediaType)
+			w.WriteHeader(code)
+			json.NewEncoder(w).Encode(geojson)
+			return
+		}
+	}
+	respondWithJSON(w, code, payload)
+}
+
+// wantsGeoJSON reports whether the request used a .geojson route or sent
+// Accept: application/geo+json.
+func wantsGeoJSON(r *http.Request) bool {
+	if strings.HasSuffix(r.URL.Path, ".geojson") {
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), geo.MediaType)
+}
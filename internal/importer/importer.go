@@ -0,0 +1,442 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/translit"
+)
+
+// Store is the part of a repository an import needs. Every
+// repository.OrganizationRepo implements it.
+type Store interface {
+	GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error)
+	GetDepartmentOffices(departmentID int) ([]models.Office, error)
+	Import(plan models.ImportPlan) (models.ImportResult, error)
+}
+
+// Options controls an import.
+type Options struct {
+	// DryRun checks every row and builds the report without writing.
+	DryRun bool
+}
+
+// ErrInvalidRows is returned, with the report, when a row of an import is
+// invalid. Nothing is created.
+var ErrInvalidRows = errors.New("import has invalid rows")
+
+// RowReport is the outcome of one row: valid when it has no errors.
+type RowReport struct {
+	Entity Entity   `json:"entity"`
+	Row    int      `json:"row"`
+	Name   string   `json:"name"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Report describes an import row by row. Created lists the new ids once
+// the import has been committed.
+type Report struct {
+	DryRun      bool                 `json:"dry_run"`
+	Valid       bool                 `json:"valid"`
+	Ministries  int                  `json:"ministries"`
+	Departments int                  `json:"departments"`
+	Offices     int                  `json:"offices"`
+	Invalid     int                  `json:"invalid"`
+	Rows        []RowReport          `json:"rows"`
+	Created     *models.ImportResult `json:"created,omitempty"`
+}
+
+// Run checks every row of batch against the file and store. Unless
+// opts.DryRun is set, a batch without invalid rows is then created in
+// store in one transaction. With invalid rows Run returns the report and
+// ErrInvalidRows, and creates nothing.
+func Run(store Store, batch Batch, opts Options) (Report, error) {
+	c, err := newChecker(store)
+	if err != nil {
+		return Report{}, err
+	}
+	plan, err := c.check(batch)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := c.report
+	report.DryRun = opts.DryRun
+	report.Valid = report.Invalid == 0
+	if !report.Valid {
+		return report, ErrInvalidRows
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	created, err := store.Import(plan)
+	if err != nil {
+		return report, err
+	}
+	report.Created = &created
+	return report, nil
+}
+
+// WriteReport writes the rows of a report as CSV with a header row, the
+// errors of a row separated by semicolons.
+func WriteReport(w io.Writer, rows []RowReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"entity", "row", "name", "status", "errors"}); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		status := "ok"
+		if len(r.Errors) > 0 {
+			status = "invalid"
+		}
+		if err := writer.Write([]string{string(r.Entity), strconv.Itoa(r.Row), r.Name, status, strings.Join(r.Errors, "; ")}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// nameKey is the form names are matched and compared in.
+func nameKey(name string) string {
+	return strings.ToLower(translit.Normalize(strings.TrimSpace(name)))
+}
+
+// owner identifies the ministry of a department or the department of an
+// office: an existing record by id, or a row of the file by index.
+type owner struct {
+	id    int
+	index int
+}
+
+func existing(id int) owner  { return owner{id: id, index: -1} }
+func inFile(index int) owner { return owner{index: index} }
+
+// fileDepartment is a department row of the file as offices see it.
+type fileDepartment struct {
+	ministry string // nameKey of its ministry's name
+	valid    bool
+}
+
+// checker resolves the rows of a batch against each other and the
+// existing records, collecting the errors of each row and the plan that
+// would create them.
+type checker struct {
+	store Store
+
+	ministryIDs     map[int]string   // existing ministry names by id
+	ministryNames   map[string][]int // existing ministry ids by nameKey
+	departments     map[int]models.Department
+	departmentNames map[string][]int        // existing department ids by nameKey
+	offices         map[int]map[string]bool // nameKeys of existing offices, loaded per department
+	children        map[int]map[string]bool // nameKeys of the existing departments of each ministry
+
+	fileMinistries    map[string]int // index of the first ministry row by nameKey
+	ministryValid     []bool
+	fileDepartments   []fileDepartment
+	departmentsByName map[string][]int // department row indexes by nameKey
+
+	report Report
+}
+
+func newChecker(store Store) (*checker, error) {
+	ministries, err := store.GetMinistriesWithDepartments()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &checker{
+		store:             store,
+		ministryIDs:       map[int]string{},
+		ministryNames:     map[string][]int{},
+		departments:       map[int]models.Department{},
+		departmentNames:   map[string][]int{},
+		offices:           map[int]map[string]bool{},
+		children:          map[int]map[string]bool{},
+		fileMinistries:    map[string]int{},
+		departmentsByName: map[string][]int{},
+		report:            Report{Rows: []RowReport{}},
+	}
+	for _, m := range ministries {
+		c.ministryIDs[m.ID] = m.Name
+		key := nameKey(m.Name)
+		c.ministryNames[key] = append(c.ministryNames[key], m.ID)
+		c.children[m.ID] = map[string]bool{}
+		for _, d := range m.Departments {
+			c.departments[d.ID] = d
+			key := nameKey(d.Name)
+			c.departmentNames[key] = append(c.departmentNames[key], d.ID)
+			c.children[m.ID][key] = true
+		}
+	}
+	return c, nil
+}
+
+// check reports on every row of batch and returns the plan creating them,
+// which is only complete when every row is valid.
+func (c *checker) check(batch Batch) (models.ImportPlan, error) {
+	var plan models.ImportPlan
+	for i, row := range batch.Ministries {
+		m, errs := c.checkMinistry(i, row, batch.Ministries)
+		c.ministryValid = append(c.ministryValid, len(errs) == 0)
+		c.add(row, m.Name, errs)
+		plan.Ministries = append(plan.Ministries, models.ImportMinistry{Ministry: m, Row: row.Number})
+	}
+
+	siblings := map[owner]map[string]int{}
+	for i, row := range batch.Departments {
+		d, errs := c.checkDepartment(row, batch.Ministries, siblings, i)
+		c.add(row, d.Name, errs)
+		plan.Departments = append(plan.Departments, d)
+	}
+
+	siblings = map[owner]map[string]int{}
+	for _, row := range batch.Offices {
+		o, errs, err := c.checkOffice(row, batch, siblings)
+		if err != nil {
+			return models.ImportPlan{}, err
+		}
+		c.add(row, o.Name, errs)
+		plan.Offices = append(plan.Offices, o)
+	}
+
+	for _, row := range batch.Unknown {
+		c.add(row, row.Name, append(row.problems, fmt.Sprintf("entity %q must be ministry, department or office", row.Entity)))
+	}
+	return plan, nil
+}
+
+func (c *checker) add(row Row, name string, errs []string) {
+	if name == "" {
+		name = row.Name
+	}
+	c.report.Rows = append(c.report.Rows, RowReport{Entity: row.Entity, Row: row.Number, Name: name, Errors: errs})
+	switch {
+	case len(errs) > 0:
+		c.report.Invalid++
+	case row.Entity == EntityMinistry:
+		c.report.Ministries++
+	case row.Entity == EntityDepartment:
+		c.report.Departments++
+	case row.Entity == EntityOffice:
+		c.report.Offices++
+	}
+}
+
+func (c *checker) checkMinistry(i int, row Row, rows []Row) (models.Ministry, []string) {
+	m := models.Ministry{Name: row.Name, Names: row.Names, Kind: row.Kind, Location: row.Location}
+	errs := checkOrganization(row, &m.Name, &m.Names, m.Kind, models.MinistryKinds, m.Location)
+
+	if key := nameKey(m.Name); key != "" {
+		if first, ok := c.fileMinistries[key]; ok {
+			errs = append(errs, fmt.Sprintf("duplicate of the ministry on row %d", rows[first].Number))
+		} else {
+			c.fileMinistries[key] = i
+		}
+		if ids := c.ministryNames[key]; len(ids) > 0 {
+			errs = append(errs, fmt.Sprintf("ministry %q already exists with id %d", m.Name, ids[0]))
+		}
+	}
+	return m, errs
+}
+
+func (c *checker) checkDepartment(row Row, ministries []Row, siblings map[owner]map[string]int, i int) (models.ImportDepartment, []string) {
+	d := models.ImportDepartment{
+		Department: models.Department{Name: row.Name, Names: row.Names, Kind: row.Kind, Location: row.Location},
+		Row:        row.Number,
+	}
+	errs := checkOrganization(row, &d.Name, &d.Names, d.Kind, models.DepartmentKinds, d.Location)
+
+	var parent owner
+	var ministryName string
+	resolved := false
+	switch {
+	case row.Ministry != "" && row.MinistryID != 0:
+		errs = append(errs, "give ministry or ministry_id, not both")
+	case row.MinistryID != 0:
+		if name, ok := c.ministryIDs[row.MinistryID]; ok {
+			parent, ministryName, resolved = existing(row.MinistryID), name, true
+		} else {
+			errs = append(errs, fmt.Sprintf("unknown ministry_id %d", row.MinistryID))
+		}
+	case row.Ministry != "":
+		key := nameKey(row.Ministry)
+		if index, ok := c.fileMinistries[key]; ok {
+			parent, ministryName, resolved = inFile(index), row.Ministry, true
+			if !c.ministryValid[index] {
+				errs = append(errs, fmt.Sprintf("ministry %q on row %d is invalid", row.Ministry, ministries[index].Number))
+			}
+		} else if ids := c.ministryNames[key]; len(ids) == 1 {
+			parent, ministryName, resolved = existing(ids[0]), row.Ministry, true
+		} else if len(ids) > 1 {
+			errs = append(errs, fmt.Sprintf("ministry %q is ambiguous; use ministry_id", row.Ministry))
+		} else {
+			errs = append(errs, fmt.Sprintf("unknown ministry %q", row.Ministry))
+		}
+	default:
+		errs = append(errs, "ministry or ministry_id is required")
+	}
+
+	if resolved {
+		if parent.index >= 0 {
+			index := parent.index
+			d.MinistryIndex = &index
+		} else {
+			d.MinistryID = parent.id
+		}
+		if key := nameKey(d.Name); key != "" {
+			if siblings[parent] == nil {
+				siblings[parent] = map[string]int{}
+			}
+			if first, ok := siblings[parent][key]; ok {
+				errs = append(errs, fmt.Sprintf("duplicate of the department on row %d", first))
+			} else {
+				siblings[parent][key] = row.Number
+			}
+			if parent.index < 0 && c.children[parent.id][key] {
+				errs = append(errs, fmt.Sprintf("ministry %q already has a department %q", ministryName, d.Name))
+			}
+		}
+	}
+
+	c.fileDepartments = append(c.fileDepartments, fileDepartment{ministry: nameKey(ministryName), valid: len(errs) == 0})
+	key := nameKey(d.Name)
+	c.departmentsByName[key] = append(c.departmentsByName[key], i)
+	return d, errs
+}
+
+func (c *checker) checkOffice(row Row, batch Batch, siblings map[owner]map[string]int) (models.ImportOffice, []string, error) {
+	o := models.ImportOffice{
+		Office: models.Office{Name: translit.Normalize(strings.TrimSpace(row.Name)), PhoneNumbers: row.PhoneNumbers, Email: row.Email, Location: row.Location},
+		Row:    row.Number,
+	}
+	errs := checkProblems(row, o.Office.Validate())
+	if o.Name == "" {
+		errs = append(errs, "name is required")
+	}
+
+	var parent owner
+	resolved := false
+	switch {
+	case row.Department != "" && row.DepartmentID != 0:
+		errs = append(errs, "give department or department_id, not both")
+	case row.DepartmentID != 0:
+		if _, ok := c.departments[row.DepartmentID]; ok {
+			parent, resolved = existing(row.DepartmentID), true
+		} else {
+			errs = append(errs, fmt.Sprintf("unknown department_id %d", row.DepartmentID))
+		}
+	case row.Department != "":
+		var matches []owner
+		key, ministry := nameKey(row.Department), nameKey(row.Ministry)
+		for _, index := range c.departmentsByName[key] {
+			if ministry == "" || c.fileDepartments[index].ministry == ministry {
+				matches = append(matches, inFile(index))
+			}
+		}
+		if len(matches) == 0 {
+			for _, id := range c.departmentNames[key] {
+				if ministry == "" || nameKey(c.ministryIDs[c.departments[id].MinistryID]) == ministry {
+					matches = append(matches, existing(id))
+				}
+			}
+		}
+
+		switch {
+		case len(matches) == 0 && ministry != "":
+			errs = append(errs, fmt.Sprintf("unknown department %q of ministry %q", row.Department, row.Ministry))
+		case len(matches) == 0:
+			errs = append(errs, fmt.Sprintf("unknown department %q", row.Department))
+		case len(matches) > 1:
+			errs = append(errs, fmt.Sprintf("department %q is ambiguous; give its ministry or use department_id", row.Department))
+		default:
+			parent, resolved = matches[0], true
+			if parent.index >= 0 && !c.fileDepartments[parent.index].valid {
+				errs = append(errs, fmt.Sprintf("department %q on row %d is invalid", row.Department, batch.Departments[parent.index].Number))
+			}
+		}
+	default:
+		errs = append(errs, "department or department_id is required")
+	}
+
+	if resolved {
+		if parent.index >= 0 {
+			index := parent.index
+			o.DepartmentIndex = &index
+		} else {
+			o.DepartmentID = parent.id
+		}
+		if key := nameKey(o.Name); key != "" {
+			if siblings[parent] == nil {
+				siblings[parent] = map[string]int{}
+			}
+			if first, ok := siblings[parent][key]; ok {
+				errs = append(errs, fmt.Sprintf("duplicate of the office on row %d", first))
+			} else {
+				siblings[parent][key] = row.Number
+			}
+			if parent.index < 0 {
+				names, err := c.officeNames(parent.id)
+				if err != nil {
+					return models.ImportOffice{}, nil, err
+				}
+				if names[key] {
+					errs = append(errs, fmt.Sprintf("department %d already has an office %q", parent.id, o.Name))
+				}
+			}
+		}
+	}
+	return o, errs, nil
+}
+
+// officeNames returns the nameKeys of the offices of an existing
+// department, reading them once.
+func (c *checker) officeNames(departmentID int) (map[string]bool, error) {
+	if names, ok := c.offices[departmentID]; ok {
+		return names, nil
+	}
+	offices, err := c.store.GetDepartmentOffices(departmentID)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, o := range offices {
+		names[nameKey(o.Name)] = true
+	}
+	c.offices[departmentID] = names
+	return names, nil
+}
+
+// checkOrganization checks the fields ministries and departments share,
+// normalizing their names as the create endpoints do.
+func checkOrganization(row Row, name *string, names *models.LocalizedNames, kind models.OrgKind, kinds []models.OrgKind,
+	location models.Location) []string {
+	errs := checkProblems(row, location.Validate())
+	var err error
+	if *name, *names, err = models.NormalizeNames(*name, *names); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if *name == "" {
+		errs = append(errs, "name is required")
+	}
+	if err := models.ValidateKind(kind, kinds); err != nil {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+// checkProblems starts the errors of row with the values that could not
+// be read, and leaves out a location error that only repeats them.
+func checkProblems(row Row, location error) []string {
+	errs := append([]string(nil), row.problems...)
+	if location != nil && !(row.unreadCoordinates && errors.Is(location, models.ErrIncompleteCoordinates)) {
+		errs = append(errs, location.Error())
+	}
+	return errs
+}
@@ -0,0 +1,206 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+
+	"go-mysql-backend/internal/importer"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const organisationsCSV = `entity,name,name_si,kind,ministry,department,department_id,address,latitude,longitude,phone_numbers
+ministry,Ministry of Ports,,,,,,Colombo 01,6.94,79.84,
+department,Ports Authority,,,Ministry of Ports,,,,,,
+department,Medical Supplies Division,,division,ministry of health,,,,,,
+office,Galle Office,,,,Ports Authority,,,6.03,80.22,091 222 3333; 091 222 4444
+`
+
+func newStore(t *testing.T) (*repository.MemoryRepository, int) {
+	t.Helper()
+	repo := repository.NewMemoryRepository(repository.NewMemoryStore())
+	healthID, err := repo.CreateMinistry(models.Ministry{Name: "Ministry of Health"})
+	require.NoError(t, err)
+	return repo, healthID
+}
+
+func parseCSV(t *testing.T, text string) importer.Batch {
+	t.Helper()
+	batch, err := importer.Parse(strings.NewReader(text), importer.CSV)
+	require.NoError(t, err)
+	return batch
+}
+
+func TestParseCSV(t *testing.T) {
+	batch := parseCSV(t, organisationsCSV)
+	require.Len(t, batch.Ministries, 1)
+	require.Len(t, batch.Departments, 2)
+	require.Len(t, batch.Offices, 1)
+
+	ministry := batch.Ministries[0]
+	assert.Equal(t, 2, ministry.Number)
+	assert.Equal(t, "Colombo 01", ministry.Address)
+	require.True(t, ministry.HasCoordinates())
+	assert.Equal(t, 6.94, *ministry.Latitude)
+	assert.Equal(t, models.KindDivision, batch.Departments[1].Kind)
+	assert.Equal(t, []string{"091 222 3333", "091 222 4444"}, batch.Offices[0].PhoneNumbers)
+	assert.Equal(t, 5, batch.Offices[0].Number)
+
+	for _, text := range []string{
+		"",
+		"name\nMinistry of Ports\n",
+		"entity,name,colour\nministry,Ministry of Ports,blue\n",
+		"entity,name\n",
+	} {
+		_, err := importer.Parse(strings.NewReader(text), importer.CSV)
+		assert.ErrorIs(t, err, importer.ErrInvalidFile, text)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	batch, err := importer.Parse(strings.NewReader(`{
+		"ministries": [{"name": "Ministry of Ports", "latitude": 6.94, "longitude": 79.84}],
+		"departments": [{"name": "Ports Authority", "ministry": "Ministry of Ports"}],
+		"offices": [{"name": "Galle Office", "department": "Ports Authority", "phone_numbers": ["091 222 3333"]}]
+	}`), importer.JSON)
+	require.NoError(t, err)
+	require.Len(t, batch.Departments, 1)
+	assert.Equal(t, importer.EntityDepartment, batch.Departments[0].Entity)
+	assert.Equal(t, 1, batch.Departments[0].Number)
+
+	_, err = importer.Parse(strings.NewReader(`{"ministries": [{"title": "Ministry of Ports"}]}`), importer.JSON)
+	assert.ErrorIs(t, err, importer.ErrInvalidFile)
+}
+
+func TestRun_Commit(t *testing.T) {
+	repo, healthID := newStore(t)
+
+	report, err := importer.Run(repo, parseCSV(t, organisationsCSV), importer.Options{})
+	require.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Equal(t, 1, report.Ministries)
+	assert.Equal(t, 2, report.Departments)
+	assert.Equal(t, 1, report.Offices)
+	require.NotNil(t, report.Created)
+
+	ports, err := repo.GetMinistryByIDWithDepartments(report.Created.MinistryIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "Ministry of Ports", ports.Name)
+	require.Len(t, ports.Departments, 1)
+	assert.Equal(t, "Ports Authority", ports.Departments[0].Name)
+
+	supplies, err := repo.GetDepartmentByID(report.Created.DepartmentIDs[1])
+	require.NoError(t, err)
+	assert.Equal(t, healthID, supplies.MinistryID)
+
+	offices, err := repo.GetDepartmentOffices(ports.Departments[0].ID)
+	require.NoError(t, err)
+	require.Len(t, offices, 1)
+	assert.Equal(t, "Galle Office", offices[0].Name)
+
+	// The same file again only holds duplicates.
+	report, err = importer.Run(repo, parseCSV(t, organisationsCSV), importer.Options{})
+	assert.ErrorIs(t, err, importer.ErrInvalidRows)
+	assert.Equal(t, 4, report.Invalid)
+}
+
+func TestRun_DryRunReport(t *testing.T) {
+	repo, healthID := newStore(t)
+	_, err := repo.CreateDepartment(models.Department{Name: "Department of Health Services", MinistryID: healthID})
+	require.NoError(t, err)
+
+	batch := parseCSV(t, `entity,name,ministry,ministry_id,department,latitude,longitude
+ministry,,,,,,
+ministry,Ministry of Ports,,,,95,80
+ministry,Ministry of Ports,,,,,
+ministry,Ministry of Health,,,,,
+department,Ports Authority,Ministry of Shipping,,,,
+department,Department of Health Services,,1,,,
+department,Harbour Master,,,,,
+department,Dredging Unit,Ministry of Ports,,,six,80
+office,Galle Office,,,Ports Authority,,
+office,Kandy Office,,,Dredging Unit,,
+agency,Tea Board,,,,,
+`)
+	report, err := importer.Run(repo, batch, importer.Options{DryRun: true})
+	assert.ErrorIs(t, err, importer.ErrInvalidRows)
+	assert.True(t, report.DryRun)
+	assert.False(t, report.Valid)
+	assert.Nil(t, report.Created)
+
+	errorsOnRow := map[int][]string{}
+	for _, row := range report.Rows {
+		errorsOnRow[row.Row] = row.Errors
+	}
+	assert.Equal(t, []string{"name is required"}, errorsOnRow[2])
+	assert.Equal(t, []string{"latitude must be between -90 and 90"}, errorsOnRow[3])
+	assert.Equal(t, []string{"duplicate of the ministry on row 3"}, errorsOnRow[4])
+	assert.Equal(t, []string{`ministry "Ministry of Health" already exists with id 1`}, errorsOnRow[5])
+	assert.Equal(t, []string{`unknown ministry "Ministry of Shipping"`}, errorsOnRow[6])
+	assert.Equal(t, []string{`ministry "Ministry of Health" already has a department "Department of Health Services"`}, errorsOnRow[7])
+	assert.Equal(t, []string{"ministry or ministry_id is required"}, errorsOnRow[8])
+	assert.Equal(t, []string{`latitude "six" is not a number`, `ministry "Ministry of Ports" on row 3 is invalid`}, errorsOnRow[9])
+	assert.Equal(t, []string{`department "Ports Authority" on row 6 is invalid`}, errorsOnRow[10])
+	assert.Equal(t, []string{`department "Dredging Unit" on row 9 is invalid`}, errorsOnRow[11])
+	assert.Equal(t, []string{`entity "agency" must be ministry, department or office`}, errorsOnRow[12])
+	assert.Equal(t, 11, report.Invalid)
+
+	ministries, err := repo.GetMinistriesWithDepartments()
+	require.NoError(t, err)
+	assert.Len(t, ministries, 1)
+}
+
+func TestRun_DryRunValid(t *testing.T) {
+	repo, _ := newStore(t)
+
+	report, err := importer.Run(repo, parseCSV(t, organisationsCSV), importer.Options{DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Nil(t, report.Created)
+
+	ministries, err := repo.GetMinistriesWithDepartments()
+	require.NoError(t, err)
+	assert.Len(t, ministries, 1)
+}
+
+func TestRun_OfficeReferences(t *testing.T) {
+	repo, healthID := newStore(t)
+	educationID, err := repo.CreateMinistry(models.Ministry{Name: "Ministry of Education"})
+	require.NoError(t, err)
+	for _, ministryID := range []int{healthID, educationID} {
+		_, err := repo.CreateDepartment(models.Department{Name: "Planning Division", MinistryID: ministryID})
+		require.NoError(t, err)
+	}
+
+	batch := parseCSV(t, `entity,name,ministry,department,department_id,email
+office,Head Office,,Planning Division,,
+office,Head Office,Ministry of Education,Planning Division,,
+office,Head Office,Ministry of Education,Planning Division,,
+office,Branch,,,99,
+office,Branch,,Planning Division,1,
+office,Annex,,,1,not an address
+`)
+	report, err := importer.Run(repo, batch, importer.Options{DryRun: true})
+	assert.ErrorIs(t, err, importer.ErrInvalidRows)
+	require.Len(t, report.Rows, 6)
+	assert.Equal(t, []string{`department "Planning Division" is ambiguous; give its ministry or use department_id`}, report.Rows[0].Errors)
+	assert.Empty(t, report.Rows[1].Errors)
+	assert.Equal(t, []string{"duplicate of the office on row 3"}, report.Rows[2].Errors)
+	assert.Equal(t, []string{"unknown department_id 99"}, report.Rows[3].Errors)
+	assert.Equal(t, []string{"give department or department_id, not both"}, report.Rows[4].Errors)
+	assert.Equal(t, []string{models.ErrInvalidEmail.Error()}, report.Rows[5].Errors)
+}
+
+func TestWriteReport(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, importer.WriteReport(&out, []importer.RowReport{
+		{Entity: importer.EntityMinistry, Row: 2, Name: "Ministry of Ports"},
+		{Entity: importer.EntityOffice, Row: 3, Errors: []string{"name is required", "unknown department_id 9"}},
+	}))
+	assert.Equal(t, "entity,row,name,status,errors\n"+
+		"ministry,2,Ministry of Ports,ok,\n"+
+		"office,3,,invalid,name is required; unknown department_id 9\n", out.String())
+}
@@ -0,0 +1,245 @@
+// Package importer creates ministries, departments and offices in bulk
+// from a CSV or JSON file. Every row is checked against the rest of the
+// file and the existing records first; the rows are then created in one
+// transaction, or, in a dry run, only reported on.
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-mysql-backend/internal/models"
+)
+
+// Entity is what a row of an import creates.
+type Entity string
+
+const (
+	EntityMinistry   Entity = "ministry"
+	EntityDepartment Entity = "department"
+	EntityOffice     Entity = "office"
+)
+
+// Format is the format of an import file.
+type Format string
+
+const (
+	CSV  Format = "csv"
+	JSON Format = "json"
+)
+
+// ErrInvalidFile is wrapped by the errors of a file that cannot be read
+// at all, as opposed to one with invalid rows.
+var ErrInvalidFile = errors.New("invalid import file")
+
+// Row is one record of an import file. A department names its ministry by
+// name in Ministry or by MinistryID. An office names its department by
+// name in Department, which Ministry may narrow down, or by DepartmentID.
+// Names are matched without regard to case, first against the rows of the
+// file and then against the existing records.
+type Row struct {
+	Entity Entity `json:"-"`
+	// Number is the line of a CSV row, or the position of a JSON row in
+	// its list, counting from 1.
+	Number int `json:"-"`
+
+	Name         string                `json:"name"`
+	Names        models.LocalizedNames `json:"names,omitempty"`
+	Kind         models.OrgKind        `json:"kind,omitempty"`
+	Ministry     string                `json:"ministry,omitempty"`
+	MinistryID   int                   `json:"ministry_id,omitempty"`
+	Department   string                `json:"department,omitempty"`
+	DepartmentID int                   `json:"department_id,omitempty"`
+	PhoneNumbers []string              `json:"phone_numbers,omitempty"`
+	Email        string                `json:"email,omitempty"`
+	models.Location
+
+	// problems are the values of a CSV row that could not be read;
+	// unreadCoordinates is set when a coordinate is among them.
+	problems          []string
+	unreadCoordinates bool
+}
+
+// Batch is the content of an import file. Unknown holds the CSV rows whose
+// entity is none of the three.
+type Batch struct {
+	Ministries  []Row `json:"ministries"`
+	Departments []Row `json:"departments"`
+	Offices     []Row `json:"offices"`
+	Unknown     []Row `json:"-"`
+}
+
+func (b Batch) size() int {
+	return len(b.Ministries) + len(b.Departments) + len(b.Offices) + len(b.Unknown)
+}
+
+// Parse reads an import file in format.
+func Parse(r io.Reader, format Format) (Batch, error) {
+	var batch Batch
+	var err error
+	switch format {
+	case CSV:
+		batch, err = parseCSV(r)
+	case JSON:
+		batch, err = parseJSON(r)
+	default:
+		return Batch{}, fmt.Errorf("%w: format must be csv or json", ErrInvalidFile)
+	}
+	if err != nil {
+		return Batch{}, err
+	}
+	if batch.size() == 0 {
+		return Batch{}, fmt.Errorf("%w: no rows", ErrInvalidFile)
+	}
+	return batch, nil
+}
+
+// parseJSON reads an object with ministries, departments and offices
+// lists. Unknown fields are rejected, so a misspelt one is not silently
+// dropped.
+func parseJSON(r io.Reader) (Batch, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	var batch Batch
+	if err := decoder.Decode(&batch); err != nil {
+		return Batch{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	number(batch.Ministries, EntityMinistry)
+	number(batch.Departments, EntityDepartment)
+	number(batch.Offices, EntityOffice)
+	return batch, nil
+}
+
+func number(rows []Row, entity Entity) {
+	for i := range rows {
+		rows[i].Entity = entity
+		rows[i].Number = i + 1
+	}
+}
+
+// csvColumns are the columns a CSV file may have; entity and name are
+// required. phone_numbers are separated by semicolons.
+var csvColumns = []string{
+	"entity", "name", "name_si", "name_ta", "kind", "ministry", "ministry_id", "department", "department_id",
+	"address", "latitude", "longitude", "phone_numbers", "email",
+}
+
+// parseCSV reads a CSV file with a header row naming its columns, in any
+// order, and one row per ministry, department or office.
+func parseCSV(r io.Reader) (Batch, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return Batch{}, fmt.Errorf("%w: no header row", ErrInvalidFile)
+	} else if err != nil {
+		return Batch{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(csvColumns, name) {
+			return Batch{}, fmt.Errorf("%w: unknown column %q, expected some of %s", ErrInvalidFile, name, strings.Join(csvColumns, ", "))
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"entity", "name"} {
+		if _, ok := columns[required]; !ok {
+			return Batch{}, fmt.Errorf("%w: missing column %q", ErrInvalidFile, required)
+		}
+	}
+
+	var batch Batch
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return batch, nil
+		} else if err != nil {
+			return Batch{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+		row := csvRow(record, columns, line)
+		switch row.Entity {
+		case EntityMinistry:
+			batch.Ministries = append(batch.Ministries, row)
+		case EntityDepartment:
+			batch.Departments = append(batch.Departments, row)
+		case EntityOffice:
+			batch.Offices = append(batch.Offices, row)
+		default:
+			batch.Unknown = append(batch.Unknown, row)
+		}
+	}
+}
+
+func csvRow(record []string, columns map[string]int, line int) Row {
+	value := func(column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := Row{
+		Entity:     Entity(strings.ToLower(value("entity"))),
+		Number:     line,
+		Name:       value("name"),
+		Kind:       models.OrgKind(value("kind")),
+		Ministry:   value("ministry"),
+		Department: value("department"),
+		Email:      value("email"),
+	}
+	row.Address = value("address")
+	for _, locale := range []models.Locale{models.LocaleSinhala, models.LocaleTamil} {
+		if name := value("name_" + string(locale)); name != "" {
+			if row.Names == nil {
+				row.Names = models.LocalizedNames{}
+			}
+			row.Names[locale] = name
+		}
+	}
+	for _, phone := range strings.Split(value("phone_numbers"), ";") {
+		if phone = strings.TrimSpace(phone); phone != "" {
+			row.PhoneNumbers = append(row.PhoneNumbers, phone)
+		}
+	}
+
+	row.MinistryID = row.readID(value("ministry_id"), "ministry_id")
+	row.DepartmentID = row.readID(value("department_id"), "department_id")
+	row.Latitude = row.readCoordinate(value("latitude"), "latitude")
+	row.Longitude = row.readCoordinate(value("longitude"), "longitude")
+	return row
+}
+
+func (row *Row) readID(value, column string) int {
+	if value == "" {
+		return 0
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		row.problems = append(row.problems, fmt.Sprintf("%s %q is not an id", column, value))
+		return 0
+	}
+	return id
+}
+
+func (row *Row) readCoordinate(value, column string) *float64 {
+	if value == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		row.problems = append(row.problems, fmt.Sprintf("%s %q is not a number", column, value))
+		row.unreadCoordinates = true
+		return nil
+	}
+	return &f
+}
@@ -0,0 +1,180 @@
+package mapembed_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go-mysql-backend/internal/mapembed"
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_EmbedForms(t *testing.T) {
+	cases := []struct {
+		name   string
+		script string
+		lat    float64
+		lon    float64
+	}{
+		{
+			name:   "iframe pb centre",
+			script: `<iframe src="https://www.google.com/maps/embed?pb=!1m18!1m12!1m3!1d3960.798!2d79.8540!3d6.9270!2m3!1f0!2f0!3f0!3m2!1i1024!2i768!4f13.1!5e0!3m2!1sen!2slk!4v1700000000000" width="600" height="450"></iframe>`,
+			lat:    6.9270,
+			lon:    79.8540,
+		},
+		{
+			name:   "pb place pin",
+			script: `<iframe src='https://www.google.com/maps/embed?pb=!1m14!1m8!1m3!1d15843.2!2d79.84!3d6.91!3m2!1i1024!2i768!4f13.1!3m3!1m2!1s0x0%3A0x0!2sParliament!8m2!3d6.8868!4d79.9187!5e0'></iframe>`,
+			lat:    6.8868,
+			lon:    79.9187,
+		},
+		{
+			name:   "q coordinates with html entities",
+			script: `<iframe src="https://maps.google.com/maps?q=7.2906,80.6337&amp;z=15&amp;output=embed"></iframe>`,
+			lat:    7.2906,
+			lon:    80.6337,
+		},
+		{
+			name:   "url encoded q",
+			script: `https://maps.google.com/maps?q=6.0535%2C80.2210&output=embed`,
+			lat:    6.0535,
+			lon:    80.2210,
+		},
+		{
+			name:   "viewport url",
+			script: `<a href="#"><iframe src="https://www.google.com/maps/@9.6615,80.0255,14z"></iframe></a>`,
+			lat:    9.6615,
+			lon:    80.0255,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lat, lon, err := mapembed.Parse(tc.script)
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.lat, lat, 1e-9)
+			assert.InDelta(t, tc.lon, lon, 1e-9)
+		})
+	}
+}
+
+func TestParse_Unparseable(t *testing.T) {
+	_, _, err := mapembed.Parse("   ")
+	assert.ErrorIs(t, err, mapembed.ErrEmptyEmbed)
+
+	_, _, err = mapembed.Parse(`<iframe src="https://maps.google.com/maps?q=Parliament+of+Sri+Lanka&output=embed"></iframe>`)
+	assert.ErrorIs(t, err, mapembed.ErrPlaceQuery)
+
+	_, _, err = mapembed.Parse(`<script src='map/12.js'></script>`)
+	assert.ErrorIs(t, err, mapembed.ErrScriptReference)
+
+	_, _, err = mapembed.Parse(`<iframe src="https://maps.google.com/maps?q=96.1,80.2"></iframe>`)
+	assert.ErrorIs(t, err, mapembed.ErrOutOfRange)
+
+	_, _, err = mapembed.Parse(`<div>map goes here</div>`)
+	assert.ErrorIs(t, err, mapembed.ErrNoCoordinates)
+}
+
+type fakeStore struct {
+	ministries  []models.MinistryWithDepartments
+	orphans     []models.Department // departments without a ministry
+	ministryLoc map[int]models.Location
+	deptLoc     map[int]models.Location
+}
+
+func (s *fakeStore) GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error) {
+	return s.ministries, nil
+}
+
+func (s *fakeStore) GetAllDepartments() ([]models.Department, error) {
+	var departments []models.Department
+	for _, m := range s.ministries {
+		departments = append(departments, m.Departments...)
+	}
+	return append(departments, s.orphans...), nil
+}
+
+func (s *fakeStore) UpdateMinistryLocation(id int, loc models.Location) error {
+	s.ministryLoc[id] = loc
+	return nil
+}
+
+func (s *fakeStore) UpdateDepartmentLocation(id int, loc models.Location) error {
+	s.deptLoc[id] = loc
+	return nil
+}
+
+func newFakeStore() *fakeStore {
+	lat, lon := 6.9, 79.8
+	return &fakeStore{
+		ministries: []models.MinistryWithDepartments{
+			{
+				Ministry: models.Ministry{ID: 1, Name: "Ministry of Health", Google_map_script: `<iframe src="https://maps.google.com/maps?q=6.9190,79.8680"></iframe>`, Location: models.Location{Address: "385 Deans Road"}},
+				Departments: []models.Department{
+					{ID: 10, Name: "Medical Supplies", MinistryID: 1, Google_map_script: `<script src='dept/10.js'></script>`},
+					{ID: 11, Name: "Health Promotion", MinistryID: 1, Google_map_script: `https://maps.google.com/maps?q=7.0,80.0`, Location: models.Location{Latitude: &lat, Longitude: &lon}},
+					{ID: 12, Name: "Epidemiology", MinistryID: 1},
+				},
+			},
+		},
+		ministryLoc: map[int]models.Location{},
+		deptLoc:     map[int]models.Location{},
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	store := newFakeStore()
+
+	result, err := mapembed.Migrate(store, mapembed.Options{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Len(t, result.Failures, 1)
+	assert.Equal(t, 10, result.Failures[0].ID)
+
+	loc := store.ministryLoc[1]
+	assert.InDelta(t, 6.9190, *loc.Latitude, 1e-9)
+	assert.InDelta(t, 79.8680, *loc.Longitude, 1e-9)
+	assert.Equal(t, "385 Deans Road", loc.Address)
+	assert.Empty(t, store.deptLoc)
+
+	var report bytes.Buffer
+	assert.NoError(t, mapembed.WriteReport(&report, result.Failures))
+	lines := strings.Split(strings.TrimSpace(report.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[1], "department,10,Medical Supplies,"))
+}
+
+func TestMigrate_DryRunAndOverwrite(t *testing.T) {
+	store := newFakeStore()
+
+	result, err := mapembed.Migrate(store, mapembed.Options{DryRun: true, Overwrite: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Updated)
+	assert.Equal(t, 0, result.Skipped)
+	assert.Empty(t, store.ministryLoc)
+	assert.Empty(t, store.deptLoc)
+}
+
+func TestMigrate_DepartmentWithoutMinistry(t *testing.T) {
+	store := newFakeStore()
+	store.orphans = []models.Department{
+		{ID: 20, Name: "Land Commission", Google_map_script: `https://maps.google.com/maps?q=7.29,80.63`},
+		{ID: 21, Name: "Salt Corporation", Google_map_script: `<script src='dept/21.js'></script>`},
+	}
+
+	result, err := mapembed.Migrate(store, mapembed.Options{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Updated)
+	require.Len(t, result.Failures, 2)
+	assert.Equal(t, 21, result.Failures[1].ID)
+	require.Contains(t, store.deptLoc, 20)
+	assert.InDelta(t, 7.29, *store.deptLoc[20].Latitude, 1e-9)
+}
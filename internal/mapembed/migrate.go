@@ -0,0 +1,126 @@
+package mapembed
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"go-mysql-backend/internal/models"
+)
+
+// LocationStore is the part of a repository the migration needs. Both
+// repository.OrganizationRepository and repository.Neo4jRepository
+// implement it.
+type LocationStore interface {
+	GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error)
+	GetAllDepartments() ([]models.Department, error)
+	UpdateMinistryLocation(id int, loc models.Location) error
+	UpdateDepartmentLocation(id int, loc models.Location) error
+}
+
+// Options controls a migration run.
+type Options struct {
+	// DryRun parses every embed and builds the report without writing.
+	DryRun bool
+	// Overwrite replaces coordinates that are already set.
+	Overwrite bool
+}
+
+// Failure is one row of the report of embeds that could not be parsed.
+type Failure struct {
+	EntityType string
+	ID         int
+	Name       string
+	Reason     string
+	Script     string
+}
+
+// Result summarises a migration run.
+type Result struct {
+	Updated   int
+	Skipped   int
+	Unchanged int
+	Failures  []Failure
+}
+
+// Migrate parses the google_map_script of every ministry and department in
+// store and writes the coordinates it finds. Rows that already have
+// coordinates are skipped unless opts.Overwrite is set, and rows without a
+// script count as unchanged. The address and geometry of a row are kept.
+//
+// Departments are listed on their own rather than under their ministries,
+// since department.ministry_id may be null.
+func Migrate(store LocationStore, opts Options) (Result, error) {
+	var result Result
+
+	ministries, err := store.GetMinistriesWithDepartments()
+	if err != nil {
+		return result, err
+	}
+	departments, err := store.GetAllDepartments()
+	if err != nil {
+		return result, err
+	}
+
+	for _, m := range ministries {
+		err := migrateOne(&result, opts, "ministry", m.ID, m.Name, m.Google_map_script, m.Location, store.UpdateMinistryLocation)
+		if err != nil {
+			return result, err
+		}
+	}
+	for _, d := range departments {
+		err := migrateOne(&result, opts, "department", d.ID, d.Name, d.Google_map_script, d.Location, store.UpdateDepartmentLocation)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func migrateOne(result *Result, opts Options, entityType string, id int, name, script string, loc models.Location,
+	update func(int, models.Location) error) error {
+	if loc.HasCoordinates() && !opts.Overwrite {
+		result.Skipped++
+		return nil
+	}
+
+	lat, lon, err := Parse(script)
+	if err == ErrEmptyEmbed {
+		result.Unchanged++
+		return nil
+	} else if err != nil {
+		result.Failures = append(result.Failures, Failure{
+			EntityType: entityType,
+			ID:         id,
+			Name:       name,
+			Reason:     err.Error(),
+			Script:     script,
+		})
+		return nil
+	}
+
+	loc.Latitude, loc.Longitude = &lat, &lon
+	if !opts.DryRun {
+		if err := update(id, loc); err != nil {
+			return err
+		}
+	}
+	result.Updated++
+	return nil
+}
+
+// WriteReport writes the failures as CSV with a header row.
+func WriteReport(w io.Writer, failures []Failure) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"entity_type", "id", "name", "reason", "google_map_script"}); err != nil {
+		return err
+	}
+	for _, f := range failures {
+		if err := writer.Write([]string{f.EntityType, strconv.Itoa(f.ID), f.Name, f.Reason, f.Script}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
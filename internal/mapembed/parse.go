@@ -0,0 +1,107 @@
+// Package mapembed extracts structured coordinates from the Google Maps
+// iframe and script embeds stored in google_map_script, and backfills them
+// into the latitude/longitude of ministries and departments.
+package mapembed
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrEmptyEmbed      = errors.New("map script is empty")
+	ErrNoCoordinates   = errors.New("no coordinates found in map script")
+	ErrPlaceQuery      = errors.New("map script only has a place-name query; it needs geocoding")
+	ErrScriptReference = errors.New("map script references an external script without coordinates")
+	ErrOutOfRange      = errors.New("coordinates in map script are out of range")
+)
+
+var (
+	srcAttrPattern = regexp.MustCompile(`(?i)src\s*=\s*["']([^"']+)["']`)
+	// Place pins in pb= and /data= parameters: !3d<lat>!4d<lon>.
+	pinPattern = regexp.MustCompile(`!3d(-?\d+(?:\.\d+)?)!4d(-?\d+(?:\.\d+)?)`)
+	// Map centre in embed pb= parameters: !2d<lon>!3d<lat>.
+	centerPattern = regexp.MustCompile(`!2d(-?\d+(?:\.\d+)?)!3d(-?\d+(?:\.\d+)?)`)
+	// Viewport in /maps/@<lat>,<lon>,<zoom>z URLs.
+	atPattern = regexp.MustCompile(`@(-?\d+(?:\.\d+)?),(-?\d+(?:\.\d+)?)`)
+	// "<lat>,<lon>" values of q=, ll=, center= and similar parameters.
+	latLonPattern = regexp.MustCompile(`^\s*(-?\d+(?:\.\d+)?)\s*,\s*(-?\d+(?:\.\d+)?)\s*$`)
+	// <script src='map/12.js'> embeds written by the Neo4j seeder.
+	scriptRefPattern = regexp.MustCompile(`(?i)\.js(?:[?#].*)?$`)
+)
+
+// coordinateParams are the query parameters that may carry "<lat>,<lon>".
+var coordinateParams = []string{"q", "ll", "center", "query", "destination", "daddr"}
+
+// Parse returns the latitude and longitude found in a Google Maps embed.
+// It understands iframe and script tags as well as bare URLs, and looks at
+// (in order) pb=/data= pins, pb= map centres, @lat,lon viewports and
+// q=/ll=/center= parameters.
+func Parse(script string) (lat, lon float64, err error) {
+	text := strings.TrimSpace(html.UnescapeString(script))
+	if text == "" {
+		return 0, 0, ErrEmptyEmbed
+	}
+
+	src := text
+	if match := srcAttrPattern.FindStringSubmatch(text); match != nil {
+		src = strings.TrimSpace(match[1])
+	}
+	decoded := src
+	if unescaped, err := url.QueryUnescape(src); err == nil {
+		decoded = unescaped
+	}
+
+	if match := pinPattern.FindStringSubmatch(decoded); match != nil {
+		return parsePair(match[1], match[2])
+	}
+	if match := centerPattern.FindStringSubmatch(decoded); match != nil {
+		return parsePair(match[2], match[1])
+	}
+	if match := atPattern.FindStringSubmatch(decoded); match != nil {
+		return parsePair(match[1], match[2])
+	}
+
+	placeQuery := false
+	if u, err := url.Parse(src); err == nil {
+		query := u.Query()
+		for _, name := range coordinateParams {
+			value := query.Get(name)
+			if value == "" {
+				continue
+			}
+			if match := latLonPattern.FindStringSubmatch(value); match != nil {
+				return parsePair(match[1], match[2])
+			}
+			placeQuery = true
+		}
+		if scriptRefPattern.MatchString(u.Path) {
+			return 0, 0, fmt.Errorf("%w (%s)", ErrScriptReference, u.Path)
+		}
+	}
+
+	if placeQuery {
+		return 0, 0, ErrPlaceQuery
+	}
+	return 0, 0, ErrNoCoordinates
+}
+
+func parsePair(latStr, lonStr string) (float64, float64, error) {
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil {
+		return 0, 0, ErrNoCoordinates
+	}
+	lon, err := strconv.ParseFloat(lonStr, 64)
+	if err != nil {
+		return 0, 0, ErrNoCoordinates
+	}
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, ErrOutOfRange
+	}
+	return lat, lon, nil
+}
@@ -0,0 +1,248 @@
+// Package migrations holds the versioned schema of the Postgres and Neo4j
+// backends, embedded in the binary, and applies it. Each migration is a
+// pair of scripts, NNNN_name.up.sql and NNNN_name.down.sql (.cypher for
+// Neo4j), applied in version order. The database records the version and
+// checksum of every migration applied to it, so a script edited after it
+// was applied, or a database migrated by newer code, is detected rather
+// than silently run against.
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed postgres/*.sql neo4j/*.cypher
+var files embed.FS
+
+// Dialect names a set of migrations, kept in the directory of that name.
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	Neo4j    Dialect = "neo4j"
+)
+
+// Migration is one step of a schema.
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // hex SHA-256 of Up
+}
+
+// Record is a migration as the database recorded it when it was applied.
+type Record struct {
+	Version   int
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Target is a database migrations are applied to.
+type Target interface {
+	// Applied returns the recorded migrations ordered by version,
+	// creating where they are recorded first if needed.
+	Applied() ([]Record, error)
+	// Apply runs m.Up and records m.
+	Apply(m Migration) error
+	// Revert runs m.Down and removes the record of m.
+	Revert(m Migration) error
+}
+
+var (
+	// ErrPending means the database lacks migrations the code expects.
+	ErrPending = errors.New("schema migrations are pending")
+	// ErrModified means an applied migration has since been edited.
+	ErrModified = errors.New("applied migration has been modified")
+	// ErrUnknown means the database has a migration this code does not
+	// know, so it was migrated by newer code.
+	ErrUnknown = errors.New("database has a migration unknown to this version")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.(sql|cypher)$`)
+
+// Load returns the migrations of dialect in version order.
+func Load(dialect Dialect) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, string(dialect))
+	if err != nil {
+		return nil, fmt.Errorf("unknown migration dialect %q: %w", dialect, err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %s/%s: name is not NNNN_name.up|down.ext", dialect, entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		script, err := fs.ReadFile(files, path.Join(string(dialect), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %s/%04d has two names, %s and %s", dialect, version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(script)
+		} else {
+			m.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %s/%04d_%s needs both an up and a down script", dialect, m.Version, m.Name)
+		}
+		sum := sha256.Sum256([]byte(m.Up))
+		m.Checksum = hex.EncodeToString(sum[:])
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// State is a migration together with its record, if it has been applied.
+type State struct {
+	Migration Migration
+	Applied   *Record
+}
+
+// Modified reports whether the migration was applied with another script.
+func (s State) Modified() bool {
+	return s.Applied != nil && s.Applied.Checksum != s.Migration.Checksum
+}
+
+// Status returns the state of every migration in migrations, followed by
+// any recorded migrations it does not include, which have no Up or Down.
+func Status(t Target, migrations []Migration) ([]State, error) {
+	records, err := t.Applied()
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]Record, len(records))
+	for _, r := range records {
+		byVersion[r.Version] = r
+	}
+
+	states := make([]State, 0, len(migrations))
+	for _, m := range migrations {
+		state := State{Migration: m}
+		if r, ok := byVersion[m.Version]; ok {
+			state.Applied = &r
+			delete(byVersion, m.Version)
+		}
+		states = append(states, state)
+	}
+	for _, r := range records {
+		if _, unknown := byVersion[r.Version]; unknown {
+			states = append(states, State{Migration: Migration{Version: r.Version, Name: r.Name, Checksum: r.Checksum}, Applied: &r})
+		}
+	}
+	return states, nil
+}
+
+// Check returns nil when the database has exactly the migrations given,
+// unmodified, and otherwise an error wrapping ErrModified, ErrUnknown or
+// ErrPending, in that order of precedence.
+func Check(t Target, migrations []Migration) error {
+	states, err := Status(t, migrations)
+	if err != nil {
+		return err
+	}
+	if err := verify(states, len(migrations)); err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, s := range states {
+		if s.Applied == nil {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: %d of %d not applied", ErrPending, pending, len(migrations))
+	}
+	return nil
+}
+
+// Up applies the pending migrations in order and returns those it
+// applied. It refuses to run on a database with modified or unknown
+// migrations, and stops at the first migration that fails.
+func Up(t Target, migrations []Migration) ([]Migration, error) {
+	states, err := Status(t, migrations)
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(states, len(migrations)); err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, s := range states {
+		if s.Applied != nil {
+			continue
+		}
+		if err := t.Apply(s.Migration); err != nil {
+			return applied, fmt.Errorf("migration %04d_%s: %w", s.Migration.Version, s.Migration.Name, err)
+		}
+		applied = append(applied, s.Migration)
+	}
+	return applied, nil
+}
+
+// Down reverts up to steps of the applied migrations, latest first, and
+// returns those it reverted.
+func Down(t Target, migrations []Migration, steps int) ([]Migration, error) {
+	states, err := Status(t, migrations)
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(states, len(migrations)); err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	for i := len(states) - 1; i >= 0 && len(reverted) < steps; i-- {
+		if states[i].Applied == nil {
+			continue
+		}
+		m := states[i].Migration
+		if err := t.Revert(m); err != nil {
+			return reverted, fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		reverted = append(reverted, m)
+	}
+	return reverted, nil
+}
+
+// verify rejects modified migrations, and the unknown ones Status lists
+// after the known ones.
+func verify(states []State, known int) error {
+	for _, s := range states[:known] {
+		if s.Modified() {
+			return fmt.Errorf("%w: %04d_%s", ErrModified, s.Migration.Version, s.Migration.Name)
+		}
+	}
+	if len(states) > known {
+		s := states[known]
+		return fmt.Errorf("%w: %04d_%s", ErrUnknown, s.Migration.Version, s.Migration.Name)
+	}
+	return nil
+}
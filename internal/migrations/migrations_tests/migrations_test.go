@@ -0,0 +1,161 @@
+package migrations_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go-mysql-backend/internal/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTarget records migrations in memory and can fail one version.
+type fakeTarget struct {
+	records []migrations.Record
+	failOn  int
+}
+
+func (f *fakeTarget) Applied() ([]migrations.Record, error) {
+	return append([]migrations.Record(nil), f.records...), nil
+}
+
+func (f *fakeTarget) Apply(m migrations.Migration) error {
+	if m.Version == f.failOn {
+		return errors.New("boom")
+	}
+	f.records = append(f.records, migrations.Record{Version: m.Version, Name: m.Name, Checksum: m.Checksum, AppliedAt: time.Now()})
+	return nil
+}
+
+func (f *fakeTarget) Revert(m migrations.Migration) error {
+	for i, r := range f.records {
+		if r.Version == m.Version {
+			f.records = append(f.records[:i], f.records[i+1:]...)
+		}
+	}
+	return nil
+}
+
+func versions(ms []migrations.Migration) []int {
+	result := make([]int, len(ms))
+	for i, m := range ms {
+		result[i] = m.Version
+	}
+	return result
+}
+
+func TestLoad(t *testing.T) {
+	for _, dialect := range []migrations.Dialect{migrations.Postgres, migrations.Neo4j} {
+		all, err := migrations.Load(dialect)
+		require.NoError(t, err, dialect)
+		require.NotEmpty(t, all, dialect)
+		for i, m := range all {
+			assert.Equal(t, i+1, m.Version, "%s versions are consecutive from 1", dialect)
+			assert.NotEmpty(t, m.Up)
+			assert.NotEmpty(t, m.Down)
+			assert.Len(t, m.Checksum, 64)
+		}
+	}
+
+	_, err := migrations.Load("mysql")
+	assert.Error(t, err)
+}
+
+func TestUpAndDown(t *testing.T) {
+	all, err := migrations.Load(migrations.Postgres)
+	require.NoError(t, err)
+	target := &fakeTarget{}
+
+	assert.ErrorIs(t, migrations.Check(target, all), migrations.ErrPending)
+
+	applied, err := migrations.Up(target, all[:2])
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions(applied))
+
+	applied, err = migrations.Up(target, all)
+	require.NoError(t, err)
+	assert.Equal(t, versions(all[2:]), versions(applied))
+	assert.NoError(t, migrations.Check(target, all))
+
+	applied, err = migrations.Up(target, all)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	reverted, err := migrations.Down(target, all, 2)
+	require.NoError(t, err)
+	last := len(all)
+	assert.Equal(t, []int{last, last - 1}, versions(reverted))
+	assert.ErrorIs(t, migrations.Check(target, all), migrations.ErrPending)
+
+	reverted, err = migrations.Down(target, all, 100)
+	require.NoError(t, err)
+	assert.Len(t, reverted, last-2)
+	assert.Empty(t, target.records)
+}
+
+func TestUp_StopsAtFailure(t *testing.T) {
+	all, err := migrations.Load(migrations.Postgres)
+	require.NoError(t, err)
+	target := &fakeTarget{failOn: 3}
+
+	applied, err := migrations.Up(target, all)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "0003_")
+	assert.Equal(t, []int{1, 2}, versions(applied))
+	assert.Len(t, target.records, 2)
+}
+
+func TestCheck_Modified(t *testing.T) {
+	all, err := migrations.Load(migrations.Postgres)
+	require.NoError(t, err)
+	target := &fakeTarget{}
+	_, err = migrations.Up(target, all)
+	require.NoError(t, err)
+
+	target.records[1].Checksum = "edited"
+	assert.ErrorIs(t, migrations.Check(target, all), migrations.ErrModified)
+	_, err = migrations.Up(target, all)
+	assert.ErrorIs(t, err, migrations.ErrModified)
+	_, err = migrations.Down(target, all, 1)
+	assert.ErrorIs(t, err, migrations.ErrModified)
+}
+
+func TestCheck_Unknown(t *testing.T) {
+	all, err := migrations.Load(migrations.Postgres)
+	require.NoError(t, err)
+	target := &fakeTarget{}
+	_, err = migrations.Up(target, all)
+	require.NoError(t, err)
+	target.records = append(target.records, migrations.Record{Version: 99, Name: "from_the_future", Checksum: "x"})
+
+	assert.ErrorIs(t, migrations.Check(target, all), migrations.ErrUnknown)
+	_, err = migrations.Up(target, all)
+	assert.ErrorIs(t, err, migrations.ErrUnknown)
+
+	states, err := migrations.Status(target, all)
+	require.NoError(t, err)
+	require.Len(t, states, len(all)+1)
+	unknown := states[len(all)]
+	assert.Equal(t, 99, unknown.Migration.Version)
+	assert.Equal(t, "from_the_future", unknown.Migration.Name)
+	assert.NotNil(t, unknown.Applied)
+}
+
+func TestStatus(t *testing.T) {
+	all, err := migrations.Load(migrations.Neo4j)
+	require.NoError(t, err)
+	target := &fakeTarget{}
+	_, err = migrations.Up(target, all[:1])
+	require.NoError(t, err)
+
+	states, err := migrations.Status(target, all)
+	require.NoError(t, err)
+	require.Len(t, states, len(all))
+	assert.NotNil(t, states[0].Applied)
+	assert.False(t, states[0].Modified())
+	for _, s := range states[1:] {
+		assert.Nil(t, s.Applied)
+	}
+}
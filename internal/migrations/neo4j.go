@@ -0,0 +1,107 @@
+package migrations
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Neo4jTarget records migrations as (:SchemaMigration) nodes. Neo4j cannot
+// change its schema and data in one transaction, nor run several
+// statements in one query, so each statement of a script runs on its own
+// and the record is written last. Scripts use IF [NOT] EXISTS throughout,
+// so a migration that failed part way can simply be run again.
+type Neo4jTarget struct {
+	Driver neo4j.DriverWithContext
+}
+
+func NewNeo4jTarget(driver neo4j.DriverWithContext) *Neo4jTarget {
+	return &Neo4jTarget{Driver: driver}
+}
+
+func (t *Neo4jTarget) Applied() ([]Record, error) {
+	if err := t.run(`CREATE CONSTRAINT schema_migration_version IF NOT EXISTS
+		FOR (m:SchemaMigration) REQUIRE m.version IS UNIQUE`, nil); err != nil {
+		return nil, err
+	}
+
+	result, err := neo4j.ExecuteQuery(context.Background(), t.Driver, `
+		MATCH (m:SchemaMigration)
+		RETURN m.version AS version, m.name AS name, m.checksum AS checksum, m.applied_at AS applied_at
+		ORDER BY m.version
+	`, nil, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, 0, len(result.Records))
+	for _, rec := range result.Records {
+		version, _, err := neo4j.GetRecordValue[int64](rec, "version")
+		if err != nil {
+			return nil, err
+		}
+		name, _, err := neo4j.GetRecordValue[string](rec, "name")
+		if err != nil {
+			return nil, err
+		}
+		checksum, _, err := neo4j.GetRecordValue[string](rec, "checksum")
+		if err != nil {
+			return nil, err
+		}
+		appliedAt, _, err := neo4j.GetRecordValue[time.Time](rec, "applied_at")
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, Record{Version: int(version), Name: name, Checksum: checksum, AppliedAt: appliedAt})
+	}
+	return records, nil
+}
+
+func (t *Neo4jTarget) Apply(m Migration) error {
+	for _, statement := range statements(m.Up) {
+		if err := t.run(statement, nil); err != nil {
+			return err
+		}
+	}
+	return t.run(`CREATE (:SchemaMigration {version: $version, name: $name, checksum: $checksum, applied_at: datetime()})`,
+		map[string]interface{}{"version": m.Version, "name": m.Name, "checksum": m.Checksum})
+}
+
+func (t *Neo4jTarget) Revert(m Migration) error {
+	for _, statement := range statements(m.Down) {
+		if err := t.run(statement, nil); err != nil {
+			return err
+		}
+	}
+	return t.run(`MATCH (m:SchemaMigration {version: $version}) DELETE m`, map[string]interface{}{"version": m.Version})
+}
+
+func (t *Neo4jTarget) run(query string, params map[string]interface{}) error {
+	_, err := neo4j.ExecuteQuery(context.Background(), t.Driver, query, params, neo4j.EagerResultTransformer)
+	return err
+}
+
+// statements splits a script into its statements, which end with a
+// semicolon at the end of a line. Lines starting with // are comments.
+func statements(script string) []string {
+	var result []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			result = append(result, strings.TrimSuffix(strings.TrimSpace(current.String()), ";"))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		result = append(result, rest)
+	}
+	return result
+}
@@ -0,0 +1,7 @@
+DROP CONSTRAINT sequence_name IF EXISTS;
+DROP CONSTRAINT person_id IF EXISTS;
+DROP CONSTRAINT admin_area_level_code IF EXISTS;
+DROP CONSTRAINT admin_area_id IF EXISTS;
+DROP CONSTRAINT office_id IF EXISTS;
+DROP CONSTRAINT department_id IF EXISTS;
+DROP CONSTRAINT ministry_id IF EXISTS;
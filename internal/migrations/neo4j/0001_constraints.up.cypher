@@ -0,0 +1,9 @@
+// Ids are handed out by nextID from :Sequence nodes; the constraints keep
+// them unique and index the lookups by id.
+CREATE CONSTRAINT ministry_id IF NOT EXISTS FOR (n:Ministry) REQUIRE n.id IS UNIQUE;
+CREATE CONSTRAINT department_id IF NOT EXISTS FOR (n:Department) REQUIRE n.id IS UNIQUE;
+CREATE CONSTRAINT office_id IF NOT EXISTS FOR (n:Office) REQUIRE n.id IS UNIQUE;
+CREATE CONSTRAINT admin_area_id IF NOT EXISTS FOR (n:AdminArea) REQUIRE n.id IS UNIQUE;
+CREATE CONSTRAINT admin_area_level_code IF NOT EXISTS FOR (n:AdminArea) REQUIRE (n.level, n.code) IS UNIQUE;
+CREATE CONSTRAINT person_id IF NOT EXISTS FOR (n:Person) REQUIRE n.id IS UNIQUE;
+CREATE CONSTRAINT sequence_name IF NOT EXISTS FOR (n:Sequence) REQUIRE n.name IS UNIQUE;
@@ -0,0 +1,3 @@
+DROP INDEX gazette_change_gazette IF EXISTS;
+DROP INDEX department_version_department IF EXISTS;
+DROP INDEX ministry_version_ministry IF EXISTS;
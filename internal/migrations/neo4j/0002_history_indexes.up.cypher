@@ -0,0 +1,4 @@
+// Version nodes and gazette changes are looked up by the record they describe.
+CREATE INDEX ministry_version_ministry IF NOT EXISTS FOR (v:MinistryVersion) ON (v.ministry_id);
+CREATE INDEX department_version_department IF NOT EXISTS FOR (v:DepartmentVersion) ON (v.department_id);
+CREATE INDEX gazette_change_gazette IF NOT EXISTS FOR (c:GazetteChange) ON (c.gazette);
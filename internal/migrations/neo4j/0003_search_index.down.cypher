@@ -0,0 +1 @@
+DROP INDEX organization_search_v2 IF EXISTS;
@@ -0,0 +1,6 @@
+// Full-text index used by Search. Full-text indexes cannot be altered, so
+// a change to the indexed properties replaces the index under a new name
+// in a new migration, and repository.searchIndex follows it.
+DROP INDEX organization_search IF EXISTS;
+CREATE FULLTEXT INDEX organization_search_v2 IF NOT EXISTS
+FOR (n:Ministry|Department|Office) ON EACH [n.name, n.name_si, n.name_ta, n.name_key, n.address];
@@ -0,0 +1,2 @@
+// The versions opened are history from then on, indistinguishable from
+// those written since, so reverting keeps them.
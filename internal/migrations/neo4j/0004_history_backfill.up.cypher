@@ -0,0 +1,19 @@
+// Open a version, from today, for every ministry and department that has
+// none, as 0003_history does in Postgres; writes only open versions for the
+// nodes they change.
+MATCH (m:Ministry)
+WHERE NOT EXISTS { MATCH (v:MinistryVersion {ministry_id: m.id}) WHERE v.valid_to IS NULL }
+OPTIONAL MATCH (pm:Ministry)-[:HAS_MINISTRY]->(m)
+CREATE (:MinistryVersion {
+    ministry_id: m.id, name: m.name, name_si: m.name_si, name_ta: m.name_ta, kind: coalesce(m.kind, 'ministry'),
+    parent_id: pm.id, valid_from: toString(date())
+});
+
+MATCH (d:Department)
+WHERE NOT EXISTS { MATCH (v:DepartmentVersion {department_id: d.id}) WHERE v.valid_to IS NULL }
+OPTIONAL MATCH (m:Ministry)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(d)
+OPTIONAL MATCH (pd:Department)-[:HAS_UNIT]->(d)
+CREATE (:DepartmentVersion {
+    department_id: d.id, name: d.name, name_si: d.name_si, name_ta: d.name_ta, kind: coalesce(d.kind, 'department'),
+    parent_id: pd.id, ministry_id: m.id, valid_from: toString(date())
+});
@@ -0,0 +1,3 @@
+// The entity and organization_id properties are left on the terms.
+DROP CONSTRAINT former_organization_entity_id IF EXISTS;
+DROP INDEX holds_position_organization IF EXISTS;
@@ -0,0 +1,11 @@
+// Terms name their organisation on the HOLDS_POSITION relationship, so they
+// outlive it; a deleted organisation leaves a :FormerOrganization node for
+// its terms to point at.
+MATCH (:Person)-[t:HOLDS_POSITION]->(o)
+WHERE t.entity IS NULL
+SET t.entity = CASE WHEN o:Ministry THEN 'ministry' ELSE 'department' END, t.organization_id = o.id;
+
+CREATE INDEX holds_position_organization IF NOT EXISTS
+FOR ()-[t:HOLDS_POSITION]-() ON (t.entity, t.organization_id);
+CREATE CONSTRAINT former_organization_entity_id IF NOT EXISTS
+FOR (n:FormerOrganization) REQUIRE (n.entity, n.id) IS UNIQUE;
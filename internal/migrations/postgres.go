@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"database/sql"
+)
+
+// PostgresTarget records migrations in the schema_migrations table. Each
+// migration runs in a transaction with its record, so it is applied or
+// reverted entirely or not at all; two processes migrating at once
+// collide on the record's primary key and one of them rolls back.
+type PostgresTarget struct {
+	DB *sql.DB
+}
+
+func NewPostgresTarget(db *sql.DB) *PostgresTarget {
+	return &PostgresTarget{DB: db}
+}
+
+func (t *PostgresTarget) Applied() ([]Record, error) {
+	_, err := t.DB.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := t.DB.Query(`SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var r Record
+		if err := rows.Scan(&r.Version, &r.Name, &r.Checksum, &r.AppliedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+func (t *PostgresTarget) Apply(m Migration) error {
+	return t.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(m.Up); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+			m.Version, m.Name, m.Checksum)
+		return err
+	})
+}
+
+func (t *PostgresTarget) Revert(m Migration) error {
+	return t.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(m.Down); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`DELETE FROM schema_migrations WHERE version = $1`, m.Version)
+		return err
+	})
+}
+
+func (t *PostgresTarget) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
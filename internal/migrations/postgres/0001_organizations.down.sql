@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS department;
+DROP TABLE IF EXISTS ministry;
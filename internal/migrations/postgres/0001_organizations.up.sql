@@ -0,0 +1,14 @@
+-- Ministries and their departments, as first released. Existing databases
+-- already have these tables; the columns added since come in 0002.
+CREATE TABLE IF NOT EXISTS ministry (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    google_map_script TEXT
+);
+
+CREATE TABLE IF NOT EXISTS department (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    ministry_id INTEGER REFERENCES ministry(id),
+    google_map_script TEXT
+);
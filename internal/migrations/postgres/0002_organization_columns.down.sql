@@ -0,0 +1,22 @@
+ALTER TABLE department
+    DROP COLUMN IF EXISTS admin_area_id,
+    DROP COLUMN IF EXISTS geometry,
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS parent_id,
+    DROP COLUMN IF EXISTS kind,
+    DROP COLUMN IF EXISTS search_key,
+    DROP COLUMN IF EXISTS names;
+
+ALTER TABLE ministry
+    DROP COLUMN IF EXISTS geometry,
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS parent_id,
+    DROP COLUMN IF EXISTS kind,
+    DROP COLUMN IF EXISTS search_key,
+    DROP COLUMN IF EXISTS names;
+
+DROP TABLE IF EXISTS admin_area;
@@ -0,0 +1,43 @@
+-- Locations, localized names, kinds, hierarchy and the administrative
+-- areas departments serve. Existing rows get the defaults: no location,
+-- no localized names, and kind ministry or department at the top level.
+CREATE TABLE IF NOT EXISTS admin_area (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(32) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    level VARCHAR(16) NOT NULL CHECK (level IN ('province', 'district', 'ds_division', 'gn_division')),
+    parent_id INTEGER REFERENCES admin_area(id),
+    geometry JSONB,
+    UNIQUE (level, code)
+);
+
+ALTER TABLE ministry
+    ADD COLUMN IF NOT EXISTS names JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS search_key TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS kind VARCHAR(32) NOT NULL DEFAULT 'ministry'
+        CHECK (kind IN ('ministry', 'state_ministry', 'statutory_board', 'agency')),
+    ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES ministry(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    ADD COLUMN IF NOT EXISTS address TEXT,
+    ADD COLUMN IF NOT EXISTS geometry JSONB;
+
+ALTER TABLE department
+    ADD COLUMN IF NOT EXISTS names JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS search_key TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS kind VARCHAR(32) NOT NULL DEFAULT 'department'
+        CHECK (kind IN ('department', 'agency', 'division', 'unit')),
+    ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES department(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    ADD COLUMN IF NOT EXISTS address TEXT,
+    ADD COLUMN IF NOT EXISTS geometry JSONB,
+    ADD COLUMN IF NOT EXISTS admin_area_id INTEGER REFERENCES admin_area(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS ministry_parent_idx ON ministry (parent_id);
+CREATE INDEX IF NOT EXISTS department_parent_idx ON department (parent_id);
+CREATE INDEX IF NOT EXISTS admin_area_parent_idx ON admin_area (parent_id);
+CREATE INDEX IF NOT EXISTS department_admin_area_idx ON department (admin_area_id);
+
+CREATE INDEX IF NOT EXISTS ministry_lat_lon_idx ON ministry (latitude, longitude);
+CREATE INDEX IF NOT EXISTS department_lat_lon_idx ON department (latitude, longitude);
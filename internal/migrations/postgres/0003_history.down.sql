@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS department_version;
+DROP TABLE IF EXISTS ministry_version;
@@ -0,0 +1,37 @@
+-- History of the structure; rows outlive the ministry or department they describe.
+CREATE TABLE IF NOT EXISTS ministry_version (
+    id SERIAL PRIMARY KEY,
+    ministry_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    names JSONB NOT NULL DEFAULT '{}',
+    kind VARCHAR(32) NOT NULL,
+    parent_id INTEGER,
+    valid_from DATE NOT NULL,
+    valid_to DATE CHECK (valid_to > valid_from)
+);
+
+CREATE TABLE IF NOT EXISTS department_version (
+    id SERIAL PRIMARY KEY,
+    department_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    names JSONB NOT NULL DEFAULT '{}',
+    kind VARCHAR(32) NOT NULL,
+    parent_id INTEGER,
+    ministry_id INTEGER,
+    valid_from DATE NOT NULL,
+    valid_to DATE CHECK (valid_to > valid_from)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ministry_version_open_idx ON ministry_version (ministry_id) WHERE valid_to IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS department_version_open_idx ON department_version (department_id) WHERE valid_to IS NULL;
+CREATE INDEX IF NOT EXISTS ministry_version_range_idx ON ministry_version (valid_from, valid_to);
+CREATE INDEX IF NOT EXISTS department_version_range_idx ON department_version (valid_from, valid_to);
+
+-- Open a version, from today, for every row that has none.
+INSERT INTO ministry_version (ministry_id, name, names, kind, parent_id, valid_from)
+SELECT m.id, m.name, m.names, m.kind, m.parent_id, CURRENT_DATE FROM ministry m
+WHERE NOT EXISTS (SELECT 1 FROM ministry_version v WHERE v.ministry_id = m.id AND v.valid_to IS NULL);
+
+INSERT INTO department_version (department_id, name, names, kind, parent_id, ministry_id, valid_from)
+SELECT d.id, d.name, d.names, d.kind, d.parent_id, d.ministry_id, CURRENT_DATE FROM department d
+WHERE NOT EXISTS (SELECT 1 FROM department_version v WHERE v.department_id = d.id AND v.valid_to IS NULL);
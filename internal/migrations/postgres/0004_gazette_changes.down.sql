@@ -0,0 +1 @@
+DROP TABLE IF EXISTS gazette_change;
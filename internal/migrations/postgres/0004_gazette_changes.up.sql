@@ -0,0 +1,11 @@
+-- Operations applied from gazette change sets.
+CREATE TABLE IF NOT EXISTS gazette_change (
+    id SERIAL PRIMARY KEY,
+    gazette VARCHAR(64) NOT NULL,
+    effective_date DATE NOT NULL,
+    type VARCHAR(16) NOT NULL,
+    operation JSONB NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS gazette_change_gazette_idx ON gazette_change (gazette);
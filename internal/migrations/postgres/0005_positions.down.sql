@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS position_term;
+DROP TABLE IF EXISTS person;
@@ -0,0 +1,20 @@
+-- Office-holders; a term outlives the organisation it names, so there is no foreign key to it.
+CREATE TABLE IF NOT EXISTS person (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    title VARCHAR(64)
+);
+
+CREATE TABLE IF NOT EXISTS position_term (
+    id SERIAL PRIMARY KEY,
+    person_id INTEGER NOT NULL REFERENCES person(id) ON DELETE CASCADE,
+    role VARCHAR(32) NOT NULL
+        CHECK (role IN ('minister', 'state_minister', 'deputy_minister', 'secretary', 'head')),
+    entity VARCHAR(16) NOT NULL CHECK (entity IN ('ministry', 'department')),
+    organization_id INTEGER NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE CHECK (end_date > start_date)
+);
+
+CREATE INDEX IF NOT EXISTS position_term_org_idx ON position_term (entity, organization_id);
+CREATE INDEX IF NOT EXISTS position_term_person_idx ON position_term (person_id);
@@ -0,0 +1,16 @@
+-- Branch and regional offices of a department.
+CREATE TABLE IF NOT EXISTS office (
+    id SERIAL PRIMARY KEY,
+    department_id INTEGER NOT NULL REFERENCES department(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    phone_numbers TEXT[] NOT NULL DEFAULT '{}',
+    email VARCHAR(255),
+    hours JSONB NOT NULL DEFAULT '[]',
+    closures JSONB NOT NULL DEFAULT '[]',
+    latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    address TEXT,
+    geometry JSONB
+);
+
+CREATE INDEX IF NOT EXISTS office_department_idx ON office (department_id);
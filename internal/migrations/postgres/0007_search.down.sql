@@ -0,0 +1,3 @@
+ALTER TABLE office DROP COLUMN IF EXISTS search;
+ALTER TABLE department DROP COLUMN IF EXISTS search;
+ALTER TABLE ministry DROP COLUMN IF EXISTS search;
@@ -0,0 +1,21 @@
+-- Full-text search over names and addresses.
+ALTER TABLE ministry ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('simple', names), 'A') ||
+    setweight(to_tsvector('simple', search_key), 'B') ||
+    setweight(to_tsvector('english', coalesce(address, '')), 'C')
+) STORED;
+ALTER TABLE department ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('simple', names), 'A') ||
+    setweight(to_tsvector('simple', search_key), 'B') ||
+    setweight(to_tsvector('english', coalesce(address, '')), 'C')
+) STORED;
+ALTER TABLE office ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', coalesce(address, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS ministry_search_idx ON ministry USING GIN (search);
+CREATE INDEX IF NOT EXISTS department_search_idx ON department USING GIN (search);
+CREATE INDEX IF NOT EXISTS office_search_idx ON office USING GIN (search);
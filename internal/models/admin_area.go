@@ -0,0 +1,70 @@
+package models
+
+import "encoding/json"
+
+// AdminLevel is a tier of the administrative hierarchy.
+type AdminLevel string
+
+const (
+	LevelProvince   AdminLevel = "province"
+	LevelDistrict   AdminLevel = "district"
+	LevelDSDivision AdminLevel = "ds_division" // divisional secretariat
+	LevelGNDivision AdminLevel = "gn_division" // grama niladhari division
+)
+
+// AdminLevels lists the levels from the top of the hierarchy down.
+var AdminLevels = []AdminLevel{LevelProvince, LevelDistrict, LevelDSDivision, LevelGNDivision}
+
+// Valid reports whether l is one of AdminLevels.
+func (l AdminLevel) Valid() bool {
+	return l.depth() >= 0
+}
+
+// Parent returns the level directly above l, or "" for provinces.
+func (l AdminLevel) Parent() AdminLevel {
+	if d := l.depth(); d > 0 {
+		return AdminLevels[d-1]
+	}
+	return ""
+}
+
+func (l AdminLevel) depth() int {
+	for i, level := range AdminLevels {
+		if level == l {
+			return i
+		}
+	}
+	return -1
+}
+
+// AdminArea is a province, district, divisional secretariat or GN division.
+// Code is the official code, unique within a level; ParentCode is the code
+// of the parent area and is used when loading boundaries. Geometry is a
+// GeoJSON Polygon or MultiPolygon and is only returned for single areas.
+type AdminArea struct {
+	ID         int             `json:"id,omitempty"`
+	Code       string          `json:"code"`
+	Name       string          `json:"name"`
+	Level      AdminLevel      `json:"level"`
+	ParentID   *int            `json:"parent_id,omitempty"`
+	ParentCode string          `json:"parent_code,omitempty"`
+	Geometry   json.RawMessage `json:"geometry,omitempty"`
+}
+
+// Jurisdiction answers which areas contain a point and who is responsible
+// for it. Areas run from the province down to the smallest containing area,
+// each with the departments linked to it; Departments lists the departments
+// whose own boundary polygon contains the point.
+type Jurisdiction struct {
+	Latitude    float64            `json:"latitude"`
+	Longitude   float64            `json:"longitude"`
+	Areas       []AreaJurisdiction `json:"areas"`
+	Departments []Department       `json:"departments"`
+}
+
+// AreaJurisdiction is one containing area of a Jurisdiction. The area is
+// returned without its geometry.
+type AreaJurisdiction struct {
+	AdminArea
+	Departments []Department `json:"departments"`
+}
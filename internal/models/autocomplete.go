@@ -0,0 +1,36 @@
+package models
+
+// AutocompleteEntities lists the entities autocomplete suggests, in the
+// order suggestions of equal rank are returned.
+var AutocompleteEntities = []SearchEntity{SearchMinistry, SearchDepartment}
+
+// AutocompleteQuery asks for the names that start with what has been typed
+// so far. Entities is never empty once the query has been read.
+type AutocompleteQuery struct {
+	Text     string
+	Entities []SearchEntity
+	Limit    int
+}
+
+// Suggestion is one name offered for an autocomplete query. ParentID is
+// the ministry of a department.
+type Suggestion struct {
+	Entity   SearchEntity   `json:"entity"`
+	ID       int            `json:"id"`
+	Name     string         `json:"name"`
+	Names    LocalizedNames `json:"names,omitempty"`
+	ParentID *int           `json:"parent_id,omitempty"`
+}
+
+// AutocompleteResult is the response of an autocomplete query.
+type AutocompleteResult struct {
+	Query       string       `json:"query"`
+	Suggestions []Suggestion `json:"suggestions"`
+}
+
+// Localize sets the name of every suggestion to the name in locale.
+func (a *AutocompleteResult) Localize(locale Locale) {
+	for i := range a.Suggestions {
+		a.Suggestions[i].Name = a.Suggestions[i].Names.Pick(locale, a.Suggestions[i].Name)
+	}
+}
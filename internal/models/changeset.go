@@ -0,0 +1,199 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ChangeOpType names a structural change published in a gazette.
+type ChangeOpType string
+
+const (
+	// OpTransfer moves department ID, with its divisions, to ministry
+	// ToMinistryID.
+	OpTransfer ChangeOpType = "transfer"
+	// OpRename renames ministry or department ID to Name, with Names in the
+	// other languages.
+	OpRename ChangeOpType = "rename"
+	// OpMerge folds departments DepartmentIDs into department IntoID, which
+	// takes over their divisions and is renamed to Name when one is given.
+	OpMerge ChangeOpType = "merge"
+	// OpSplit replaces department ID with the departments in Parts.
+	OpSplit ChangeOpType = "split"
+)
+
+// ErrInvalidChange is wrapped by the errors of ChangeSet.Validate.
+var ErrInvalidChange = errors.New("invalid change")
+
+// SplitPart is one department created by a split. It takes the divisions
+// listed in UnitIDs; divisions nobody takes become top-level departments of
+// the ministry.
+type SplitPart struct {
+	Name    string         `json:"name"`
+	Names   LocalizedNames `json:"names,omitempty"`
+	Kind    OrgKind        `json:"kind,omitempty"`
+	UnitIDs []int          `json:"unit_ids,omitempty"`
+}
+
+// ChangeOp is one operation of a change set. Which fields apply depends on
+// Type; Gazette and EffectiveDate default to those of the change set.
+type ChangeOp struct {
+	Type          ChangeOpType `json:"type"`
+	Gazette       string       `json:"gazette,omitempty"`
+	EffectiveDate Date         `json:"effective_date"`
+
+	Entity        OrgEntity      `json:"entity,omitempty"`
+	ID            int            `json:"id,omitempty"`
+	ToMinistryID  int            `json:"to_ministry_id,omitempty"`
+	Name          string         `json:"name,omitempty"`
+	Names         LocalizedNames `json:"names,omitempty"`
+	DepartmentIDs []int          `json:"department_ids,omitempty"`
+	IntoID        int            `json:"into_id,omitempty"`
+	Parts         []SplitPart    `json:"parts,omitempty"`
+}
+
+// ChangeSet is a batch of operations applied together, usually everything
+// published in one gazette.
+type ChangeSet struct {
+	Gazette       string     `json:"gazette"`
+	EffectiveDate Date       `json:"effective_date"`
+	Operations    []ChangeOp `json:"operations"`
+}
+
+// ChangeOpError reports the operation of a change set that was rejected.
+type ChangeOpError struct {
+	Index int
+	Type  ChangeOpType
+	Err   error
+}
+
+func (e *ChangeOpError) Error() string {
+	return fmt.Sprintf("operation %d (%s): %v", e.Index, e.Type, e.Err)
+}
+
+func (e *ChangeOpError) Unwrap() error {
+	return e.Err
+}
+
+// ChangeOpResult describes an applied operation. CreatedIDs lists the
+// departments created by a split.
+type ChangeOpResult struct {
+	Index      int          `json:"index"`
+	Type       ChangeOpType `json:"type"`
+	Gazette    string       `json:"gazette"`
+	CreatedIDs []int        `json:"created_ids,omitempty"`
+}
+
+// ChangeSetResult is returned once a whole change set has been applied.
+type ChangeSetResult struct {
+	Applied    int              `json:"applied"`
+	Operations []ChangeOpResult `json:"operations"`
+}
+
+// Resolve returns the operations with the change set's gazette and
+// effective date filled in where an operation gives none, the entity of a
+// rename defaulted to department and names normalized as by
+// NormalizeNames. Names that do not normalize are left for Validate to
+// report.
+func (cs ChangeSet) Resolve() []ChangeOp {
+	ops := make([]ChangeOp, len(cs.Operations))
+	for i, op := range cs.Operations {
+		if op.Gazette == "" {
+			op.Gazette = cs.Gazette
+		}
+		if op.EffectiveDate.IsZero() {
+			op.EffectiveDate = cs.EffectiveDate
+		}
+		if op.Type == OpRename && op.Entity == "" {
+			op.Entity = EntityDepartment
+		}
+		op.Name, op.Names, _ = NormalizeNames(op.Name, op.Names)
+		if op.Parts != nil {
+			parts := make([]SplitPart, len(op.Parts))
+			for j, part := range op.Parts {
+				part.Name, part.Names, _ = NormalizeNames(part.Name, part.Names)
+				parts[j] = part
+			}
+			op.Parts = parts
+		}
+		ops[i] = op
+	}
+	return ops
+}
+
+// Validate checks every operation on its own before anything is applied.
+// The first invalid operation is reported as a *ChangeOpError.
+func (cs ChangeSet) Validate() error {
+	ops := cs.Resolve()
+	if len(ops) == 0 {
+		return fmt.Errorf("%w: change set has no operations", ErrInvalidChange)
+	}
+	for i, op := range ops {
+		if err := op.validate(); err != nil {
+			return &ChangeOpError{Index: i, Type: op.Type, Err: fmt.Errorf("%w: %s", ErrInvalidChange, err)}
+		}
+	}
+	return nil
+}
+
+func (op ChangeOp) validate() error {
+	if op.Gazette == "" {
+		return errors.New("gazette is required")
+	}
+	if op.EffectiveDate.IsZero() {
+		return errors.New("effective_date is required")
+	}
+	if _, _, err := NormalizeNames(op.Name, op.Names); err != nil {
+		return err
+	}
+
+	switch op.Type {
+	case OpTransfer:
+		if op.ID <= 0 || op.ToMinistryID <= 0 {
+			return errors.New("transfer needs id and to_ministry_id")
+		}
+	case OpRename:
+		if !op.Entity.Valid() {
+			return errors.New("entity must be ministry or department")
+		}
+		if op.ID <= 0 || op.Name == "" {
+			return errors.New("rename needs id and name")
+		}
+	case OpMerge:
+		if op.IntoID <= 0 || len(op.DepartmentIDs) == 0 {
+			return errors.New("merge needs into_id and department_ids")
+		}
+		seen := map[int]bool{op.IntoID: true}
+		for _, id := range op.DepartmentIDs {
+			if id <= 0 || seen[id] {
+				return errors.New("department_ids must be distinct and exclude into_id")
+			}
+			seen[id] = true
+		}
+	case OpSplit:
+		if op.ID <= 0 || len(op.Parts) < 2 {
+			return errors.New("split needs id and at least two parts")
+		}
+		claimed := map[int]bool{}
+		for _, part := range op.Parts {
+			if part.Name == "" {
+				return errors.New("every part needs a name")
+			}
+			if _, _, err := NormalizeNames(part.Name, part.Names); err != nil {
+				return err
+			}
+			if err := ValidateKind(part.Kind, DepartmentKinds); err != nil {
+				return err
+			}
+			for _, unit := range part.UnitIDs {
+				if claimed[unit] {
+					return fmt.Errorf("division %d is claimed by more than one part", unit)
+				}
+				claimed[unit] = true
+			}
+		}
+	default:
+		return fmt.Errorf("unknown operation type %q", op.Type)
+	}
+	return nil
+}
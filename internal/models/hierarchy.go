@@ -0,0 +1,77 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// OrgKind says what sort of body a ministry or department row is. The
+// tables only distinguish ministry-level bodies from department-level ones;
+// the kind carries the finer distinction.
+type OrgKind string
+
+const (
+	KindMinistry       OrgKind = "ministry"
+	KindStateMinistry  OrgKind = "state_ministry"
+	KindStatutoryBoard OrgKind = "statutory_board"
+	KindDepartment     OrgKind = "department"
+	KindAgency         OrgKind = "agency"
+	KindDivision       OrgKind = "division"
+	KindUnit           OrgKind = "unit"
+)
+
+// MinistryKinds are the kinds a Ministry may have; the first is the default.
+var MinistryKinds = []OrgKind{KindMinistry, KindStateMinistry, KindStatutoryBoard, KindAgency}
+
+// DepartmentKinds are the kinds a Department may have; the first is the
+// default.
+var DepartmentKinds = []OrgKind{KindDepartment, KindAgency, KindDivision, KindUnit}
+
+var ErrInvalidKind = errors.New("invalid organisation kind")
+
+// ValidateKind checks that kind is empty (meaning the default) or one of allowed.
+func ValidateKind(kind OrgKind, allowed []OrgKind) error {
+	if kind == "" {
+		return nil
+	}
+	for _, k := range allowed {
+		if k == kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q, expected one of %v", ErrInvalidKind, kind, allowed)
+}
+
+// OrgEntity names the table an organisation lives in.
+type OrgEntity string
+
+const (
+	EntityMinistry   OrgEntity = "ministry"
+	EntityDepartment OrgEntity = "department"
+)
+
+// Valid reports whether e is EntityMinistry or EntityDepartment.
+func (e OrgEntity) Valid() bool {
+	return e == EntityMinistry || e == EntityDepartment
+}
+
+// OrgRef identifies a ministry or a department. Ministry and department ids
+// are separate sequences, so an id alone is ambiguous.
+type OrgRef struct {
+	Entity OrgEntity
+	ID     int
+}
+
+// OrgNode is one organisation in a hierarchy. A ministry's children are
+// its state ministries and boards followed by its top-level departments; a
+// department's children are its divisions and units. Depth is the distance
+// from the root of the requested tree.
+type OrgNode struct {
+	Entity   OrgEntity      `json:"entity"`
+	ID       int            `json:"id"`
+	Name     string         `json:"name"`
+	Names    LocalizedNames `json:"names,omitempty"`
+	Kind     OrgKind        `json:"kind"`
+	Depth    int            `json:"depth"`
+	Children []OrgNode      `json:"children"`
+}
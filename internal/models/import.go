@@ -0,0 +1,56 @@
+package models
+
+import "fmt"
+
+// ImportPlan is a checked batch of new ministries, departments and offices
+// that a repository creates together, in order, or not at all. A
+// department belongs to the existing ministry MinistryID, or to the
+// ministry of the plan at MinistryIndex when that is set; an office
+// likewise to DepartmentID or the department at DepartmentIndex.
+type ImportPlan struct {
+	Ministries  []ImportMinistry
+	Departments []ImportDepartment
+	Offices     []ImportOffice
+}
+
+// ImportMinistry is a ministry of an ImportPlan. Row is its row in the
+// imported file, used to report a failure.
+type ImportMinistry struct {
+	Ministry
+	Row int
+}
+
+type ImportDepartment struct {
+	Department
+	Row           int
+	MinistryIndex *int
+}
+
+type ImportOffice struct {
+	Office
+	Row             int
+	DepartmentIndex *int
+}
+
+// ImportResult lists the ids created by an import, in plan order.
+type ImportResult struct {
+	MinistryIDs   []int `json:"ministry_ids"`
+	DepartmentIDs []int `json:"department_ids"`
+	OfficeIDs     []int `json:"office_ids"`
+}
+
+// ImportRowError reports the record of an import that could not be
+// created, after which nothing was.
+type ImportRowError struct {
+	Entity string
+	Row    int
+	Err    error
+}
+
+func (e *ImportRowError) Error() string {
+	return fmt.Sprintf("%s on row %d: %v", e.Entity, e.Row, e.Err)
+}
+
+func (e *ImportRowError) Unwrap() error {
+	return e.Err
+}
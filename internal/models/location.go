@@ -0,0 +1,173 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Location is the structured geographic position of a ministry or
+// department. Coordinates are WGS 84 decimal degrees; Geometry is an
+// optional GeoJSON geometry object (for example an office compound outline).
+type Location struct {
+	Latitude  *float64        `json:"latitude,omitempty"`
+	Longitude *float64        `json:"longitude,omitempty"`
+	Address   string          `json:"address,omitempty"`
+	Geometry  json.RawMessage `json:"geometry,omitempty"`
+}
+
+var (
+	ErrIncompleteCoordinates = errors.New("latitude and longitude must be provided together")
+	ErrLatitudeOutOfRange    = errors.New("latitude must be between -90 and 90")
+	ErrLongitudeOutOfRange   = errors.New("longitude must be between -180 and 180")
+	ErrInvalidGeometry       = errors.New("geometry must be a valid GeoJSON geometry object")
+)
+
+// HasCoordinates reports whether both latitude and longitude are set.
+func (l Location) HasCoordinates() bool {
+	return l.Latitude != nil && l.Longitude != nil
+}
+
+// Validate checks coordinate bounds and, when present, that Geometry is a
+// GeoJSON geometry whose positions are within bounds as well.
+func (l Location) Validate() error {
+	if (l.Latitude == nil) != (l.Longitude == nil) {
+		return ErrIncompleteCoordinates
+	}
+	if l.HasCoordinates() {
+		if err := validatePosition(*l.Longitude, *l.Latitude); err != nil {
+			return err
+		}
+	}
+	if len(l.Geometry) > 0 && string(l.Geometry) != "null" {
+		return validateGeometry(l.Geometry)
+	}
+	return nil
+}
+
+func validatePosition(lon, lat float64) error {
+	if lat < -90 || lat > 90 {
+		return ErrLatitudeOutOfRange
+	}
+	if lon < -180 || lon > 180 {
+		return ErrLongitudeOutOfRange
+	}
+	return nil
+}
+
+// coordinateDepth is the nesting depth of the "coordinates" member for each
+// GeoJSON geometry type (RFC 7946 section 3.1).
+var coordinateDepth = map[string]int{
+	"Point":           1,
+	"MultiPoint":      2,
+	"LineString":      2,
+	"MultiLineString": 3,
+	"Polygon":         3,
+	"MultiPolygon":    4,
+}
+
+func validateGeometry(raw json.RawMessage) error {
+	var geometry struct {
+		Type        string            `json:"type"`
+		Coordinates json.RawMessage   `json:"coordinates"`
+		Geometries  []json.RawMessage `json:"geometries"`
+	}
+	if err := json.Unmarshal(raw, &geometry); err != nil {
+		return ErrInvalidGeometry
+	}
+
+	if geometry.Type == "GeometryCollection" {
+		for _, g := range geometry.Geometries {
+			if err := validateGeometry(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	depth, ok := coordinateDepth[geometry.Type]
+	if !ok || len(geometry.Coordinates) == 0 {
+		return ErrInvalidGeometry
+	}
+	var coordinates interface{}
+	if err := json.Unmarshal(geometry.Coordinates, &coordinates); err != nil {
+		return ErrInvalidGeometry
+	}
+	return validateCoordinates(coordinates, depth)
+}
+
+func validateCoordinates(value interface{}, depth int) error {
+	list, ok := value.([]interface{})
+	if !ok {
+		return ErrInvalidGeometry
+	}
+	if depth == 1 {
+		if len(list) < 2 {
+			return ErrInvalidGeometry
+		}
+		lon, okLon := list[0].(float64)
+		lat, okLat := list[1].(float64)
+		if !okLon || !okLat {
+			return ErrInvalidGeometry
+		}
+		return validatePosition(lon, lat)
+	}
+	for _, item := range list {
+		if err := validateCoordinates(item, depth-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NearbyQuery describes a nearest-office search around a point.
+type NearbyQuery struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+	Limit     int
+}
+
+// NearbyDepartment is a department together with its great-circle distance
+// from the search point.
+type NearbyDepartment struct {
+	Department
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// BBox is a map viewport in GeoJSON order: minLon,minLat,maxLon,maxLat.
+// A box whose MinLon is greater than its MaxLon crosses the antimeridian.
+type BBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+// Contains reports whether the point lies inside the box, edges included.
+func (b BBox) Contains(lat, lon float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.MinLon <= b.MaxLon {
+		return lon >= b.MinLon && lon <= b.MaxLon
+	}
+	return lon >= b.MinLon || lon <= b.MaxLon
+}
+
+// DepartmentViewport is the result of a bounding-box query on departments.
+// Truncated is set when more than Limit departments are in the box, so map
+// clients can ask the user to zoom in.
+type DepartmentViewport struct {
+	Departments []Department `json:"departments"`
+	Count       int          `json:"count"`
+	Limit       int          `json:"limit"`
+	Truncated   bool         `json:"truncated"`
+}
+
+// MinistryViewport is the result of a bounding-box query on ministries.
+type MinistryViewport struct {
+	Ministries []Ministry `json:"ministries"`
+	Count      int        `json:"count"`
+	Limit      int        `json:"limit"`
+	Truncated  bool       `json:"truncated"`
+}
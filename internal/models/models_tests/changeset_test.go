@@ -0,0 +1,106 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeSetResolve(t *testing.T) {
+	setDate := models.NewDate(2024, time.January, 15)
+	opDate := models.NewDate(2024, time.February, 1)
+	cs := models.ChangeSet{
+		Gazette:       "2367/12",
+		EffectiveDate: setDate,
+		Operations: []models.ChangeOp{
+			{Type: models.OpRename, ID: 4, Name: "Department of Ports"},
+			{Type: models.OpTransfer, ID: 9, ToMinistryID: 2, Gazette: "2368/03", EffectiveDate: opDate},
+		},
+	}
+
+	ops := cs.Resolve()
+
+	assert.Equal(t, "2367/12", ops[0].Gazette)
+	assert.Equal(t, setDate, ops[0].EffectiveDate)
+	assert.Equal(t, models.EntityDepartment, ops[0].Entity)
+	assert.Equal(t, "2368/03", ops[1].Gazette)
+	assert.Equal(t, opDate, ops[1].EffectiveDate)
+	assert.Empty(t, cs.Operations[0].Gazette, "Resolve must not modify the change set")
+}
+
+func TestChangeSetResolveNames(t *testing.T) {
+	cs := models.ChangeSet{
+		Gazette:       "2367/12",
+		EffectiveDate: models.NewDate(2024, time.January, 15),
+		Operations: []models.ChangeOp{
+			{Type: models.OpRename, ID: 4, Names: models.LocalizedNames{"en": " Department of Ports ", "ta": "துறைமுக திணைக்களம்"}},
+			{Type: models.OpSplit, ID: 8, Parts: []models.SplitPart{{Name: "Department of Wildlife"}, {Name: "Department of Forests"}}},
+		},
+	}
+
+	ops := cs.Resolve()
+
+	assert.Equal(t, "Department of Ports", ops[0].Name)
+	assert.Equal(t, models.LocalizedNames{"en": "Department of Ports", "ta": "துறைமுக திணைக்களம்"}, ops[0].Names)
+	assert.Equal(t, models.LocalizedNames{"en": "Department of Wildlife"}, ops[1].Parts[0].Names)
+	assert.Nil(t, cs.Operations[1].Parts[0].Names, "Resolve must not modify the change set")
+}
+
+func TestChangeSetValidate(t *testing.T) {
+	date := models.NewDate(2024, time.March, 1)
+	valid := []models.ChangeOp{
+		{Type: models.OpTransfer, ID: 9, ToMinistryID: 2},
+		{Type: models.OpRename, Entity: models.EntityMinistry, ID: 3, Name: "Ministry of Health"},
+		{Type: models.OpRename, ID: 4, Names: models.LocalizedNames{"en": "Department of Ports", "si": "වරාය දෙපාර්තමේන්තුව"}},
+		{Type: models.OpMerge, IntoID: 5, DepartmentIDs: []int{6, 7}, Name: "Department of Archives"},
+		{Type: models.OpSplit, ID: 8, Parts: []models.SplitPart{
+			{Name: "Department of Wildlife", UnitIDs: []int{81}},
+			{Name: "Department of Forests", Kind: models.KindAgency, UnitIDs: []int{82}},
+		}},
+	}
+	assert.NoError(t, models.ChangeSet{Gazette: "2370/01", EffectiveDate: date, Operations: valid}.Validate())
+
+	tests := []struct {
+		name string
+		op   models.ChangeOp
+	}{
+		{"unknown type", models.ChangeOp{Type: "abolish", ID: 1}},
+		{"transfer without ministry", models.ChangeOp{Type: models.OpTransfer, ID: 1}},
+		{"rename without name", models.ChangeOp{Type: models.OpRename, ID: 1}},
+		{"rename with a mismatched English name", models.ChangeOp{Type: models.OpRename, ID: 1, Name: "a", Names: models.LocalizedNames{"en": "b"}}},
+		{"rename with a name in the wrong script", models.ChangeOp{Type: models.OpRename, ID: 1, Name: "a", Names: models.LocalizedNames{"ta": "වරාය"}}},
+		{"rename of unknown entity", models.ChangeOp{Type: models.OpRename, Entity: "board", ID: 1, Name: "x"}},
+		{"merge into itself", models.ChangeOp{Type: models.OpMerge, IntoID: 5, DepartmentIDs: []int{5}}},
+		{"merge with duplicates", models.ChangeOp{Type: models.OpMerge, IntoID: 5, DepartmentIDs: []int{6, 6}}},
+		{"split into one part", models.ChangeOp{Type: models.OpSplit, ID: 8, Parts: []models.SplitPart{{Name: "a"}}}},
+		{"split claiming a division twice", models.ChangeOp{Type: models.OpSplit, ID: 8, Parts: []models.SplitPart{
+			{Name: "a", UnitIDs: []int{81}}, {Name: "b", UnitIDs: []int{81}},
+		}}},
+		{"split with a ministry kind", models.ChangeOp{Type: models.OpSplit, ID: 8, Parts: []models.SplitPart{
+			{Name: "a", Kind: models.KindStateMinistry}, {Name: "b"},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := append(append([]models.ChangeOp(nil), valid...), tt.op)
+			err := models.ChangeSet{Gazette: "2370/01", EffectiveDate: date, Operations: ops}.Validate()
+
+			var opErr *models.ChangeOpError
+			assert.True(t, errors.As(err, &opErr))
+			assert.Equal(t, len(valid), opErr.Index)
+			assert.ErrorIs(t, err, models.ErrInvalidChange)
+		})
+	}
+}
+
+func TestChangeSetValidateRequiresGazetteAndDate(t *testing.T) {
+	op := models.ChangeOp{Type: models.OpTransfer, ID: 9, ToMinistryID: 2}
+
+	assert.ErrorIs(t, models.ChangeSet{EffectiveDate: models.NewDate(2024, time.March, 1), Operations: []models.ChangeOp{op}}.Validate(), models.ErrInvalidChange)
+	assert.ErrorIs(t, models.ChangeSet{Gazette: "2370/01", Operations: []models.ChangeOp{op}}.Validate(), models.ErrInvalidChange)
+	assert.ErrorIs(t, models.ChangeSet{Gazette: "2370/01", EffectiveDate: models.NewDate(2024, time.March, 1)}.Validate(), models.ErrInvalidChange)
+}
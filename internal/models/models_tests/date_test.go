@@ -0,0 +1,57 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDate(t *testing.T) {
+	d, err := models.ParseDate("2024-03-01")
+	assert.NoError(t, err)
+	assert.Equal(t, models.NewDate(2024, time.March, 1), d)
+	assert.Equal(t, "2024-03-01", d.String())
+
+	_, err = models.ParseDate("01/03/2024")
+	assert.Error(t, err)
+	_, err = models.ParseDate("2024-02-30")
+	assert.Error(t, err)
+}
+
+func TestDateOrdering(t *testing.T) {
+	earlier := models.NewDate(2022, time.December, 31)
+	later := models.NewDate(2023, time.January, 1)
+
+	assert.True(t, earlier.Before(later))
+	assert.True(t, later.After(earlier))
+	assert.True(t, later.Equal(models.NewDate(2023, time.January, 1)))
+}
+
+func TestDateJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		From models.Date `json:"from"`
+		To   models.Date `json:"to"`
+	}{From: models.NewDate(2020, time.August, 12)})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"from": "2020-08-12", "to": null}`, string(data))
+
+	var d models.Date
+	assert.NoError(t, json.Unmarshal([]byte(`"2019-11-18"`), &d))
+	assert.Equal(t, models.NewDate(2019, time.November, 18), d)
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &d))
+	assert.True(t, d.IsZero())
+}
+
+func TestDateScan(t *testing.T) {
+	var d models.Date
+	assert.NoError(t, d.Scan(time.Date(2021, time.May, 4, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2021-05-04", d.String())
+	assert.NoError(t, d.Scan([]byte("2021-06-01T00:00:00Z")))
+	assert.Equal(t, "2021-06-01", d.String())
+	assert.NoError(t, d.Scan(nil))
+	assert.True(t, d.IsZero())
+}
@@ -0,0 +1,23 @@
+package models_test
+
+import (
+	"testing"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKind(t *testing.T) {
+	assert.NoError(t, models.ValidateKind("", models.MinistryKinds))
+	assert.NoError(t, models.ValidateKind(models.KindStateMinistry, models.MinistryKinds))
+	assert.NoError(t, models.ValidateKind(models.KindDivision, models.DepartmentKinds))
+	assert.ErrorIs(t, models.ValidateKind(models.KindDivision, models.MinistryKinds), models.ErrInvalidKind)
+	assert.ErrorIs(t, models.ValidateKind("committee", models.DepartmentKinds), models.ErrInvalidKind)
+}
+
+func TestOrgEntityValid(t *testing.T) {
+	assert.True(t, models.EntityMinistry.Valid())
+	assert.True(t, models.EntityDepartment.Valid())
+	assert.False(t, models.OrgEntity("agency").Valid())
+}
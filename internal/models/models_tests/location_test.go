@@ -0,0 +1,59 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestLocationValidate_Empty(t *testing.T) {
+	assert.NoError(t, models.Location{}.Validate())
+}
+
+func TestLocationValidate_Coordinates(t *testing.T) {
+	colombo := models.Location{Latitude: float(6.9271), Longitude: float(79.8612), Address: "Colombo 01"}
+	assert.NoError(t, colombo.Validate())
+
+	assert.ErrorIs(t, models.Location{Latitude: float(6.9271)}.Validate(), models.ErrIncompleteCoordinates)
+	assert.ErrorIs(t, models.Location{Latitude: float(91), Longitude: float(79.8)}.Validate(), models.ErrLatitudeOutOfRange)
+	assert.ErrorIs(t, models.Location{Latitude: float(6.9), Longitude: float(-180.5)}.Validate(), models.ErrLongitudeOutOfRange)
+}
+
+func TestLocationValidate_Geometry(t *testing.T) {
+	polygon := models.Location{Geometry: json.RawMessage(`{"type":"Polygon","coordinates":[[[79.85,6.92],[79.87,6.92],[79.87,6.94],[79.85,6.92]]]}`)}
+	assert.NoError(t, polygon.Validate())
+
+	collection := models.Location{Geometry: json.RawMessage(`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[79.86,6.93]}]}`)}
+	assert.NoError(t, collection.Validate())
+
+	invalid := []string{
+		`"not an object"`,
+		`{"type":"Circle","coordinates":[79.86,6.93]}`,
+		`{"type":"Point"}`,
+		`{"type":"Point","coordinates":[[79.86,6.93]]}`,
+		`{"type":"Polygon","coordinates":[[[79.85,6.92],[79.87,96.0],[79.85,6.92]]]}`,
+	}
+	for _, geometry := range invalid {
+		loc := models.Location{Geometry: json.RawMessage(geometry)}
+		assert.Error(t, loc.Validate(), geometry)
+	}
+}
+
+func TestBBoxContains(t *testing.T) {
+	sriLanka := models.BBox{MinLon: 79.5, MinLat: 5.9, MaxLon: 81.9, MaxLat: 9.9}
+	assert.True(t, sriLanka.Contains(6.9271, 79.8612))
+	assert.True(t, sriLanka.Contains(5.9, 79.5))
+	assert.False(t, sriLanka.Contains(13.0827, 80.2707)) // Chennai
+
+	fiji := models.BBox{MinLon: 177, MinLat: -21, MaxLon: -178, MaxLat: -12}
+	assert.True(t, fiji.Contains(-17.7, 178.0))
+	assert.True(t, fiji.Contains(-17.0, -179.5))
+	assert.False(t, fiji.Contains(-17.0, 0))
+}
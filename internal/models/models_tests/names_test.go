@@ -0,0 +1,109 @@
+package models_test
+
+import (
+	"testing"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		tag    string
+		locale models.Locale
+		ok     bool
+	}{
+		{"si", models.LocaleSinhala, true},
+		{"ta-LK", models.LocaleTamil, true},
+		{"EN_gb", models.LocaleEnglish, true},
+		{"fr", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			locale, ok := models.ParseLocale(tt.tag)
+			assert.Equal(t, tt.locale, locale)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestLocalizedNamesPick(t *testing.T) {
+	names := models.LocalizedNames{"si": "සෞඛ්‍ය අමාත්‍යාංශය", "en": "Ministry of Health"}
+
+	assert.Equal(t, "සෞඛ්‍ය අමාත්‍යාංශය", names.Pick(models.LocaleSinhala, "x"))
+	assert.Equal(t, "Ministry of Health", names.Pick(models.LocaleTamil, "x"), "Tamil falls back to English")
+	assert.Equal(t, "සෞඛ්‍ය අමාත්‍යාංශය", models.LocalizedNames{"si": "සෞඛ්‍ය අමාත්‍යාංශය"}.Pick(models.LocaleTamil, "x"),
+		"then to Sinhala")
+	assert.Equal(t, "x", models.LocalizedNames(nil).Pick(models.LocaleTamil, "x"))
+}
+
+func TestLocalizedNamesValidate(t *testing.T) {
+	assert.NoError(t, models.LocalizedNames{
+		"si": "සෞඛ්‍ය අමාත්‍යාංශය",
+		"ta": "சுகாதார அமைச்சு",
+		"en": "Ministry of Health",
+	}.Validate())
+
+	assert.ErrorIs(t, models.LocalizedNames{"si": "Ministry of Health"}.Validate(), models.ErrNameScript)
+	assert.ErrorIs(t, models.LocalizedNames{"ta": "සෞඛ්‍ය"}.Validate(), models.ErrNameScript)
+	assert.ErrorIs(t, models.LocalizedNames{"en": "சுகாதார"}.Validate(), models.ErrNameScript)
+	assert.ErrorIs(t, models.LocalizedNames{"fr": "Ministère"}.Validate(), models.ErrUnknownLocale)
+}
+
+func TestNormalizeNames(t *testing.T) {
+	name, names, err := models.NormalizeNames(" Ministry of Health ", models.LocalizedNames{"ta": "சுகாதார அமைச்சு", "si": " "})
+	assert.NoError(t, err)
+	assert.Equal(t, "Ministry of Health", name)
+	assert.Equal(t, models.LocalizedNames{"en": "Ministry of Health", "ta": "சுகாதார அமைச்சு"}, names)
+
+	name, names, err = models.NormalizeNames("", models.LocalizedNames{"en": "Ministry of Health"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Ministry of Health", name)
+	assert.Equal(t, models.LocalizedNames{"en": "Ministry of Health"}, names)
+
+	_, names, err = models.NormalizeNames("", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, names)
+
+	_, _, err = models.NormalizeNames("Ministry of Health", models.LocalizedNames{"en": "Ministry of Education"})
+	assert.ErrorIs(t, err, models.ErrNameMismatch)
+
+	// ො typed as its two parts is stored precomposed.
+	_, names, err = models.NormalizeNames("Colombo", models.LocalizedNames{"si": "ක\u0dd9\u0dcfළඹ"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ක\u0ddcළඹ", names[models.LocaleSinhala])
+}
+
+func TestNamesEncodeDecode(t *testing.T) {
+	names := models.LocalizedNames{"si": "සෞඛ්‍ය අමාත්‍යාංශය", "en": "Ministry of Health"}
+
+	encoded, err := names.Encode()
+	assert.NoError(t, err)
+	decoded, err := models.DecodeNames([]byte(encoded))
+	assert.NoError(t, err)
+	assert.Equal(t, names, decoded)
+
+	decoded, err = models.DecodeNames([]byte("{}"))
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+}
+
+func TestMinistryWithDepartmentsLocalize(t *testing.T) {
+	ministry := models.MinistryWithDepartments{
+		Ministry: models.Ministry{Name: "Ministry of Health", Names: models.LocalizedNames{
+			"en": "Ministry of Health", "ta": "சுகாதார அமைச்சு",
+		}},
+		Departments: []models.Department{
+			{Name: "Department of Ayurveda"},
+			{Name: "Medical Supplies Division", Names: models.LocalizedNames{"ta": "மருத்துவ விநியோகப் பிரிவு"}},
+		},
+	}
+
+	ministry.Localize(models.LocaleTamil)
+
+	assert.Equal(t, "சுகாதார அமைச்சு", ministry.Name)
+	assert.Equal(t, "Department of Ayurveda", ministry.Departments[0].Name, "keeps the name when there are no names")
+	assert.Equal(t, "மருத்துவ விநியோகப் பிரிவு", ministry.Departments[1].Name)
+}
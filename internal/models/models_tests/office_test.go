@@ -0,0 +1,65 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfficeValidate(t *testing.T) {
+	office := models.Office{
+		DepartmentID: 4,
+		Name:         "Kandy Regional Office",
+		PhoneNumbers: []string{"+94 81 222 3344", "(081) 222-3345"},
+		Email:        "kandy@immigration.gov.lk",
+		Location:     models.Location{Latitude: float(7.2906), Longitude: float(80.6337)},
+	}
+	assert.NoError(t, office.Validate())
+
+	office.PhoneNumbers = []string{"call 0812223344"}
+	assert.ErrorIs(t, office.Validate(), models.ErrInvalidPhoneNumber)
+	office.PhoneNumbers = []string{" "}
+	assert.ErrorIs(t, office.Validate(), models.ErrInvalidPhoneNumber)
+	office.PhoneNumbers = nil
+
+	office.Email = "Kandy Office <kandy@immigration.gov.lk>"
+	assert.ErrorIs(t, office.Validate(), models.ErrInvalidEmail)
+	office.Email = "kandy"
+	assert.ErrorIs(t, office.Validate(), models.ErrInvalidEmail)
+	office.Email = ""
+
+	office.Location = models.Location{Latitude: float(7.2906)}
+	assert.ErrorIs(t, office.Validate(), models.ErrIncompleteCoordinates)
+}
+
+func TestOpeningHoursJSON(t *testing.T) {
+	var hours []models.OpeningHours
+	err := json.Unmarshal([]byte(`[{"day": "Friday", "opens": "20:00", "closes": "02:00"}]`), &hours)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Weekday(time.Friday), hours[0].Day)
+	assert.Equal(t, models.ClockTime(20*60), hours[0].Opens)
+	assert.True(t, hours[0].Overnight())
+
+	data, err := json.Marshal(hours)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"day": "friday", "opens": "20:00", "closes": "02:00"}]`, string(data))
+
+	for _, invalid := range []string{
+		`[{"day": "funday", "opens": "08:00", "closes": "16:00"}]`,
+		`[{"day": "monday", "opens": "8:00", "closes": "16:00"}]`,
+		`[{"day": "monday", "opens": "08:00", "closes": "24:00"}]`,
+	} {
+		assert.Error(t, json.Unmarshal([]byte(invalid), &hours), invalid)
+	}
+}
+
+func TestValidateSchedule_Closures(t *testing.T) {
+	poya := models.NewDate(2024, time.March, 25)
+	assert.NoError(t, models.ValidateSchedule(nil, []models.Closure{{Date: poya, Reason: "Poya"}}))
+	assert.ErrorIs(t, models.ValidateSchedule(nil, []models.Closure{{Date: poya}, {Date: poya}}), models.ErrInvalidClosure)
+	assert.ErrorIs(t, models.ValidateSchedule(nil, []models.Closure{{Reason: "No date"}}), models.ErrInvalidClosure)
+}
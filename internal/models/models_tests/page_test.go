@@ -0,0 +1,35 @@
+package models_test
+
+import (
+	"testing"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	for _, c := range []models.Cursor{{ID: 12}, {ID: 3, Before: true}} {
+		decoded, err := models.DecodeCursor(c.Encode())
+		assert.NoError(t, err)
+		assert.Equal(t, c, decoded)
+	}
+
+	for _, s := range []string{"", "not base64!", "bnVsbA", "eyJpZCI6MH0"} {
+		_, err := models.DecodeCursor(s)
+		assert.ErrorIs(t, err, models.ErrInvalidCursor, s)
+	}
+}
+
+func TestPageNeighbours(t *testing.T) {
+	page := models.Page[int]{Items: []int{4, 5}, Total: 5, Limit: 2, Offset: 2}
+	assert.True(t, page.HasNext())
+	assert.True(t, page.HasPrev())
+
+	page = models.Page[int]{Items: []int{5}, Total: 5, Limit: 2, Offset: 4}
+	assert.False(t, page.HasNext())
+
+	page = models.Page[int]{Items: []int{}, Total: 0, Limit: 2}
+	assert.False(t, page.HasNext())
+	assert.False(t, page.HasPrev())
+}
@@ -0,0 +1,34 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRole(t *testing.T) {
+	assert.NoError(t, models.ValidateRole(models.RoleMinister, models.EntityMinistry))
+	assert.NoError(t, models.ValidateRole(models.RoleSecretary, models.EntityMinistry))
+	assert.NoError(t, models.ValidateRole(models.RoleHead, models.EntityDepartment))
+	assert.ErrorIs(t, models.ValidateRole(models.RoleHead, models.EntityMinistry), models.ErrInvalidRole)
+	assert.ErrorIs(t, models.ValidateRole(models.RoleMinister, models.EntityDepartment), models.ErrInvalidRole)
+	assert.ErrorIs(t, models.ValidateRole("governor", models.EntityMinistry), models.ErrInvalidRole)
+}
+
+func TestPositionHeldOn(t *testing.T) {
+	term := models.Position{
+		StartDate: models.NewDate(2020, time.August, 12),
+		EndDate:   models.NewDate(2022, time.May, 20),
+	}
+
+	assert.False(t, term.HeldOn(models.NewDate(2020, time.August, 11)))
+	assert.True(t, term.HeldOn(models.NewDate(2020, time.August, 12)))
+	assert.True(t, term.HeldOn(models.NewDate(2022, time.May, 19)))
+	assert.False(t, term.HeldOn(models.NewDate(2022, time.May, 20)), "a term ends the day before its end date")
+
+	term.EndDate = models.Date{}
+	assert.True(t, term.HeldOn(models.NewDate(2030, time.January, 1)))
+}
@@ -0,0 +1,21 @@
+package models_test
+
+import (
+	"testing"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSearchResult(t *testing.T) {
+	hits := []models.SearchHit{{Entity: models.SearchMinistry, ID: 1, Name: "Ministry of Education"}}
+
+	result := models.NewSearchResult("adhyapana", hits)
+	assert.Equal(t, "adhyapana", result.Query)
+	assert.Equal(t, hits, result.Hits)
+	assert.Equal(t, models.LocalizedNames{"si": "අද්‍යපන", "ta": "அத்யபன"}, result.Transliterations)
+
+	result = models.NewSearchResult("අධ්‍යාපන", hits)
+	assert.Nil(t, result.Transliterations)
+}
@@ -0,0 +1,200 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go-mysql-backend/internal/translit"
+)
+
+// Locale is one of the three official languages of Sri Lanka.
+type Locale string
+
+const (
+	LocaleSinhala Locale = "si"
+	LocaleTamil   Locale = "ta"
+	LocaleEnglish Locale = "en"
+)
+
+// Locales lists the supported locales. DefaultLocale is used when a
+// request does not ask for one; the English name is always present.
+var (
+	Locales       = []Locale{LocaleSinhala, LocaleTamil, LocaleEnglish}
+	DefaultLocale = LocaleEnglish
+)
+
+var (
+	ErrUnknownLocale = errors.New("names must be given as si, ta or en")
+	ErrNameScript    = errors.New("name is not written in the script of its language")
+	ErrNameMismatch  = errors.New("name and names.en must be the same")
+)
+
+// ParseLocale reads a language tag such as "si", "ta-LK" or "en-GB" and
+// returns the locale of its primary language.
+func ParseLocale(tag string) (Locale, bool) {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	primary, _, _ = strings.Cut(primary, "_")
+	for _, locale := range Locales {
+		if string(locale) == primary {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// FallbackChain returns the locales tried, in order, for a name requested
+// in locale: the locale itself, then English, Sinhala and Tamil.
+func FallbackChain(locale Locale) []Locale {
+	chain := []Locale{locale}
+	for _, l := range []Locale{LocaleEnglish, LocaleSinhala, LocaleTamil} {
+		if l != locale {
+			chain = append(chain, l)
+		}
+	}
+	return chain
+}
+
+// LocalizedNames holds the official name of an organisation in each
+// language it has one in.
+type LocalizedNames map[Locale]string
+
+// Pick returns the name for locale following FallbackChain, or fallback
+// when there is no name at all.
+func (n LocalizedNames) Pick(locale Locale, fallback string) string {
+	for _, l := range FallbackChain(locale) {
+		if name := n[l]; name != "" {
+			return name
+		}
+	}
+	return fallback
+}
+
+// Validate checks that every name is written in its language's script:
+// Sinhala and Tamil names must use their own script and not the other's,
+// and English names must use the Latin alphabet.
+func (n LocalizedNames) Validate() error {
+	for locale, name := range n {
+		var ok bool
+		switch locale {
+		case LocaleSinhala:
+			ok = hasScript(name, unicode.Sinhala) && !hasScript(name, unicode.Tamil)
+		case LocaleTamil:
+			ok = hasScript(name, unicode.Tamil) && !hasScript(name, unicode.Sinhala)
+		case LocaleEnglish:
+			ok = hasScript(name, unicode.Latin) && !hasScript(name, unicode.Sinhala) && !hasScript(name, unicode.Tamil)
+		default:
+			return fmt.Errorf("%w, got %q", ErrUnknownLocale, locale)
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s name %q", ErrNameScript, locale, name)
+		}
+	}
+	return nil
+}
+
+// Encode returns the names as a JSON object for storage.
+func (n LocalizedNames) Encode() (string, error) {
+	if n == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(n)
+	return string(data), err
+}
+
+// DecodeNames reads names stored by Encode. Empty input has no names.
+func DecodeNames(data []byte) (LocalizedNames, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var names LocalizedNames
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	return names, nil
+}
+
+// NormalizeNames reconciles the English name of an organisation with its
+// localized names on writes. name is the English name: it fills in
+// names.en, or is taken from it when missing, and the two must agree.
+// Names are put in Unicode NFC and blank ones dropped. On error the
+// inputs are returned unchanged.
+func NormalizeNames(name string, names LocalizedNames) (string, LocalizedNames, error) {
+	normalized := LocalizedNames{}
+	for locale, value := range names {
+		if value = translit.Normalize(strings.TrimSpace(value)); value != "" {
+			normalized[locale] = value
+		}
+	}
+
+	english := translit.Normalize(strings.TrimSpace(name))
+	switch {
+	case english == "":
+		english = normalized[LocaleEnglish]
+	case normalized[LocaleEnglish] != "" && normalized[LocaleEnglish] != english:
+		return name, names, ErrNameMismatch
+	}
+	if english != "" {
+		normalized[LocaleEnglish] = english
+	}
+
+	if err := normalized.Validate(); err != nil {
+		return name, names, err
+	}
+	if len(normalized) == 0 {
+		normalized = nil
+	}
+	return english, normalized, nil
+}
+
+// NormalizeNames applies NormalizeNames to the ministry.
+func (m *Ministry) NormalizeNames() (err error) {
+	m.Name, m.Names, err = NormalizeNames(m.Name, m.Names)
+	return err
+}
+
+// NormalizeNames applies NormalizeNames to the department.
+func (d *Department) NormalizeNames() (err error) {
+	d.Name, d.Names, err = NormalizeNames(d.Name, d.Names)
+	return err
+}
+
+// Localize sets Name to the name in locale.
+func (m *Ministry) Localize(locale Locale) {
+	m.Name = m.Names.Pick(locale, m.Name)
+}
+
+// Localize sets Name to the name in locale.
+func (d *Department) Localize(locale Locale) {
+	d.Name = d.Names.Pick(locale, d.Name)
+}
+
+// Localize sets the names of the ministry and its departments.
+func (m *MinistryWithDepartments) Localize(locale Locale) {
+	m.Ministry.Localize(locale)
+	for i := range m.Departments {
+		m.Departments[i].Localize(locale)
+	}
+}
+
+// Localize sets the names of the node and everything below it.
+func (n *OrgNode) Localize(locale Locale) {
+	n.Name = n.Names.Pick(locale, n.Name)
+	for i := range n.Children {
+		n.Children[i].Localize(locale)
+	}
+}
+
+func hasScript(s string, script *unicode.RangeTable) bool {
+	for _, r := range s {
+		if unicode.Is(script, r) {
+			return true
+		}
+	}
+	return false
+}
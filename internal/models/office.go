@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+)
+
+// Office is a branch or regional office of a department, with its own
+// location, contact details and opening hours. OpenNow is computed on
+// reads and left out for offices without hours.
+type Office struct {
+	ID           int            `json:"id,omitempty"`
+	DepartmentID int            `json:"department_id"`
+	Name         string         `json:"name"`
+	PhoneNumbers []string       `json:"phone_numbers,omitempty"`
+	Email        string         `json:"email,omitempty"`
+	Hours        []OpeningHours `json:"hours,omitempty"`
+	Closures     []Closure      `json:"closures,omitempty"`
+	OpenNow      *bool          `json:"open_now,omitempty"`
+	Location
+}
+
+// NearbyOffice is an office together with its great-circle distance from
+// the search point.
+type NearbyOffice struct {
+	Office
+	DistanceKm float64 `json:"distance_km"`
+}
+
+var (
+	ErrInvalidPhoneNumber = errors.New("phone numbers may only contain digits, spaces and + - ( )")
+	ErrInvalidEmail       = errors.New("email must be a valid address")
+)
+
+// Validate checks the location, contact details and schedule of the
+// office.
+func (o Office) Validate() error {
+	for _, phone := range o.PhoneNumbers {
+		if strings.TrimSpace(phone) == "" || strings.Trim(phone, "0123456789 +-()") != "" {
+			return ErrInvalidPhoneNumber
+		}
+	}
+	if o.Email != "" {
+		if addr, err := mail.ParseAddress(o.Email); err != nil || addr.Address != o.Email {
+			return ErrInvalidEmail
+		}
+	}
+	if err := ValidateSchedule(o.Hours, o.Closures); err != nil {
+		return err
+	}
+	return o.Location.Validate()
+}
@@ -1,19 +1,50 @@
 package models
 
+// Ministry is a ministry-level body. ParentID is set for state ministries
+// and statutory boards that sit under another ministry. Name is the English
+// name until a response is localized; Names holds the official name in each
+// language.
 type Ministry struct {
-	ID                int    `json:"id,omitempty"`
-	Name              string `json:"name"`
-	Google_map_script string `json:"google_map_script"`
+	ID                int            `json:"id,omitempty"`
+	Name              string         `json:"name"`
+	Names             LocalizedNames `json:"names,omitempty"`
+	Kind              OrgKind        `json:"kind,omitempty"`
+	ParentID          *int           `json:"parent_id,omitempty"`
+	Google_map_script string         `json:"google_map_script"`
+	Location
 }
 
+// Department is a department-level body of a ministry. ParentID is set for
+// divisions and units that sit under another department of the same
+// ministry. Offices is only filled in when a single department is read.
+// Name and Names are as for Ministry.
 type Department struct {
-	ID                int    `json:"id,omitempty"`
-	Name              string `json:"name"`
-	Google_map_script string `json:"google_map_script"`
-	MinistryID        int    `json:"ministry_id"`
+	ID                int            `json:"id,omitempty"`
+	Name              string         `json:"name"`
+	Names             LocalizedNames `json:"names,omitempty"`
+	Kind              OrgKind        `json:"kind,omitempty"`
+	ParentID          *int           `json:"parent_id,omitempty"`
+	Google_map_script string         `json:"google_map_script"`
+	MinistryID        int            `json:"ministry_id"`
+	AdminAreaID       *int           `json:"admin_area_id,omitempty"`
+	Offices           []Office       `json:"offices,omitempty"`
+	Location
 }
 
 type MinistryWithDepartments struct {
 	Ministry
 	Departments []Department
 }
+
+// MinistryDeleteMode controls what happens to the departments of a ministry
+// that is being deleted.
+type MinistryDeleteMode string
+
+const (
+	// DeleteReject refuses to delete a ministry that still has departments.
+	DeleteReject MinistryDeleteMode = "reject"
+	// DeleteCascade deletes the ministry together with its departments.
+	DeleteCascade MinistryDeleteMode = "cascade"
+	// DeleteReassign moves the departments to another ministry first.
+	DeleteReassign MinistryDeleteMode = "reassign"
+)
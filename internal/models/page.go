@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor is returned for a cursor that was not issued by us.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageRequest selects one page of a list ordered by id: the page Offset
+// items in or, when Cursor is set, the page just after or before it.
+type PageRequest struct {
+	Limit  int
+	Offset int
+	Cursor *Cursor
+}
+
+// Cursor is a keyset position in a list ordered by id. The page it selects
+// holds the items after ID, or the items before ID when Before is set.
+type Cursor struct {
+	ID     int  `json:"id"`
+	Before bool `json:"before,omitempty"`
+}
+
+// Encode returns c in the opaque form used in query strings.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reads a cursor written by Encode.
+func DecodeCursor(s string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Page is one page of a list ordered by id. Offset is the position of the
+// first item, also on pages read by cursor. The cursors and the Next and
+// Prev links, which the handlers fill in, are empty at the ends of the
+// list.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// HasNext reports whether items follow the page.
+func (p Page[T]) HasNext() bool {
+	return p.Offset+len(p.Items) < p.Total
+}
+
+// HasPrev reports whether items precede the page.
+func (p Page[T]) HasPrev() bool {
+	return p.Offset > 0
+}
+
+// SetCursors fills in NextCursor and PrevCursor from the first and last
+// items, id returning the id of an item.
+func (p *Page[T]) SetCursors(id func(T) int) {
+	if len(p.Items) == 0 {
+		return
+	}
+	if p.HasNext() {
+		p.NextCursor = Cursor{ID: id(p.Items[len(p.Items)-1])}.Encode()
+	}
+	if p.HasPrev() {
+		p.PrevCursor = Cursor{ID: id(p.Items[0]), Before: true}.Encode()
+	}
+}
+
+// MinistryPage is a page of ministries with their departments.
+type MinistryPage = Page[MinistryWithDepartments]
+
+// DepartmentPage is a page of departments.
+type DepartmentPage = Page[Department]
+
+// Localize sets the name of every item to the name in locale.
+func (p *Page[T]) Localize(locale Locale) {
+	for i := range p.Items {
+		if l, ok := any(&p.Items[i]).(interface{ Localize(Locale) }); ok {
+			l.Localize(locale)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Person is someone who holds or has held a public office.
+type Person struct {
+	ID    int    `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Title string `json:"title,omitempty"` // honorific, e.g. "Hon." or "Dr."
+}
+
+// PositionRole is the office a person holds in a ministry or department.
+type PositionRole string
+
+const (
+	RoleMinister       PositionRole = "minister"
+	RoleStateMinister  PositionRole = "state_minister"
+	RoleDeputyMinister PositionRole = "deputy_minister"
+	RoleSecretary      PositionRole = "secretary"
+	RoleHead           PositionRole = "head" // head of department, e.g. Director General or Commissioner
+)
+
+// MinistryRoles and DepartmentRoles are the roles held in each entity.
+var (
+	MinistryRoles   = []PositionRole{RoleMinister, RoleStateMinister, RoleDeputyMinister, RoleSecretary}
+	DepartmentRoles = []PositionRole{RoleHead}
+)
+
+var ErrInvalidRole = errors.New("invalid position role")
+
+// ValidateRole checks that role is held in entity.
+func ValidateRole(role PositionRole, entity OrgEntity) error {
+	allowed := MinistryRoles
+	if entity == EntityDepartment {
+		allowed = DepartmentRoles
+	}
+	for _, r := range allowed {
+		if r == role {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q for a %s, expected one of %v", ErrInvalidRole, role, entity, allowed)
+}
+
+// Position is one term of a person in an office, from StartDate until the
+// day before EndDate. A zero EndDate means the term is current.
+// PersonName and OrganizationName are filled in on reads.
+type Position struct {
+	ID               int          `json:"id,omitempty"`
+	PersonID         int          `json:"person_id"`
+	PersonName       string       `json:"person_name,omitempty"`
+	Role             PositionRole `json:"role"`
+	Entity           OrgEntity    `json:"entity"`
+	OrganizationID   int          `json:"organization_id"`
+	OrganizationName string       `json:"organization_name,omitempty"`
+	StartDate        Date         `json:"start_date"`
+	EndDate          Date         `json:"end_date"`
+}
+
+// HeldOn reports whether the term covers date.
+func (p Position) HeldOn(date Date) bool {
+	return !p.StartDate.After(date) && (p.EndDate.IsZero() || p.EndDate.After(date))
+}
+
+// PersonWithPositions is a person with every term they have held, oldest
+// first.
+type PersonWithPositions struct {
+	Person
+	Positions []Position `json:"positions"`
+}
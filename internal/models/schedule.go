@@ -0,0 +1,116 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Weekday is a day of the week, written in JSON as "monday".."sunday".
+type Weekday time.Weekday
+
+var ErrInvalidWeekday = errors.New("day must be one of monday, tuesday, wednesday, thursday, friday, saturday or sunday")
+
+func (d Weekday) String() string {
+	return strings.ToLower(time.Weekday(d).String())
+}
+
+func (d Weekday) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Weekday) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if Weekday(day).String() == strings.ToLower(s) {
+			*d = Weekday(day)
+			return nil
+		}
+	}
+	return ErrInvalidWeekday
+}
+
+// ClockTime is a time of day in minutes after midnight, written in JSON as
+// "HH:MM" on the 24-hour clock.
+type ClockTime int
+
+var ErrInvalidClockTime = errors.New("time of day must be HH:MM between 00:00 and 23:59")
+
+// ParseClockTime parses an "HH:MM" time of day.
+func ParseClockTime(s string) (ClockTime, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil || len(s) != len("15:04") {
+		return 0, ErrInvalidClockTime
+	}
+	return ClockTime(t.Hour()*60 + t.Minute()), nil
+}
+
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+func (c ClockTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *ClockTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseClockTime(s)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// OpeningHours is one weekly opening of an office, in Sri Lanka time. When
+// Closes is not after Opens the office stays open past midnight and closes
+// on the following day, e.g. friday 20:00-02:00. Closes "00:00" means
+// midnight, and 00:00-00:00 is open all day.
+type OpeningHours struct {
+	Day    Weekday   `json:"day"`
+	Opens  ClockTime `json:"opens"`
+	Closes ClockTime `json:"closes"`
+}
+
+// Overnight reports whether the opening runs past midnight.
+func (h OpeningHours) Overnight() bool {
+	return h.Closes <= h.Opens
+}
+
+// Closure is a day an office is closed regardless of its weekly hours,
+// such as a public holiday or a special closure.
+type Closure struct {
+	Date   Date   `json:"date"`
+	Reason string `json:"reason,omitempty"`
+}
+
+var ErrInvalidClosure = errors.New("closures need a date and each date may appear only once")
+
+// ValidateSchedule checks the weekly hours and closures of an office.
+func ValidateSchedule(hours []OpeningHours, closures []Closure) error {
+	for _, h := range hours {
+		if h.Day < Weekday(time.Sunday) || h.Day > Weekday(time.Saturday) {
+			return ErrInvalidWeekday
+		}
+		if h.Opens < 0 || h.Opens >= 24*60 || h.Closes < 0 || h.Closes >= 24*60 {
+			return ErrInvalidClockTime
+		}
+	}
+	seen := make(map[string]bool, len(closures))
+	for _, c := range closures {
+		if c.Date.IsZero() || seen[c.Date.String()] {
+			return ErrInvalidClosure
+		}
+		seen[c.Date.String()] = true
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package models
+
+import "go-mysql-backend/internal/translit"
+
+// SearchEntity is the kind of record a search hit points to.
+type SearchEntity string
+
+const (
+	SearchMinistry   SearchEntity = "ministry"
+	SearchDepartment SearchEntity = "department"
+	SearchOffice     SearchEntity = "office"
+)
+
+// SearchEntities lists every searchable entity, in the order hits of equal
+// score are returned.
+var SearchEntities = []SearchEntity{SearchMinistry, SearchDepartment, SearchOffice}
+
+// SearchQuery is a full-text search. Entities limits the hits to those
+// kinds of record; it is never empty once the query has been read.
+type SearchQuery struct {
+	Text     string
+	Entities []SearchEntity
+	Limit    int
+}
+
+// SearchHit is one record matching a search, best first. Snippet is the
+// matched text with the matching words wrapped in <mark></mark>. ParentID
+// is the ministry of a department or the department of an office.
+type SearchHit struct {
+	Entity   SearchEntity   `json:"entity"`
+	ID       int            `json:"id"`
+	Name     string         `json:"name"`
+	Names    LocalizedNames `json:"names,omitempty"`
+	ParentID *int           `json:"parent_id,omitempty"`
+	Score    float64        `json:"score"`
+	Snippet  string         `json:"snippet"`
+}
+
+// SearchResult is the response of a search. When the query is romanized,
+// Transliterations holds it in Sinhala and Tamil script, as searched.
+type SearchResult struct {
+	Query            string         `json:"query"`
+	Transliterations LocalizedNames `json:"transliterations,omitempty"`
+	Hits             []SearchHit    `json:"hits"`
+}
+
+// NewSearchResult returns the result of searching for text.
+func NewSearchResult(text string, hits []SearchHit) SearchResult {
+	result := SearchResult{Query: text, Hits: hits}
+	if translit.IsRomanized(text) {
+		result.Transliterations = LocalizedNames{
+			LocaleSinhala: translit.ToSinhala(text),
+			LocaleTamil:   translit.ToTamil(text),
+		}
+	}
+	return result
+}
+
+// Localize sets the name of every hit to the name in locale.
+func (s *SearchResult) Localize(locale Locale) {
+	for i := range s.Hits {
+		s.Hits[i].Name = s.Hits[i].Names.Pick(locale, s.Hits[i].Name)
+	}
+}
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"go-mysql-backend/internal/models"
+)
+
+// AdminAreaRepository keeps the administrative areas in Postgres, or in
+// SQLite, whose schema has the same tables.
+type AdminAreaRepository struct {
+	DB *sql.DB
+}
+
+func NewAdminAreaRepository(db *sql.DB) *AdminAreaRepository {
+	return &AdminAreaRepository{DB: db}
+}
+
+const adminAreaColumns = `a.id, a.code, a.name, a.level, a.parent_id, p.code`
+
+func (r *AdminAreaRepository) GetAreas(level models.AdminLevel) ([]models.AdminArea, error) {
+	rows, err := r.DB.Query(`
+		SELECT `+adminAreaColumns+`
+		FROM admin_area a
+		LEFT JOIN admin_area p ON p.id = a.parent_id
+		WHERE a.level = $1
+		ORDER BY a.code
+	`, level)
+	if err != nil {
+		return nil, err
+	}
+	return scanAdminAreas(rows)
+}
+
+func (r *AdminAreaRepository) GetAreaByID(id int) (*models.AdminArea, error) {
+	var area models.AdminArea
+	var parentID sql.NullInt64
+	var parentCode sql.NullString
+	var geometry []byte
+
+	err := r.DB.QueryRow(`
+		SELECT `+adminAreaColumns+`, a.geometry
+		FROM admin_area a
+		LEFT JOIN admin_area p ON p.id = a.parent_id
+		WHERE a.id = $1
+	`, id).Scan(&area.ID, &area.Code, &area.Name, &area.Level, &parentID, &parentCode, &geometry)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	area.ParentID = nullIntPtr(parentID)
+	area.ParentCode = parentCode.String
+	if len(geometry) > 0 {
+		area.Geometry = geometry
+	}
+	return &area, nil
+}
+
+// GetAreaBoundaries returns every area that has a boundary, geometry
+// included, ordered from provinces down.
+func (r *AdminAreaRepository) GetAreaBoundaries() ([]models.AdminArea, error) {
+	rows, err := r.DB.Query(`
+		SELECT ` + adminAreaColumns + `, a.geometry
+		FROM admin_area a
+		LEFT JOIN admin_area p ON p.id = a.parent_id
+		WHERE a.geometry IS NOT NULL
+		ORDER BY a.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	areas := []models.AdminArea{}
+	for rows.Next() {
+		var area models.AdminArea
+		var parentID sql.NullInt64
+		var parentCode sql.NullString
+		var geometry []byte
+		if err := rows.Scan(&area.ID, &area.Code, &area.Name, &area.Level, &parentID, &parentCode, &geometry); err != nil {
+			return nil, err
+		}
+		area.ParentID = nullIntPtr(parentID)
+		area.ParentCode = parentCode.String
+		area.Geometry = geometry
+		areas = append(areas, area)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sortAreasByLevel(areas), nil
+}
+
+func (r *AdminAreaRepository) GetChildAreas(id int) ([]models.AdminArea, error) {
+	if err := r.expectArea(id); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.DB.Query(`
+		SELECT `+adminAreaColumns+`
+		FROM admin_area a
+		JOIN admin_area p ON p.id = a.parent_id
+		WHERE a.parent_id = $1
+		ORDER BY a.code
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	return scanAdminAreas(rows)
+}
+
+// GetAreaDepartments returns the departments linked to the area or to any
+// area below it.
+func (r *AdminAreaRepository) GetAreaDepartments(id int) ([]models.Department, error) {
+	if err := r.expectArea(id); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.DB.Query(`
+		WITH RECURSIVE area_tree AS (
+			SELECT id FROM admin_area WHERE id = $1
+			UNION ALL
+			SELECT a.id FROM admin_area a JOIN area_tree t ON a.parent_id = t.id
+		)
+		SELECT `+departmentColumns+`
+		FROM department d
+		WHERE d.admin_area_id IN (SELECT id FROM area_tree)
+		ORDER BY d.id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	return scanDepartments(rows)
+}
+
+// SaveAreas inserts or updates areas keyed by (level, code) in a single
+// transaction. Parents are resolved from ParentCode, so a file of districts
+// can be loaded once its provinces exist.
+func (r *AdminAreaRepository) SaveAreas(areas []models.AdminArea) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, area := range sortAreasByLevel(areas) {
+		var parentID *int
+		if area.Level.Parent() != "" {
+			var id int
+			err := tx.QueryRow(`SELECT id FROM admin_area WHERE level = $1 AND code = $2`,
+				area.Level.Parent(), area.ParentCode).Scan(&id)
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("%w: %s %q has unknown parent %q", ErrInvalidReference, area.Level, area.Code, area.ParentCode)
+			} else if err != nil {
+				return err
+			}
+			parentID = &id
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO admin_area (code, name, level, parent_id, geometry)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (level, code) DO UPDATE
+			SET name = EXCLUDED.name, parent_id = EXCLUDED.parent_id, geometry = EXCLUDED.geometry
+		`, area.Code, area.Name, area.Level, parentID, nullGeometry(area.Geometry))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *AdminAreaRepository) SetDepartmentArea(departmentID int, areaID *int) error {
+	res, err := r.DB.Exec(`UPDATE department SET admin_area_id = $1 WHERE id = $2`, areaID, departmentID)
+	if err != nil {
+		return translateConstraintError(err)
+	}
+	return expectRowsAffected(res)
+}
+
+func (r *AdminAreaRepository) expectArea(id int) error {
+	var exists bool
+	if err := r.DB.QueryRow(`SELECT EXISTS (SELECT 1 FROM admin_area WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanAdminAreas(rows *sql.Rows) ([]models.AdminArea, error) {
+	defer rows.Close()
+
+	areas := []models.AdminArea{}
+	for rows.Next() {
+		var area models.AdminArea
+		var parentID sql.NullInt64
+		var parentCode sql.NullString
+		if err := rows.Scan(&area.ID, &area.Code, &area.Name, &area.Level, &parentID, &parentCode); err != nil {
+			return nil, err
+		}
+		area.ParentID = nullIntPtr(parentID)
+		area.ParentCode = parentCode.String
+		areas = append(areas, area)
+	}
+	return areas, rows.Err()
+}
+
+// sortAreasByLevel orders areas from provinces down so parents are saved
+// before their children.
+func sortAreasByLevel(areas []models.AdminArea) []models.AdminArea {
+	depth := make(map[models.AdminLevel]int, len(models.AdminLevels))
+	for i, level := range models.AdminLevels {
+		depth[level] = i
+	}
+
+	sorted := append([]models.AdminArea(nil), areas...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return depth[sorted[i].Level] < depth[sorted[j].Level]
+	})
+	return sorted
+}
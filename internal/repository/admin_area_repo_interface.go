@@ -0,0 +1,17 @@
+package repository
+
+import "go-mysql-backend/internal/models"
+
+// AdminAreaRepo stores the administrative hierarchy (provinces, districts,
+// divisional secretariats and GN divisions) and links departments to the
+// area they sit in. Lists are returned without geometry; GetAreaByID and
+// GetAreaBoundaries include it.
+type AdminAreaRepo interface {
+	GetAreas(level models.AdminLevel) ([]models.AdminArea, error)
+	GetAreaByID(id int) (*models.AdminArea, error)
+	GetAreaBoundaries() ([]models.AdminArea, error)
+	GetChildAreas(id int) ([]models.AdminArea, error)
+	GetAreaDepartments(id int) ([]models.Department, error)
+	SaveAreas(areas []models.AdminArea) error
+	SetDepartmentArea(departmentID int, areaID *int) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "errors"
+
+// Errors returned by repository implementations so callers can tell
+// expected failures apart from storage errors.
+var (
+	ErrNotFound               = errors.New("record not found")
+	ErrMinistryHasDepartments = errors.New("ministry still has departments")
+	ErrInvalidReassignTarget  = errors.New("invalid reassignment target ministry")
+	ErrInvalidReference       = errors.New("referenced record does not exist")
+	ErrInvalidParent          = errors.New("parent would create a cycle or belongs to another ministry")
+	ErrEffectiveDateOrder     = errors.New("effective date is before the latest recorded change")
+	ErrInvalidTerm            = errors.New("term would end before it starts")
+)
@@ -0,0 +1,44 @@
+package repository
+
+import "go-mysql-backend/internal/models"
+
+// importPlan creates the records of plan in order with the create
+// functions of a backend, which all run in the same transaction, and
+// stops at the first that fails.
+func importPlan(plan models.ImportPlan,
+	createMinistry func(models.Ministry) (int, error),
+	createDepartment func(models.Department) (int, error),
+	createOffice func(models.Office) (int, error)) (models.ImportResult, error) {
+	result := models.ImportResult{MinistryIDs: []int{}, DepartmentIDs: []int{}, OfficeIDs: []int{}}
+
+	for _, m := range plan.Ministries {
+		id, err := createMinistry(m.Ministry)
+		if err != nil {
+			return models.ImportResult{}, &models.ImportRowError{Entity: "ministry", Row: m.Row, Err: err}
+		}
+		result.MinistryIDs = append(result.MinistryIDs, id)
+	}
+	for _, d := range plan.Departments {
+		dept := d.Department
+		if d.MinistryIndex != nil {
+			dept.MinistryID = result.MinistryIDs[*d.MinistryIndex]
+		}
+		id, err := createDepartment(dept)
+		if err != nil {
+			return models.ImportResult{}, &models.ImportRowError{Entity: "department", Row: d.Row, Err: err}
+		}
+		result.DepartmentIDs = append(result.DepartmentIDs, id)
+	}
+	for _, o := range plan.Offices {
+		office := o.Office
+		if o.DepartmentIndex != nil {
+			office.DepartmentID = result.DepartmentIDs[*o.DepartmentIndex]
+		}
+		id, err := createOffice(office)
+		if err != nil {
+			return models.ImportResult{}, &models.ImportRowError{Entity: "office", Row: o.Row, Err: err}
+		}
+		result.OfficeIDs = append(result.OfficeIDs, id)
+	}
+	return result, nil
+}
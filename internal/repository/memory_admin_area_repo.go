@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"go-mysql-backend/internal/models"
+)
+
+// MemoryAdminAreaRepository keeps the administrative areas in the
+// MemoryStore that holds the departments linked to them.
+type MemoryAdminAreaRepository struct {
+	Store *MemoryStore
+}
+
+func NewMemoryAdminAreaRepository(store *MemoryStore) *MemoryAdminAreaRepository {
+	return &MemoryAdminAreaRepository{Store: store}
+}
+
+func (r *MemoryAdminAreaRepository) GetAreas(level models.AdminLevel) ([]models.AdminArea, error) {
+	var areas []models.AdminArea
+	err := r.Store.read(func(d *memoryData) error {
+		areas = d.areaList(func(area models.AdminArea) bool { return area.Level == level })
+		return nil
+	})
+	return areas, err
+}
+
+func (r *MemoryAdminAreaRepository) GetAreaByID(id int) (*models.AdminArea, error) {
+	var area models.AdminArea
+	err := r.Store.read(func(d *memoryData) error {
+		found, ok := d.areas[id]
+		if !ok {
+			return ErrNotFound
+		}
+		area = d.areaWithParentCode(found)
+		area.Geometry = slices.Clone(found.Geometry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &area, nil
+}
+
+// GetAreaBoundaries returns every area that has a boundary, geometry
+// included, ordered from provinces down.
+func (r *MemoryAdminAreaRepository) GetAreaBoundaries() ([]models.AdminArea, error) {
+	areas := []models.AdminArea{}
+	err := r.Store.read(func(d *memoryData) error {
+		for _, id := range sortedIDs(d.areas) {
+			if area := d.areas[id]; len(area.Geometry) > 0 {
+				withGeometry := d.areaWithParentCode(area)
+				withGeometry.Geometry = slices.Clone(area.Geometry)
+				areas = append(areas, withGeometry)
+			}
+		}
+		return nil
+	})
+	return sortAreasByLevel(areas), err
+}
+
+func (r *MemoryAdminAreaRepository) GetChildAreas(id int) ([]models.AdminArea, error) {
+	var areas []models.AdminArea
+	err := r.Store.read(func(d *memoryData) error {
+		if _, ok := d.areas[id]; !ok {
+			return ErrNotFound
+		}
+		areas = d.areaList(func(area models.AdminArea) bool { return area.ParentID != nil && *area.ParentID == id })
+		return nil
+	})
+	return areas, err
+}
+
+// GetAreaDepartments returns the departments linked to the area or to any
+// area below it.
+func (r *MemoryAdminAreaRepository) GetAreaDepartments(id int) ([]models.Department, error) {
+	departments := []models.Department{}
+	err := r.Store.read(func(d *memoryData) error {
+		if _, ok := d.areas[id]; !ok {
+			return ErrNotFound
+		}
+		inTree := map[int]bool{id: true}
+		// Areas are saved parents first, so their ids are in the same order.
+		for _, areaID := range sortedIDs(d.areas) {
+			if parentID := d.areas[areaID].ParentID; parentID != nil && inTree[*parentID] {
+				inTree[areaID] = true
+			}
+		}
+		for _, dept := range d.departmentList(nil) {
+			if dept.AdminAreaID != nil && inTree[*dept.AdminAreaID] {
+				departments = append(departments, dept)
+			}
+		}
+		return nil
+	})
+	return departments, err
+}
+
+// SaveAreas inserts or updates areas keyed by (level, code) in a single
+// write. Parents are resolved from ParentCode, so a file of districts can
+// be loaded once its provinces exist.
+func (r *MemoryAdminAreaRepository) SaveAreas(areas []models.AdminArea) error {
+	return r.Store.write(func(d *memoryData) error {
+		for _, area := range sortAreasByLevel(areas) {
+			area.ParentID = nil
+			if area.Level.Parent() != "" {
+				parent, ok := d.areaByCode(area.Level.Parent(), area.ParentCode)
+				if !ok {
+					return fmt.Errorf("%w: %s %q has unknown parent %q", ErrInvalidReference, area.Level, area.Code, area.ParentCode)
+				}
+				area.ParentID = &parent.ID
+			}
+
+			if existing, ok := d.areaByCode(area.Level, area.Code); ok {
+				area.ID = existing.ID
+			} else {
+				area.ID = d.nextID(seqArea)
+			}
+			area.ParentCode = ""
+			area.Geometry = slices.Clone(area.Geometry)
+			d.areas[area.ID] = area
+		}
+		return nil
+	})
+}
+
+func (r *MemoryAdminAreaRepository) SetDepartmentArea(departmentID int, areaID *int) error {
+	return r.Store.write(func(d *memoryData) error {
+		dept, ok := d.departments[departmentID]
+		if !ok {
+			return ErrNotFound
+		}
+		if areaID != nil {
+			if _, ok := d.areas[*areaID]; !ok {
+				return ErrInvalidReference
+			}
+		}
+		dept.AdminAreaID = copyPtr(areaID)
+		d.departments[departmentID] = dept
+		return nil
+	})
+}
+
+// areaList returns the areas that match keep, without geometry, ordered
+// by code.
+func (d *memoryData) areaList(keep func(models.AdminArea) bool) []models.AdminArea {
+	areas := []models.AdminArea{}
+	for _, id := range sortedIDs(d.areas) {
+		if area := d.areas[id]; keep(area) {
+			areas = append(areas, d.areaWithParentCode(area))
+		}
+	}
+	sort.SliceStable(areas, func(i, j int) bool { return areas[i].Code < areas[j].Code })
+	return areas
+}
+
+// areaWithParentCode returns area without geometry and with the code of
+// its parent.
+func (d *memoryData) areaWithParentCode(area models.AdminArea) models.AdminArea {
+	area.Geometry = nil
+	area.ParentID = copyPtr(area.ParentID)
+	if area.ParentID != nil {
+		area.ParentCode = d.areas[*area.ParentID].Code
+	}
+	return area
+}
+
+func (d *memoryData) areaByCode(level models.AdminLevel, code string) (models.AdminArea, bool) {
+	for _, area := range d.areas {
+		if area.Level == level && area.Code == code {
+			return area, true
+		}
+	}
+	return models.AdminArea{}, false
+}
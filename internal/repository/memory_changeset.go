@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+)
+
+// ApplyChangeSet applies every operation of cs in one write, in order,
+// each on its own effective date. If an operation fails nothing is applied
+// and the error is a *models.ChangeOpError naming it.
+func (r *MemoryRepository) ApplyChangeSet(cs models.ChangeSet) (models.ChangeSetResult, error) {
+	result := models.ChangeSetResult{Operations: []models.ChangeOpResult{}}
+	err := r.Store.write(func(d *memoryData) error {
+		for i, op := range cs.Resolve() {
+			created, err := d.applyChangeOp(op)
+			if err != nil {
+				return &models.ChangeOpError{Index: i, Type: op.Type, Err: err}
+			}
+			d.gazetteChanges = append(d.gazetteChanges, op)
+			result.Operations = append(result.Operations, models.ChangeOpResult{
+				Index: i, Type: op.Type, Gazette: op.Gazette, CreatedIDs: created,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return models.ChangeSetResult{}, err
+	}
+	result.Applied = len(result.Operations)
+	return result, nil
+}
+
+func (d *memoryData) applyChangeOp(op models.ChangeOp) ([]int, error) {
+	switch op.Type {
+	case models.OpTransfer:
+		return nil, d.transferDepartment(op.ID, op.ToMinistryID, op.EffectiveDate)
+	case models.OpRename:
+		return nil, d.renameOrganization(models.OrgRef{Entity: op.Entity, ID: op.ID}, op.Name, op.Names, op.EffectiveDate)
+	case models.OpMerge:
+		return nil, d.mergeDepartments(op.IntoID, op.DepartmentIDs, op.Name, op.Names, op.EffectiveDate)
+	case models.OpSplit:
+		return d.splitDepartment(op.ID, op.Parts, op.EffectiveDate)
+	}
+	return nil, fmt.Errorf("%w: unknown operation type %q", models.ErrInvalidChange, op.Type)
+}
+
+// transferDepartment moves a department, with its divisions, to a ministry
+// as a top-level department.
+func (d *memoryData) transferDepartment(id, ministryID int, effective models.Date) error {
+	dept, ok := d.department(id, nil)
+	if !ok {
+		return ErrNotFound
+	}
+	dept.MinistryID = ministryID
+	dept.ParentID = nil
+	return d.updateDepartment(dept, effective)
+}
+
+// renameOrganization replaces the name of a ministry or department in every
+// language; translations the gazette does not give are dropped.
+func (d *memoryData) renameOrganization(ref models.OrgRef, name string, names models.LocalizedNames, effective models.Date) error {
+	if ref.Entity == models.EntityMinistry {
+		ministry, ok := d.ministry(ref.ID, nil)
+		if !ok {
+			return ErrNotFound
+		}
+		ministry.Name, ministry.Names = name, names
+		return d.updateMinistry(ministry, effective)
+	}
+
+	dept, ok := d.department(ref.ID, nil)
+	if !ok {
+		return ErrNotFound
+	}
+	dept.Name, dept.Names = name, names
+	return d.updateDepartment(dept, effective)
+}
+
+// mergeDepartments moves the divisions and offices of each of ids to into
+// and removes the merged departments.
+func (d *memoryData) mergeDepartments(into int, ids []int, name string, names models.LocalizedNames, effective models.Date) error {
+	target, ok := d.department(into, nil)
+	if !ok {
+		return ErrNotFound
+	}
+
+	for _, id := range ids {
+		if !d.hasDepartment(id) {
+			return fmt.Errorf("department %d: %w", id, ErrNotFound)
+		}
+		for _, unitID := range sortedIDs(d.departments) {
+			unit := d.departments[unitID]
+			if unit.ParentID == nil || *unit.ParentID != id || unitID == into {
+				continue
+			}
+			unit.ParentID = &target.ID
+			unit.MinistryID = target.MinistryID
+			if err := d.updateDepartment(unit, effective); err != nil {
+				return err
+			}
+		}
+		d.moveOffices([]int{id}, into)
+		if err := d.deleteDepartment(id, effective); err != nil {
+			return err
+		}
+	}
+
+	if name == "" {
+		return nil
+	}
+	// Reload: into may have been detached from a merged parent.
+	return d.renameOrganization(models.OrgRef{Entity: models.EntityDepartment, ID: into}, name, names, effective)
+}
+
+// splitDepartment replaces a department with one new department per part,
+// under the same ministry and parent and at the same location. Each part
+// takes the divisions it claims; the rest become top-level departments.
+// The offices go to the first part.
+func (d *memoryData) splitDepartment(id int, parts []models.SplitPart, effective models.Date) ([]int, error) {
+	source, ok := d.department(id, nil)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	created := make([]int, 0, len(parts))
+	for _, part := range parts {
+		partID, err := d.createDepartment(models.Department{
+			Name:        part.Name,
+			Names:       part.Names,
+			Kind:        part.Kind,
+			ParentID:    source.ParentID,
+			MinistryID:  source.MinistryID,
+			AdminAreaID: source.AdminAreaID,
+			Location:    source.Location,
+		}, effective)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, partID)
+
+		for _, unitID := range part.UnitIDs {
+			unit, ok := d.department(unitID, nil)
+			if !ok {
+				return nil, fmt.Errorf("division %d: %w", unitID, ErrNotFound)
+			}
+			if unit.ParentID == nil || *unit.ParentID != id {
+				return nil, fmt.Errorf("%w: department %d is not a division of department %d", models.ErrInvalidChange, unitID, id)
+			}
+			unit.ParentID = &partID
+			if err := d.updateDepartment(unit, effective); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(created) > 0 {
+		d.moveOffices([]int{id}, created[0])
+	}
+	return created, d.deleteDepartment(id, effective)
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+)
+
+// GetOrganizationTree returns root and its descendants down to depth
+// levels below it.
+func (r *MemoryRepository) GetOrganizationTree(root models.OrgRef, depth int) (models.OrgNode, error) {
+	if !root.Entity.Valid() {
+		return models.OrgNode{}, fmt.Errorf("unknown organisation entity %q", root.Entity)
+	}
+
+	var tree models.OrgNode
+	err := r.Store.read(func(d *memoryData) error {
+		ministries := d.ministryList(r.asOf)
+		departments := d.departmentList(r.asOf)
+
+		node, ok := models.OrgNode{}, false
+		if root.Entity == models.EntityMinistry {
+			for _, m := range ministries {
+				if m.ID == root.ID {
+					node, ok = ministryNode(m), true
+				}
+			}
+		} else {
+			for _, dept := range departments {
+				if dept.ID == root.ID {
+					node, ok = departmentNode(dept), true
+				}
+			}
+		}
+		if !ok {
+			return ErrNotFound
+		}
+
+		// Walk the hierarchy a level at a time, as the recursive query
+		// does; seen guards against cycles in bad data.
+		var rows []orgTreeRow
+		seen := map[models.OrgRef]bool{root: true}
+		level := []models.OrgRef{root}
+		for n := 1; n <= depth && len(level) > 0; n++ {
+			var next []models.OrgRef
+			for _, parent := range level {
+				for _, child := range orgChildren(parent, ministries, departments) {
+					ref := models.OrgRef{Entity: child.Entity, ID: child.ID}
+					if seen[ref] {
+						continue
+					}
+					seen[ref] = true
+					child.Depth = n
+					rows = append(rows, orgTreeRow{node: child, parent: parent})
+					next = append(next, ref)
+				}
+			}
+			level = next
+		}
+		tree = assembleOrgTree(node, rows)
+		return nil
+	})
+	return tree, err
+}
+
+// orgChildren returns the children of parent, in the order of a tree: a
+// ministry's state ministries and boards, then its top-level departments;
+// a department's divisions and units. Both lists are ordered by id.
+func orgChildren(parent models.OrgRef, ministries []models.Ministry, departments []models.Department) []models.OrgNode {
+	var children []models.OrgNode
+	if parent.Entity == models.EntityMinistry {
+		for _, m := range ministries {
+			if m.ParentID != nil && *m.ParentID == parent.ID {
+				children = append(children, ministryNode(m))
+			}
+		}
+		for _, dept := range departments {
+			if dept.ParentID == nil && dept.MinistryID == parent.ID {
+				children = append(children, departmentNode(dept))
+			}
+		}
+		return children
+	}
+	for _, dept := range departments {
+		if dept.ParentID != nil && *dept.ParentID == parent.ID {
+			children = append(children, departmentNode(dept))
+		}
+	}
+	return children
+}
+
+func ministryNode(m models.Ministry) models.OrgNode {
+	return models.OrgNode{Entity: models.EntityMinistry, ID: m.ID, Name: m.Name, Names: m.Names, Kind: m.Kind}
+}
+
+func departmentNode(dept models.Department) models.OrgNode {
+	return models.OrgNode{Entity: models.EntityDepartment, ID: dept.ID, Name: dept.Name, Names: dept.Names, Kind: dept.Kind}
+}
+
+// checkMinistryParent rejects a parent ministry that does not exist, or that
+// is the ministry itself or one of its descendants. id is 0 for a new
+// ministry.
+func (d *memoryData) checkMinistryParent(id, parentID int) error {
+	if _, ok := d.ministries[parentID]; !ok {
+		return ErrInvalidReference
+	}
+	seen := map[int]bool{}
+	for ancestor := &parentID; ancestor != nil && !seen[*ancestor]; ancestor = d.ministries[*ancestor].ParentID {
+		if *ancestor == id {
+			return ErrInvalidParent
+		}
+		seen[*ancestor] = true
+	}
+	return nil
+}
+
+// checkDepartmentRefs checks the ministry, area and parent department that
+// dept refers to. The parent must be in the same ministry and must not be
+// the department itself or one of its descendants. id is 0 for a new
+// department.
+func (d *memoryData) checkDepartmentRefs(id int, dept models.Department) error {
+	if _, ok := d.ministries[dept.MinistryID]; !ok {
+		return ErrInvalidReference
+	}
+	if dept.AdminAreaID != nil {
+		if _, ok := d.areas[*dept.AdminAreaID]; !ok {
+			return ErrInvalidReference
+		}
+	}
+	if dept.ParentID == nil {
+		return nil
+	}
+
+	parent, ok := d.departments[*dept.ParentID]
+	if !ok {
+		return ErrInvalidReference
+	}
+	if parent.MinistryID != dept.MinistryID || parent.ID == id {
+		return ErrInvalidParent
+	}
+	for _, descendant := range d.descendants(id) {
+		if descendant == parent.ID {
+			return ErrInvalidParent
+		}
+	}
+	return nil
+}
+
+// descendants returns the divisions and units below department id, at any
+// depth.
+func (d *memoryData) descendants(id int) []int {
+	children := map[int][]int{}
+	for _, childID := range sortedIDs(d.departments) {
+		if parentID := d.departments[childID].ParentID; parentID != nil {
+			children[*parentID] = append(children[*parentID], childID)
+		}
+	}
+
+	var ids []int
+	seen := map[int]bool{id: true}
+	for queue := children[id]; len(queue) > 0; queue = queue[1:] {
+		if childID := queue[0]; !seen[childID] {
+			seen[childID] = true
+			ids = append(ids, childID)
+			queue = append(queue, children[childID]...)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	"go-mysql-backend/internal/models"
+)
+
+// memoryVersion is a snapshot of the versioned fields of a ministry or
+// department, as in ministry_version and department_version, valid over
+// [ValidFrom, ValidTo). A zero ValidTo marks the open version.
+type memoryVersion struct {
+	ID         int                   `json:"id"`
+	Name       string                `json:"name"`
+	Names      models.LocalizedNames `json:"names,omitempty"`
+	Kind       models.OrgKind        `json:"kind"`
+	ParentID   *int                  `json:"parent_id,omitempty"`
+	MinistryID int                   `json:"ministry_id,omitempty"`
+	ValidFrom  models.Date           `json:"valid_from"`
+	ValidTo    models.Date           `json:"valid_to"`
+}
+
+// memoryHistory holds the versions of each ministry or department, oldest
+// first. label names the records in errors.
+type memoryHistory struct {
+	label    string
+	versions map[int][]memoryVersion
+}
+
+func (h memoryHistory) clone() memoryHistory {
+	versions := make(map[int][]memoryVersion, len(h.versions))
+	for id, list := range h.versions {
+		versions[id] = slices.Clone(list)
+	}
+	return memoryHistory{label: h.label, versions: versions}
+}
+
+// sort orders the versions of each record by ValidFrom, as they are read
+// from a file in any order.
+func (h memoryHistory) sort() {
+	for _, list := range h.versions {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].ValidFrom.Before(list[j].ValidFrom) })
+	}
+}
+
+// list returns every version, ordered by record and then by date.
+func (h memoryHistory) list() []memoryVersion {
+	var list []memoryVersion
+	for _, id := range sortedIDs(h.versions) {
+		list = append(list, h.versions[id]...)
+	}
+	return list
+}
+
+// open returns the open version of id.
+func (h memoryHistory) open(id int) (memoryVersion, bool) {
+	list := h.versions[id]
+	if n := len(list); n > 0 && list[n-1].ValidTo.IsZero() {
+		return list[n-1], true
+	}
+	return memoryVersion{}, false
+}
+
+// at returns the version of id valid on date.
+func (h memoryHistory) at(id int, date models.Date) (memoryVersion, bool) {
+	for _, v := range h.versions[id] {
+		if !v.ValidFrom.After(date) && (v.ValidTo.IsZero() || v.ValidTo.After(date)) {
+			return v, true
+		}
+	}
+	return memoryVersion{}, false
+}
+
+// idsAt returns the ids of the records that have a version valid on date,
+// in ascending order.
+func (h memoryHistory) idsAt(date models.Date) []int {
+	var ids []int
+	for _, id := range sortedIDs(h.versions) {
+		if _, ok := h.at(id, date); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// record makes v the version starting on effective, closing the open
+// version. A version opened on the same day is replaced, so several edits
+// on one day leave a single version.
+func (h memoryHistory) record(v memoryVersion, effective models.Date) error {
+	if err := h.close(v.ID, effective); err != nil {
+		return err
+	}
+	v.ValidFrom, v.ValidTo = effective, models.Date{}
+	h.versions[v.ID] = append(h.versions[v.ID], v)
+	return nil
+}
+
+// close ends the open version of id on effective. It fails with
+// ErrEffectiveDateOrder when the open version starts after effective,
+// since history is only ever appended to.
+func (h memoryHistory) close(id int, effective models.Date) error {
+	open, ok := h.open(id)
+	if !ok {
+		return nil
+	}
+	list := h.versions[id]
+	switch {
+	case open.ValidFrom.After(effective):
+		return fmt.Errorf("%w: %s %d changed on %s", ErrEffectiveDateOrder, h.label, id, open.ValidFrom)
+	case open.ValidFrom.Equal(effective):
+		h.versions[id] = list[:len(list)-1]
+	default:
+		list[len(list)-1].ValidTo = effective
+	}
+	return nil
+}
+
+// recordMinistry and recordDepartment snapshot the live record id as the
+// version starting on effective.
+func (d *memoryData) recordMinistry(id int, effective models.Date) error {
+	m := d.ministries[id]
+	return d.ministryVersions.record(memoryVersion{
+		ID: id, Name: m.Name, Names: maps.Clone(m.Names), Kind: m.Kind, ParentID: copyPtr(m.ParentID),
+	}, effective)
+}
+
+func (d *memoryData) recordDepartment(id int, effective models.Date) error {
+	dept := d.departments[id]
+	return d.departmentVersions.record(memoryVersion{
+		ID: id, Name: dept.Name, Names: maps.Clone(dept.Names), Kind: dept.Kind, ParentID: copyPtr(dept.ParentID),
+		MinistryID: dept.MinistryID,
+	}, effective)
+}
+
+// ministry returns ministry id as it stood on asOf, or as it is now when
+// asOf is nil. Dated reads take the versioned fields from the history and
+// the rest, which is not versioned, from the live record.
+func (d *memoryData) ministry(id int, asOf *models.Date) (models.Ministry, bool) {
+	if asOf == nil {
+		m, ok := d.ministries[id]
+		return copyMinistry(m), ok
+	}
+	v, ok := d.ministryVersions.at(id, *asOf)
+	if !ok {
+		return models.Ministry{}, false
+	}
+	live := d.ministries[id]
+	return copyMinistry(models.Ministry{
+		ID: id, Name: v.Name, Names: v.Names, Kind: v.Kind, ParentID: v.ParentID,
+		Google_map_script: live.Google_map_script, Location: live.Location,
+	}), true
+}
+
+func (d *memoryData) department(id int, asOf *models.Date) (models.Department, bool) {
+	if asOf == nil {
+		dept, ok := d.departments[id]
+		return copyDepartment(dept), ok
+	}
+	v, ok := d.departmentVersions.at(id, *asOf)
+	if !ok {
+		return models.Department{}, false
+	}
+	live := d.departments[id]
+	return copyDepartment(models.Department{
+		ID: id, Name: v.Name, Names: v.Names, Kind: v.Kind, ParentID: v.ParentID, MinistryID: v.MinistryID,
+		Google_map_script: live.Google_map_script, AdminAreaID: live.AdminAreaID, Location: live.Location,
+	}), true
+}
+
+// ministryIDs and departmentIDs list the records that existed on asOf, or
+// that exist now when asOf is nil, in ascending order.
+func (d *memoryData) ministryIDs(asOf *models.Date) []int {
+	if asOf == nil {
+		return sortedIDs(d.ministries)
+	}
+	return d.ministryVersions.idsAt(*asOf)
+}
+
+func (d *memoryData) departmentIDs(asOf *models.Date) []int {
+	if asOf == nil {
+		return sortedIDs(d.departments)
+	}
+	return d.departmentVersions.idsAt(*asOf)
+}
+
+// ministryList and departmentList return the records of ministryIDs and
+// departmentIDs.
+func (d *memoryData) ministryList(asOf *models.Date) []models.Ministry {
+	ministries := []models.Ministry{}
+	for _, id := range d.ministryIDs(asOf) {
+		m, _ := d.ministry(id, asOf)
+		ministries = append(ministries, m)
+	}
+	return ministries
+}
+
+func (d *memoryData) departmentList(asOf *models.Date) []models.Department {
+	departments := []models.Department{}
+	for _, id := range d.departmentIDs(asOf) {
+		dept, _ := d.department(id, asOf)
+		departments = append(departments, dept)
+	}
+	return departments
+}
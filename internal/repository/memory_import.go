@@ -0,0 +1,19 @@
+package repository
+
+import "go-mysql-backend/internal/models"
+
+// Import creates the records of plan in one write, effective today. If
+// one fails nothing is created and the error is a *models.ImportRowError
+// naming it.
+func (r *MemoryRepository) Import(plan models.ImportPlan) (models.ImportResult, error) {
+	var result models.ImportResult
+	err := r.Store.write(func(d *memoryData) (err error) {
+		effective := models.Today()
+		result, err = importPlan(plan,
+			func(m models.Ministry) (int, error) { return d.createMinistry(m, effective) },
+			func(dept models.Department) (int, error) { return d.createDepartment(dept, effective) },
+			d.createOffice)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"sort"
+
+	"go-mysql-backend/internal/geo"
+	"go-mysql-backend/internal/models"
+)
+
+// GetDepartmentOffices lists the offices of a department, head office
+// first by id.
+func (r *MemoryRepository) GetDepartmentOffices(departmentID int) ([]models.Office, error) {
+	var offices []models.Office
+	err := r.Store.read(func(d *memoryData) error {
+		offices = d.departmentOffices(departmentID)
+		return nil
+	})
+	return offices, err
+}
+
+func (d *memoryData) departmentOffices(departmentID int) []models.Office {
+	offices := []models.Office{}
+	for _, id := range sortedIDs(d.offices) {
+		if office := d.offices[id]; office.DepartmentID == departmentID {
+			offices = append(offices, copyOffice(office))
+		}
+	}
+	return offices
+}
+
+func (r *MemoryRepository) GetOfficeByID(id int) (*models.Office, error) {
+	var office models.Office
+	err := r.Store.read(func(d *memoryData) error {
+		found, ok := d.offices[id]
+		if !ok {
+			return ErrNotFound
+		}
+		office = copyOffice(found)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &office, nil
+}
+
+// FindOfficesNearby returns the offices within q.RadiusKm of the query
+// point, nearest first, using the haversine great-circle distance.
+func (r *MemoryRepository) FindOfficesNearby(q models.NearbyQuery) ([]models.NearbyOffice, error) {
+	offices := []models.NearbyOffice{}
+	err := r.Store.read(func(d *memoryData) error {
+		for _, id := range sortedIDs(d.offices) {
+			office := d.offices[id]
+			if !office.HasCoordinates() {
+				continue
+			}
+			distance := geo.HaversineKm(q.Latitude, q.Longitude, *office.Latitude, *office.Longitude)
+			if distance <= q.RadiusKm {
+				offices = append(offices, models.NearbyOffice{Office: copyOffice(office), DistanceKm: distance})
+			}
+		}
+		return nil
+	})
+	// The list is in id order, so a stable sort breaks ties by id.
+	sort.SliceStable(offices, func(i, j int) bool { return offices[i].DistanceKm < offices[j].DistanceKm })
+	return firstN(offices, q.Limit), err
+}
+
+// CreateOffice adds an office to an existing department.
+func (r *MemoryRepository) CreateOffice(office models.Office) (int, error) {
+	var id int
+	err := r.Store.write(func(d *memoryData) (err error) {
+		id, err = d.createOffice(office)
+		return err
+	})
+	return id, err
+}
+
+func (d *memoryData) createOffice(office models.Office) (int, error) {
+	if !d.hasDepartment(office.DepartmentID) {
+		return 0, ErrInvalidReference
+	}
+	office.ID = d.nextID(seqOffice)
+	d.saveOffice(office)
+	return office.ID, nil
+}
+
+// UpdateOffice replaces the details of an office. DepartmentID moves the
+// office to another department.
+func (r *MemoryRepository) UpdateOffice(office models.Office) error {
+	return r.Store.write(func(d *memoryData) error {
+		if _, ok := d.offices[office.ID]; !ok {
+			return ErrNotFound
+		}
+		if !d.hasDepartment(office.DepartmentID) {
+			return ErrInvalidReference
+		}
+		d.saveOffice(office)
+		return nil
+	})
+}
+
+func (r *MemoryRepository) DeleteOffice(id int) error {
+	return r.Store.write(func(d *memoryData) error {
+		if _, ok := d.offices[id]; !ok {
+			return ErrNotFound
+		}
+		delete(d.offices, id)
+		return nil
+	})
+}
+
+// saveOffice stores a copy of office. OpenNow is computed on reads and
+// never stored.
+func (d *memoryData) saveOffice(office models.Office) {
+	office.OpenNow = nil
+	d.offices[office.ID] = copyOffice(office)
+}
+
+// moveOffices hands the offices of the departments in from to department
+// to, as when departments are merged or split.
+func (d *memoryData) moveOffices(from []int, to int) {
+	for id, office := range d.offices {
+		for _, deptID := range from {
+			if office.DepartmentID == deptID {
+				office.DepartmentID = to
+				d.offices[id] = office
+			}
+		}
+	}
+}
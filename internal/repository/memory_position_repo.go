@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"sort"
+
+	"go-mysql-backend/internal/models"
+)
+
+// MemoryPositionRepository keeps people and their terms in a MemoryStore.
+// As in Postgres, a term names its organisation by entity and id, so
+// tenure history outlives a ministry or department that has been
+// dissolved.
+type MemoryPositionRepository struct {
+	Store *MemoryStore
+}
+
+func NewMemoryPositionRepository(store *MemoryStore) *MemoryPositionRepository {
+	return &MemoryPositionRepository{Store: store}
+}
+
+func (r *MemoryPositionRepository) CreatePerson(person models.Person) (int, error) {
+	var id int
+	err := r.Store.write(func(d *memoryData) error {
+		id = d.nextID(seqPerson)
+		person.ID = id
+		d.people[id] = person
+		return nil
+	})
+	return id, err
+}
+
+func (r *MemoryPositionRepository) GetPersonByID(id int) (*models.Person, error) {
+	var person models.Person
+	err := r.Store.read(func(d *memoryData) error {
+		var ok bool
+		if person, ok = d.people[id]; !ok {
+			return ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
+
+// CreatePosition records a term. The person and the organisation must
+// exist.
+func (r *MemoryPositionRepository) CreatePosition(position models.Position) (int, error) {
+	var id int
+	err := r.Store.write(func(d *memoryData) error {
+		exists := d.hasDepartment(position.OrganizationID)
+		if position.Entity == models.EntityMinistry {
+			_, exists = d.ministries[position.OrganizationID]
+		}
+		if !exists || !d.hasPerson(position.PersonID) {
+			return ErrInvalidReference
+		}
+
+		id = d.nextID(seqPosition)
+		position.ID = id
+		position.PersonName, position.OrganizationName = "", ""
+		d.positions[id] = position
+		return nil
+	})
+	return id, err
+}
+
+// EndPosition sets the day a term ends, which must be after it started.
+func (r *MemoryPositionRepository) EndPosition(id int, endDate models.Date) error {
+	return r.Store.write(func(d *memoryData) error {
+		position, ok := d.positions[id]
+		if !ok {
+			return ErrNotFound
+		}
+		if !endDate.After(position.StartDate) {
+			return ErrInvalidTerm
+		}
+		position.EndDate = endDate
+		d.positions[id] = position
+		return nil
+	})
+}
+
+// GetOfficeHolders returns the terms of org that cover date.
+func (r *MemoryPositionRepository) GetOfficeHolders(org models.OrgRef, role models.PositionRole, date models.Date) ([]models.Position, error) {
+	return r.positions(func(p models.Position) bool {
+		return p.Entity == org.Entity && p.OrganizationID == org.ID && (role == "" || p.Role == role) && p.HeldOn(date)
+	})
+}
+
+func (r *MemoryPositionRepository) GetPositionHistory(org models.OrgRef, role models.PositionRole) ([]models.Position, error) {
+	return r.positions(func(p models.Position) bool {
+		return p.Entity == org.Entity && p.OrganizationID == org.ID && (role == "" || p.Role == role)
+	})
+}
+
+func (r *MemoryPositionRepository) GetPersonPositions(personID int) ([]models.Position, error) {
+	if _, err := r.GetPersonByID(personID); err != nil {
+		return nil, err
+	}
+	return r.positions(func(p models.Position) bool { return p.PersonID == personID })
+}
+
+// positions returns the terms that match keep, oldest first, with the
+// names of their holders and organisations. A dissolved organisation
+// keeps its last recorded name.
+func (r *MemoryPositionRepository) positions(keep func(models.Position) bool) ([]models.Position, error) {
+	positions := []models.Position{}
+	err := r.Store.read(func(d *memoryData) error {
+		for _, id := range sortedIDs(d.positions) {
+			p := d.positions[id]
+			if !keep(p) {
+				continue
+			}
+			p.PersonName = d.people[p.PersonID].Name
+			p.OrganizationName = d.organizationName(models.OrgRef{Entity: p.Entity, ID: p.OrganizationID})
+			positions = append(positions, p)
+		}
+		return nil
+	})
+	sort.SliceStable(positions, func(i, j int) bool { return positions[i].StartDate.Before(positions[j].StartDate) })
+	return positions, err
+}
+
+// organizationName returns the current name of ref, or the name of its
+// latest version once it has been dissolved.
+func (d *memoryData) organizationName(ref models.OrgRef) string {
+	history := d.departmentVersions
+	if ref.Entity == models.EntityMinistry {
+		if m, ok := d.ministries[ref.ID]; ok {
+			return m.Name
+		}
+		history = d.ministryVersions
+	} else if dept, ok := d.departments[ref.ID]; ok {
+		return dept.Name
+	}
+
+	if versions := history.versions[ref.ID]; len(versions) > 0 {
+		return versions[len(versions)-1].Name
+	}
+	return ""
+}
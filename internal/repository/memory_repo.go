@@ -0,0 +1,408 @@
+package repository
+
+import (
+	"sort"
+
+	"go-mysql-backend/internal/geo"
+	"go-mysql-backend/internal/models"
+)
+
+// MemoryRepository keeps the organisations in a MemoryStore. It behaves as
+// OrganizationRepository does, so the API can run with no database at all.
+type MemoryRepository struct {
+	Store *MemoryStore
+	// asOf is set on the read-only views returned by AsOf.
+	asOf *models.Date
+}
+
+func NewMemoryRepository(store *MemoryStore) *MemoryRepository {
+	return &MemoryRepository{Store: store}
+}
+
+// AsOf returns a read-only view of the repository that answers read
+// queries from the history as the structure stood on date. Locations, map
+// scripts and area links are not versioned and come from the live records.
+func (r *MemoryRepository) AsOf(date models.Date) OrganizationRepo {
+	return &MemoryRepository{Store: r.Store, asOf: &date}
+}
+
+func (r *MemoryRepository) GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error) {
+	var ministries []models.MinistryWithDepartments
+	err := r.Store.read(func(d *memoryData) error {
+		ministries = d.ministriesWithDepartments(d.ministryIDs(r.asOf), r.asOf)
+		return nil
+	})
+	return ministries, err
+}
+
+// ministriesWithDepartments returns the ministries ids, each with its
+// departments ordered by id.
+func (d *memoryData) ministriesWithDepartments(ids []int, asOf *models.Date) []models.MinistryWithDepartments {
+	byMinistry := map[int][]models.Department{}
+	for _, dept := range d.departmentList(asOf) {
+		byMinistry[dept.MinistryID] = append(byMinistry[dept.MinistryID], dept)
+	}
+
+	ministries := []models.MinistryWithDepartments{}
+	for _, id := range ids {
+		if m, ok := d.ministry(id, asOf); ok {
+			ministries = append(ministries, models.MinistryWithDepartments{Ministry: m, Departments: byMinistry[id]})
+		}
+	}
+	return ministries
+}
+
+func (r *MemoryRepository) GetAllDepartments() ([]models.Department, error) {
+	var departments []models.Department
+	err := r.Store.read(func(d *memoryData) error {
+		departments = d.departmentList(r.asOf)
+		return nil
+	})
+	return departments, err
+}
+
+// GetMinistriesPage returns a page of ministries, each with all of its
+// departments.
+func (r *MemoryRepository) GetMinistriesPage(p models.PageRequest) (models.MinistryPage, error) {
+	page := models.MinistryPage{Limit: p.Limit, Items: []models.MinistryWithDepartments{}}
+	err := r.Store.read(func(d *memoryData) error {
+		ids := d.ministryIDs(r.asOf)
+		page.Total = len(ids)
+		var pageIDs []int
+		pageIDs, page.Offset = pageOfIDs(ids, p)
+		if len(pageIDs) > 0 {
+			page.Items = d.ministriesWithDepartments(pageIDs, r.asOf)
+		}
+		return nil
+	})
+	return page, err
+}
+
+// GetDepartmentsPage returns a page of departments.
+func (r *MemoryRepository) GetDepartmentsPage(p models.PageRequest) (models.DepartmentPage, error) {
+	page := models.DepartmentPage{Limit: p.Limit, Items: []models.Department{}}
+	err := r.Store.read(func(d *memoryData) error {
+		ids := d.departmentIDs(r.asOf)
+		page.Total = len(ids)
+		var pageIDs []int
+		pageIDs, page.Offset = pageOfIDs(ids, p)
+		for _, id := range pageIDs {
+			dept, _ := d.department(id, r.asOf)
+			page.Items = append(page.Items, dept)
+		}
+		return nil
+	})
+	return page, err
+}
+
+func (r *MemoryRepository) GetMinistryByID(id int) (models.Ministry, error) {
+	var ministry models.Ministry
+	err := r.Store.read(func(d *memoryData) error {
+		var ok bool
+		if ministry, ok = d.ministry(id, r.asOf); !ok {
+			return ErrNotFound
+		}
+		return nil
+	})
+	return ministry, err
+}
+
+func (r *MemoryRepository) GetMinistryByIDWithDepartments(id int) (models.MinistryWithDepartments, error) {
+	var ministry models.MinistryWithDepartments
+	err := r.Store.read(func(d *memoryData) error {
+		ministries := d.ministriesWithDepartments([]int{id}, r.asOf)
+		if len(ministries) == 0 {
+			return ErrNotFound
+		}
+		ministry = ministries[0]
+		return nil
+	})
+	return ministry, err
+}
+
+func (r *MemoryRepository) GetDepartmentByID(id int) (*models.Department, error) {
+	var dept *models.Department
+	err := r.Store.read(func(d *memoryData) error {
+		found, ok := d.department(id, r.asOf)
+		if !ok {
+			return nil
+		}
+		// Offices are not versioned, so dated reads leave them out.
+		if r.asOf == nil {
+			found.Offices = d.departmentOffices(id)
+		}
+		dept = &found
+		return nil
+	})
+	return dept, err
+}
+
+func (r *MemoryRepository) CreateMinistry(ministry models.Ministry) (int, error) {
+	var id int
+	err := r.Store.write(func(d *memoryData) (err error) {
+		id, err = d.createMinistry(ministry, models.Today())
+		return err
+	})
+	return id, err
+}
+
+func (d *memoryData) createMinistry(ministry models.Ministry, effective models.Date) (int, error) {
+	if ministry.ParentID != nil {
+		if _, ok := d.ministries[*ministry.ParentID]; !ok {
+			return 0, ErrInvalidReference
+		}
+	}
+	ministry.ID = d.nextID(seqMinistry)
+	d.saveMinistry(ministry)
+	return ministry.ID, d.recordMinistry(ministry.ID, effective)
+}
+
+func (r *MemoryRepository) CreateDepartment(dept models.Department) (int, error) {
+	var id int
+	err := r.Store.write(func(d *memoryData) (err error) {
+		id, err = d.createDepartment(dept, models.Today())
+		return err
+	})
+	return id, err
+}
+
+func (d *memoryData) createDepartment(dept models.Department, effective models.Date) (int, error) {
+	if err := d.checkDepartmentRefs(0, dept); err != nil {
+		return 0, err
+	}
+	dept.ID = d.nextID(seqDepartment)
+	d.saveDepartment(dept)
+	return dept.ID, d.recordDepartment(dept.ID, effective)
+}
+
+func (r *MemoryRepository) UpdateMinistry(ministry models.Ministry) error {
+	return r.Store.write(func(d *memoryData) error {
+		return d.updateMinistry(ministry, models.Today())
+	})
+}
+
+func (d *memoryData) updateMinistry(ministry models.Ministry, effective models.Date) error {
+	if ministry.ParentID != nil {
+		if err := d.checkMinistryParent(ministry.ID, *ministry.ParentID); err != nil {
+			return err
+		}
+	}
+	if _, ok := d.ministries[ministry.ID]; !ok {
+		return ErrNotFound
+	}
+	d.saveMinistry(ministry)
+	return d.recordMinistry(ministry.ID, effective)
+}
+
+// UpdateDepartment saves dept. When its ministry changes, the divisions and
+// units below it move to the new ministry as well.
+func (r *MemoryRepository) UpdateDepartment(dept models.Department) error {
+	return r.Store.write(func(d *memoryData) error {
+		return d.updateDepartment(dept, models.Today())
+	})
+}
+
+func (d *memoryData) updateDepartment(dept models.Department, effective models.Date) error {
+	if err := d.checkDepartmentRefs(dept.ID, dept); err != nil {
+		return err
+	}
+	if _, ok := d.departments[dept.ID]; !ok {
+		return ErrNotFound
+	}
+	d.saveDepartment(dept)
+	if err := d.recordDepartment(dept.ID, effective); err != nil {
+		return err
+	}
+
+	for _, id := range d.descendants(dept.ID) {
+		unit := d.departments[id]
+		if unit.MinistryID == dept.MinistryID {
+			continue
+		}
+		unit.MinistryID = dept.MinistryID
+		d.departments[id] = unit
+		if err := d.recordDepartment(id, effective); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveMinistry and saveDepartment store a copy of the record, with the
+// default kind when none is given.
+func (d *memoryData) saveMinistry(ministry models.Ministry) {
+	if ministry.Kind == "" {
+		ministry.Kind = models.KindMinistry
+	}
+	d.ministries[ministry.ID] = copyMinistry(ministry)
+}
+
+func (d *memoryData) saveDepartment(dept models.Department) {
+	if dept.Kind == "" {
+		dept.Kind = models.KindDepartment
+	}
+	d.departments[dept.ID] = copyDepartment(dept)
+}
+
+// DeleteMinistry removes a ministry. What happens to its departments is
+// decided by mode; reassignTo is only used with models.DeleteReassign.
+func (r *MemoryRepository) DeleteMinistry(id int, mode models.MinistryDeleteMode, reassignTo int) error {
+	return r.Store.write(func(d *memoryData) error {
+		return d.deleteMinistry(id, mode, reassignTo, models.Today())
+	})
+}
+
+func (d *memoryData) deleteMinistry(id int, mode models.MinistryDeleteMode, reassignTo int, effective models.Date) error {
+	if _, ok := d.ministries[id]; !ok {
+		return ErrNotFound
+	}
+
+	var departments []int
+	for _, deptID := range sortedIDs(d.departments) {
+		if d.departments[deptID].MinistryID == id {
+			departments = append(departments, deptID)
+		}
+	}
+
+	switch mode {
+	case models.DeleteCascade:
+		for _, deptID := range departments {
+			if err := d.departmentVersions.close(deptID, effective); err != nil {
+				return err
+			}
+			d.removeDepartment(deptID)
+		}
+	case models.DeleteReassign:
+		if _, ok := d.ministries[reassignTo]; !ok || reassignTo == id {
+			return ErrInvalidReassignTarget
+		}
+		for _, deptID := range departments {
+			dept := d.departments[deptID]
+			dept.MinistryID = reassignTo
+			d.departments[deptID] = dept
+			if err := d.recordDepartment(deptID, effective); err != nil {
+				return err
+			}
+		}
+	default:
+		if len(departments) > 0 {
+			return ErrMinistryHasDepartments
+		}
+	}
+
+	delete(d.ministries, id)
+	if err := d.ministryVersions.close(id, effective); err != nil {
+		return err
+	}
+	// Detach any state ministries, as the foreign key does in Postgres.
+	for _, subID := range sortedIDs(d.ministries) {
+		sub := d.ministries[subID]
+		if sub.ParentID == nil || *sub.ParentID != id {
+			continue
+		}
+		sub.ParentID = nil
+		d.ministries[subID] = sub
+		if err := d.recordMinistry(subID, effective); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemoryRepository) DeleteDepartment(id int) error {
+	return r.Store.write(func(d *memoryData) error {
+		return d.deleteDepartment(id, models.Today())
+	})
+}
+
+// deleteDepartment removes a department with its offices. Its divisions
+// become top-level departments of the ministry.
+func (d *memoryData) deleteDepartment(id int, effective models.Date) error {
+	if _, ok := d.departments[id]; !ok {
+		return ErrNotFound
+	}
+	d.removeDepartment(id)
+	if err := d.departmentVersions.close(id, effective); err != nil {
+		return err
+	}
+
+	for _, unitID := range sortedIDs(d.departments) {
+		unit := d.departments[unitID]
+		if unit.ParentID == nil || *unit.ParentID != id {
+			continue
+		}
+		unit.ParentID = nil
+		d.departments[unitID] = unit
+		if err := d.recordDepartment(unitID, effective); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeDepartment deletes a department and its offices.
+func (d *memoryData) removeDepartment(id int) {
+	delete(d.departments, id)
+	for officeID, office := range d.offices {
+		if office.DepartmentID == id {
+			delete(d.offices, officeID)
+		}
+	}
+}
+
+// FindDepartmentsNearby returns the departments within q.RadiusKm of the
+// query point, nearest first, using the haversine great-circle distance.
+func (r *MemoryRepository) FindDepartmentsNearby(q models.NearbyQuery) ([]models.NearbyDepartment, error) {
+	departments := []models.NearbyDepartment{}
+	err := r.Store.read(func(d *memoryData) error {
+		for _, dept := range d.departmentList(r.asOf) {
+			if !dept.HasCoordinates() {
+				continue
+			}
+			distance := geo.HaversineKm(q.Latitude, q.Longitude, *dept.Latitude, *dept.Longitude)
+			if distance <= q.RadiusKm {
+				departments = append(departments, models.NearbyDepartment{Department: dept, DistanceKm: distance})
+			}
+		}
+		return nil
+	})
+	// The list is in id order, so a stable sort breaks ties by id.
+	sort.SliceStable(departments, func(i, j int) bool { return departments[i].DistanceKm < departments[j].DistanceKm })
+	return firstN(departments, q.Limit), err
+}
+
+// GetDepartmentsInBBox returns up to limit departments located inside bbox,
+// ordered by id.
+func (r *MemoryRepository) GetDepartmentsInBBox(bbox models.BBox, limit int) ([]models.Department, error) {
+	departments := []models.Department{}
+	err := r.Store.read(func(d *memoryData) error {
+		for _, dept := range d.departmentList(r.asOf) {
+			if len(departments) < limit && dept.HasCoordinates() && bbox.Contains(*dept.Latitude, *dept.Longitude) {
+				departments = append(departments, dept)
+			}
+		}
+		return nil
+	})
+	return departments, err
+}
+
+// GetMinistriesInBBox returns up to limit ministries located inside bbox,
+// ordered by id.
+func (r *MemoryRepository) GetMinistriesInBBox(bbox models.BBox, limit int) ([]models.Ministry, error) {
+	ministries := []models.Ministry{}
+	err := r.Store.read(func(d *memoryData) error {
+		for _, m := range d.ministryList(r.asOf) {
+			if len(ministries) < limit && m.HasCoordinates() && bbox.Contains(*m.Latitude, *m.Longitude) {
+				ministries = append(ministries, m)
+			}
+		}
+		return nil
+	})
+	return ministries, err
+}
+
+// firstN returns the first n items of list, or all of them when there are
+// fewer.
+func firstN[T any](list []T, n int) []T {
+	return list[:max(min(n, len(list)), 0)]
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/search"
+	"go-mysql-backend/internal/translit"
+)
+
+// Weights of the searched fields, after the setweight classes of the
+// Postgres search columns: names (A), phonetic keys (B) and address (C).
+const (
+	searchWeightName    = 1.0
+	searchWeightKey     = 0.4
+	searchWeightAddress = 0.2
+)
+
+// searchCandidate is a record with the text a search looks at.
+type searchCandidate struct {
+	hit     models.SearchHit
+	order   int      // position of the entity in models.SearchEntities
+	names   []string // words of the name in every language
+	address []string // words of the address
+	keys    []string // phonetic keys of the names
+	text    string   // what the snippet is made from
+}
+
+// Search scans every ministry, department and office. A record matches
+// when each word of the query starts a word of its names or address, or
+// when each phonetic key of the query starts a key of its names; whole
+// words in the names score highest. Like the other backends it always
+// reads the current structure.
+func (r *MemoryRepository) Search(q models.SearchQuery) ([]models.SearchHit, error) {
+	terms := search.Terms(q.Text)
+	if len(terms) == 0 {
+		return []models.SearchHit{}, nil
+	}
+	keys := translit.Keys(q.Text)
+
+	var candidates []searchCandidate
+	err := r.Store.read(func(d *memoryData) error {
+		candidates = d.searchCandidates(q.Entities)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []searchCandidate
+	for _, c := range candidates {
+		score, ok := c.score(terms, keys)
+		if !ok {
+			continue
+		}
+		c.hit.Score = score
+		c.hit.Snippet = search.Highlight(c.text, terms)
+		matches = append(matches, c)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.hit.Score != b.hit.Score {
+			return a.hit.Score > b.hit.Score
+		}
+		if a.order != b.order {
+			return a.order < b.order
+		}
+		return a.hit.ID < b.hit.ID
+	})
+
+	hits := []models.SearchHit{}
+	for _, c := range matches {
+		hits = append(hits, c.hit)
+	}
+	return firstN(hits, q.Limit), nil
+}
+
+// searchCandidates returns the records of the given entities.
+func (d *memoryData) searchCandidates(entities []models.SearchEntity) []searchCandidate {
+	var candidates []searchCandidate
+	for order, entity := range models.SearchEntities {
+		wanted := false
+		for _, e := range entities {
+			wanted = wanted || e == entity
+		}
+		if !wanted {
+			continue
+		}
+
+		switch entity {
+		case models.SearchMinistry:
+			for _, m := range d.ministryList(nil) {
+				candidates = append(candidates, newSearchCandidate(
+					models.SearchHit{Entity: entity, ID: m.ID, Name: m.Name, Names: m.Names}, order, m.Names, m.Address))
+			}
+		case models.SearchDepartment:
+			for _, dept := range d.departmentList(nil) {
+				parentID := dept.MinistryID
+				candidates = append(candidates, newSearchCandidate(
+					models.SearchHit{Entity: entity, ID: dept.ID, Name: dept.Name, Names: dept.Names, ParentID: &parentID},
+					order, dept.Names, dept.Address))
+			}
+		case models.SearchOffice:
+			for _, id := range sortedIDs(d.offices) {
+				office := d.offices[id]
+				parentID := office.DepartmentID
+				c := newSearchCandidate(
+					models.SearchHit{Entity: entity, ID: office.ID, Name: office.Name, ParentID: &parentID}, order, nil, office.Address)
+				// Offices have no phonetic key, as in the other backends.
+				c.keys = nil
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	return candidates
+}
+
+func newSearchCandidate(hit models.SearchHit, order int, names models.LocalizedNames, address string) searchCandidate {
+	text := []string{hit.Name}
+	for _, name := range []string{names[models.LocaleSinhala], names[models.LocaleTamil], address} {
+		if name != "" {
+			text = append(text, name)
+		}
+	}
+	allNames := strings.Join([]string{hit.Name, names[models.LocaleSinhala], names[models.LocaleTamil]}, " ")
+	return searchCandidate{
+		hit:     hit,
+		order:   order,
+		names:   search.Terms(allNames),
+		address: search.Terms(address),
+		keys:    translit.Keys(allNames),
+		text:    strings.Join(text, " · "),
+	}
+}
+
+// score returns the mean weight of the query words over the fields they
+// match, halved for prefix matches, and whether the record matches at all.
+func (c searchCandidate) score(terms, keys []string) (float64, bool) {
+	total := 0.0
+	for _, term := range terms {
+		weight := max(matchWeight(term, c.names, searchWeightName), matchWeight(term, c.address, searchWeightAddress))
+		if weight == 0 {
+			total = 0
+			break
+		}
+		total += weight
+	}
+	if total > 0 {
+		return total / float64(len(terms)), true
+	}
+
+	if len(keys) == 0 || len(c.keys) == 0 {
+		return 0, false
+	}
+	for _, key := range keys {
+		weight := matchWeight(key, c.keys, searchWeightKey)
+		if weight == 0 {
+			return 0, false
+		}
+		total += weight
+	}
+	return total / float64(len(keys)), true
+}
+
+// matchWeight returns weight if term is one of words, half of it if term
+// only starts one, and 0 otherwise.
+func matchWeight(term string, words []string, weight float64) float64 {
+	best := 0.0
+	for _, word := range words {
+		switch {
+		case word == term:
+			return weight
+		case strings.HasPrefix(word, term):
+			best = weight / 2
+		}
+	}
+	return best
+}
@@ -0,0 +1,445 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+
+	"go-mysql-backend/internal/models"
+)
+
+// MemoryStore holds the data of the in-memory backend: organisations and
+// their history, offices, administrative areas and the people holding
+// positions. The memory repositories share one store the way the Postgres
+// ones share a *sql.DB. It is safe for concurrent use. A write works on a
+// copy of the data that replaces it only when the write succeeds, so a
+// failed write, like a rolled back transaction, leaves nothing behind.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	data *memoryData
+}
+
+// memoryData is the content of a store. Records are values that are
+// replaced rather than changed in place, so copying the maps is enough to
+// copy the data; callers get deep copies so they cannot change it either.
+type memoryData struct {
+	ministries         map[int]models.Ministry
+	departments        map[int]models.Department
+	offices            map[int]models.Office
+	ministryVersions   memoryHistory
+	departmentVersions memoryHistory
+	gazetteChanges     []models.ChangeOp
+	areas              map[int]models.AdminArea
+	people             map[int]models.Person
+	positions          map[int]models.Position
+	// sequences holds the last id given out for each kind of record, so
+	// ids are not reused after a delete.
+	sequences map[string]int
+}
+
+// memoryFile is the layout of fixture and snapshot files. Every record
+// needs its id. Offices may be listed on their own or nested in their
+// department. History is opened on load, effective today, for records
+// the file gives none for.
+type memoryFile struct {
+	Ministries         []models.Ministry   `json:"ministries"`
+	Departments        []models.Department `json:"departments"`
+	Offices            []models.Office     `json:"offices,omitempty"`
+	MinistryVersions   []memoryVersion     `json:"ministry_versions,omitempty"`
+	DepartmentVersions []memoryVersion     `json:"department_versions,omitempty"`
+	GazetteChanges     []models.ChangeOp   `json:"gazette_changes,omitempty"`
+	AdminAreas         []models.AdminArea  `json:"admin_areas,omitempty"`
+	People             []models.Person     `json:"people,omitempty"`
+	Positions          []models.Position   `json:"positions,omitempty"`
+	Sequences          map[string]int      `json:"sequences,omitempty"`
+}
+
+// Sequence names, also used in the errors of LoadMemoryStore.
+const (
+	seqMinistry   = "ministry"
+	seqDepartment = "department"
+	seqOffice     = "office"
+	seqArea       = "admin_area"
+	seqPerson     = "person"
+	seqPosition   = "position"
+)
+
+// NewMemoryStore returns an empty store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: newMemoryData()}
+}
+
+func newMemoryData() *memoryData {
+	return &memoryData{
+		ministries:         map[int]models.Ministry{},
+		departments:        map[int]models.Department{},
+		offices:            map[int]models.Office{},
+		ministryVersions:   memoryHistory{label: "ministry", versions: map[int][]memoryVersion{}},
+		departmentVersions: memoryHistory{label: "department", versions: map[int][]memoryVersion{}},
+		areas:              map[int]models.AdminArea{},
+		people:             map[int]models.Person{},
+		positions:          map[int]models.Position{},
+		sequences:          map[string]int{},
+	}
+}
+
+// LoadMemoryStore returns a store holding the fixture or snapshot at path,
+// or an empty store when there is no file there yet. References between
+// the records are checked; a dangling one is ErrInvalidReference.
+func LoadMemoryStore(path string) (*MemoryStore, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewMemoryStore(), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var file memoryFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	data, err := file.data(models.Today())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &MemoryStore{data: data}, nil
+}
+
+// Save writes a snapshot of the store to path, which LoadMemoryStore reads
+// back. The file is replaced in one step, so a crash never leaves half a
+// snapshot.
+func (s *MemoryStore) Save(path string) error {
+	s.mu.RLock()
+	content, err := json.MarshalIndent(s.data.file(), "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(content, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// read runs fn on the data under the read lock.
+func (s *MemoryStore) read(fn func(d *memoryData) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(s.data)
+}
+
+// write runs fn on a copy of the data and keeps the copy if fn succeeds.
+func (s *MemoryStore) write(fn func(d *memoryData) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := s.data.clone()
+	if err := fn(data); err != nil {
+		return err
+	}
+	s.data = data
+	return nil
+}
+
+func (d *memoryData) clone() *memoryData {
+	return &memoryData{
+		ministries:         maps.Clone(d.ministries),
+		departments:        maps.Clone(d.departments),
+		offices:            maps.Clone(d.offices),
+		ministryVersions:   d.ministryVersions.clone(),
+		departmentVersions: d.departmentVersions.clone(),
+		gazetteChanges:     slices.Clone(d.gazetteChanges),
+		areas:              maps.Clone(d.areas),
+		people:             maps.Clone(d.people),
+		positions:          maps.Clone(d.positions),
+		sequences:          maps.Clone(d.sequences),
+	}
+}
+
+// nextID returns the next id of the sequence name.
+func (d *memoryData) nextID(name string) int {
+	d.sequences[name]++
+	return d.sequences[name]
+}
+
+// sortedIDs returns the keys of m in ascending order.
+func sortedIDs[T any](m map[int]T) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// file returns the data in the layout of a snapshot file.
+func (d *memoryData) file() memoryFile {
+	file := memoryFile{
+		Ministries:         []models.Ministry{},
+		Departments:        []models.Department{},
+		MinistryVersions:   d.ministryVersions.list(),
+		DepartmentVersions: d.departmentVersions.list(),
+		GazetteChanges:     d.gazetteChanges,
+		Sequences:          d.sequences,
+	}
+	for _, id := range sortedIDs(d.ministries) {
+		file.Ministries = append(file.Ministries, d.ministries[id])
+	}
+	for _, id := range sortedIDs(d.departments) {
+		file.Departments = append(file.Departments, d.departments[id])
+	}
+	for _, id := range sortedIDs(d.offices) {
+		file.Offices = append(file.Offices, d.offices[id])
+	}
+	for _, id := range sortedIDs(d.areas) {
+		area := d.areas[id]
+		area.ParentCode = ""
+		file.AdminAreas = append(file.AdminAreas, area)
+	}
+	for _, id := range sortedIDs(d.people) {
+		file.People = append(file.People, d.people[id])
+	}
+	for _, id := range sortedIDs(d.positions) {
+		file.Positions = append(file.Positions, d.positions[id])
+	}
+	return file
+}
+
+// data checks the records of a file and returns them as store data.
+// Versions are opened on effective for records that have no open one.
+func (f memoryFile) data(effective models.Date) (*memoryData, error) {
+	d := newMemoryData()
+	for _, m := range f.Ministries {
+		if m.Kind == "" {
+			m.Kind = models.KindMinistry
+		}
+		if err := addRecord(d.ministries, seqMinistry, m.ID, copyMinistry(m)); err != nil {
+			return nil, err
+		}
+	}
+	for _, dept := range f.Departments {
+		for _, office := range dept.Offices {
+			office.DepartmentID = dept.ID
+			f.Offices = append(f.Offices, office)
+		}
+		dept.Offices = nil
+		if dept.Kind == "" {
+			dept.Kind = models.KindDepartment
+		}
+		if err := addRecord(d.departments, seqDepartment, dept.ID, copyDepartment(dept)); err != nil {
+			return nil, err
+		}
+	}
+	for _, office := range f.Offices {
+		office.OpenNow = nil
+		if err := addRecord(d.offices, seqOffice, office.ID, copyOffice(office)); err != nil {
+			return nil, err
+		}
+	}
+	for _, area := range f.AdminAreas {
+		if err := addRecord(d.areas, seqArea, area.ID, area); err != nil {
+			return nil, err
+		}
+	}
+	for _, person := range f.People {
+		if err := addRecord(d.people, seqPerson, person.ID, person); err != nil {
+			return nil, err
+		}
+	}
+	for _, position := range f.Positions {
+		position.PersonName, position.OrganizationName = "", ""
+		if err := addRecord(d.positions, seqPosition, position.ID, position); err != nil {
+			return nil, err
+		}
+	}
+	if err := d.resolveAreaParents(); err != nil {
+		return nil, err
+	}
+	if err := d.checkReferences(); err != nil {
+		return nil, err
+	}
+
+	for _, v := range f.MinistryVersions {
+		d.ministryVersions.versions[v.ID] = append(d.ministryVersions.versions[v.ID], v)
+	}
+	for _, v := range f.DepartmentVersions {
+		d.departmentVersions.versions[v.ID] = append(d.departmentVersions.versions[v.ID], v)
+	}
+	d.ministryVersions.sort()
+	d.departmentVersions.sort()
+	for _, id := range sortedIDs(d.ministries) {
+		if _, open := d.ministryVersions.open(id); !open {
+			if err := d.recordMinistry(id, effective); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, id := range sortedIDs(d.departments) {
+		if _, open := d.departmentVersions.open(id); !open {
+			if err := d.recordDepartment(id, effective); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	d.gazetteChanges = f.GazetteChanges
+	for name, ids := range map[string][]int{
+		seqMinistry:   sortedIDs(d.ministries),
+		seqDepartment: sortedIDs(d.departments),
+		seqOffice:     sortedIDs(d.offices),
+		seqArea:       sortedIDs(d.areas),
+		seqPerson:     sortedIDs(d.people),
+		seqPosition:   sortedIDs(d.positions),
+	} {
+		if len(ids) > 0 {
+			d.sequences[name] = ids[len(ids)-1]
+		}
+	}
+	// A snapshot keeps the sequences past deleted records.
+	for name, last := range f.Sequences {
+		d.sequences[name] = max(d.sequences[name], last)
+	}
+	return d, nil
+}
+
+// addRecord adds a record read from a file to m. name is the sequence of
+// the record, which also names it in errors.
+func addRecord[T any](m map[int]T, name string, id int, record T) error {
+	if id <= 0 {
+		return fmt.Errorf("every %s needs a positive id", name)
+	}
+	if _, dup := m[id]; dup {
+		return fmt.Errorf("%s %d is listed twice", name, id)
+	}
+	m[id] = record
+	return nil
+}
+
+// resolveAreaParents sets the parent of areas that give a parent code
+// instead of a parent id.
+func (d *memoryData) resolveAreaParents() error {
+	for id, area := range d.areas {
+		if area.ParentID != nil || area.ParentCode == "" {
+			continue
+		}
+		parent, ok := d.areaByCode(area.Level.Parent(), area.ParentCode)
+		if !ok {
+			return fmt.Errorf("%w: %s %q has unknown parent %q", ErrInvalidReference, area.Level, area.Code, area.ParentCode)
+		}
+		area.ParentID = &parent.ID
+		d.areas[id] = area
+	}
+	return nil
+}
+
+// checkReferences reports the first record of a file that refers to a
+// record it does not hold.
+func (d *memoryData) checkReferences() error {
+	for _, id := range sortedIDs(d.ministries) {
+		if parentID := d.ministries[id].ParentID; parentID != nil {
+			if _, ok := d.ministries[*parentID]; !ok {
+				return fmt.Errorf("%w: ministry %d has unknown parent %d", ErrInvalidReference, id, *parentID)
+			}
+		}
+	}
+	for _, id := range sortedIDs(d.departments) {
+		dept := d.departments[id]
+		if _, ok := d.ministries[dept.MinistryID]; !ok {
+			return fmt.Errorf("%w: department %d has unknown ministry %d", ErrInvalidReference, id, dept.MinistryID)
+		}
+		if dept.ParentID != nil {
+			parent, ok := d.departments[*dept.ParentID]
+			if !ok {
+				return fmt.Errorf("%w: department %d has unknown parent %d", ErrInvalidReference, id, *dept.ParentID)
+			}
+			if parent.MinistryID != dept.MinistryID {
+				return fmt.Errorf("%w: department %d is in another ministry than its parent %d", ErrInvalidParent, id, parent.ID)
+			}
+		}
+		if dept.AdminAreaID != nil {
+			if _, ok := d.areas[*dept.AdminAreaID]; !ok {
+				return fmt.Errorf("%w: department %d has unknown area %d", ErrInvalidReference, id, *dept.AdminAreaID)
+			}
+		}
+	}
+	for _, id := range sortedIDs(d.offices) {
+		if deptID := d.offices[id].DepartmentID; !d.hasDepartment(deptID) {
+			return fmt.Errorf("%w: office %d has unknown department %d", ErrInvalidReference, id, deptID)
+		}
+	}
+	for _, id := range sortedIDs(d.positions) {
+		if personID := d.positions[id].PersonID; !d.hasPerson(personID) {
+			return fmt.Errorf("%w: position %d has unknown person %d", ErrInvalidReference, id, personID)
+		}
+	}
+	return nil
+}
+
+func (d *memoryData) hasDepartment(id int) bool {
+	_, ok := d.departments[id]
+	return ok
+}
+
+func (d *memoryData) hasPerson(id int) bool {
+	_, ok := d.people[id]
+	return ok
+}
+
+// copyMinistry, copyDepartment and copyOffice return deep copies, so that
+// neither the store nor its callers see changes made by the other.
+func copyMinistry(m models.Ministry) models.Ministry {
+	m.Names = maps.Clone(m.Names)
+	m.ParentID = copyPtr(m.ParentID)
+	m.Location = copyLocation(m.Location)
+	return m
+}
+
+func copyDepartment(dept models.Department) models.Department {
+	dept.Names = maps.Clone(dept.Names)
+	dept.ParentID = copyPtr(dept.ParentID)
+	dept.AdminAreaID = copyPtr(dept.AdminAreaID)
+	dept.Location = copyLocation(dept.Location)
+	dept.Offices = nil
+	return dept
+}
+
+func copyOffice(office models.Office) models.Office {
+	office.PhoneNumbers = slices.Clone(office.PhoneNumbers)
+	office.Hours = slices.Clone(office.Hours)
+	office.Closures = slices.Clone(office.Closures)
+	office.OpenNow = copyPtr(office.OpenNow)
+	office.Location = copyLocation(office.Location)
+	return office
+}
+
+func copyLocation(loc models.Location) models.Location {
+	loc.Latitude = copyPtr(loc.Latitude)
+	loc.Longitude = copyPtr(loc.Longitude)
+	loc.Geometry = slices.Clone(loc.Geometry)
+	return loc
+}
+
+func copyPtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
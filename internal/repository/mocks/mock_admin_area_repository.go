@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/admin_area_repo_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/admin_area_repo_interface.go -destination=internal/repository/mocks/mock_admin_area_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "go-mysql-backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAdminAreaRepo is a mock of AdminAreaRepo interface.
+type MockAdminAreaRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminAreaRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockAdminAreaRepoMockRecorder is the mock recorder for MockAdminAreaRepo.
+type MockAdminAreaRepoMockRecorder struct {
+	mock *MockAdminAreaRepo
+}
+
+// NewMockAdminAreaRepo creates a new mock instance.
+func NewMockAdminAreaRepo(ctrl *gomock.Controller) *MockAdminAreaRepo {
+	mock := &MockAdminAreaRepo{ctrl: ctrl}
+	mock.recorder = &MockAdminAreaRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminAreaRepo) EXPECT() *MockAdminAreaRepoMockRecorder {
+	return m.recorder
+}
+
+// GetAreaBoundaries mocks base method.
+func (m *MockAdminAreaRepo) GetAreaBoundaries() ([]models.AdminArea, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAreaBoundaries")
+	ret0, _ := ret[0].([]models.AdminArea)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAreaBoundaries indicates an expected call of GetAreaBoundaries.
+func (mr *MockAdminAreaRepoMockRecorder) GetAreaBoundaries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAreaBoundaries", reflect.TypeOf((*MockAdminAreaRepo)(nil).GetAreaBoundaries))
+}
+
+// GetAreaByID mocks base method.
+func (m *MockAdminAreaRepo) GetAreaByID(id int) (*models.AdminArea, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAreaByID", id)
+	ret0, _ := ret[0].(*models.AdminArea)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAreaByID indicates an expected call of GetAreaByID.
+func (mr *MockAdminAreaRepoMockRecorder) GetAreaByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAreaByID", reflect.TypeOf((*MockAdminAreaRepo)(nil).GetAreaByID), id)
+}
+
+// GetAreaDepartments mocks base method.
+func (m *MockAdminAreaRepo) GetAreaDepartments(id int) ([]models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAreaDepartments", id)
+	ret0, _ := ret[0].([]models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAreaDepartments indicates an expected call of GetAreaDepartments.
+func (mr *MockAdminAreaRepoMockRecorder) GetAreaDepartments(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAreaDepartments", reflect.TypeOf((*MockAdminAreaRepo)(nil).GetAreaDepartments), id)
+}
+
+// GetAreas mocks base method.
+func (m *MockAdminAreaRepo) GetAreas(level models.AdminLevel) ([]models.AdminArea, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAreas", level)
+	ret0, _ := ret[0].([]models.AdminArea)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAreas indicates an expected call of GetAreas.
+func (mr *MockAdminAreaRepoMockRecorder) GetAreas(level any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAreas", reflect.TypeOf((*MockAdminAreaRepo)(nil).GetAreas), level)
+}
+
+// GetChildAreas mocks base method.
+func (m *MockAdminAreaRepo) GetChildAreas(id int) ([]models.AdminArea, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildAreas", id)
+	ret0, _ := ret[0].([]models.AdminArea)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildAreas indicates an expected call of GetChildAreas.
+func (mr *MockAdminAreaRepoMockRecorder) GetChildAreas(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildAreas", reflect.TypeOf((*MockAdminAreaRepo)(nil).GetChildAreas), id)
+}
+
+// SaveAreas mocks base method.
+func (m *MockAdminAreaRepo) SaveAreas(areas []models.AdminArea) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAreas", areas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAreas indicates an expected call of SaveAreas.
+func (mr *MockAdminAreaRepoMockRecorder) SaveAreas(areas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAreas", reflect.TypeOf((*MockAdminAreaRepo)(nil).SaveAreas), areas)
+}
+
+// SetDepartmentArea mocks base method.
+func (m *MockAdminAreaRepo) SetDepartmentArea(departmentID int, areaID *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDepartmentArea", departmentID, areaID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDepartmentArea indicates an expected call of SetDepartmentArea.
+func (mr *MockAdminAreaRepoMockRecorder) SetDepartmentArea(departmentID, areaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDepartmentArea", reflect.TypeOf((*MockAdminAreaRepo)(nil).SetDepartmentArea), departmentID, areaID)
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/position_repo_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/position_repo_interface.go -destination=internal/repository/mocks/mock_position_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "go-mysql-backend/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPositionRepo is a mock of PositionRepo interface.
+type MockPositionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPositionRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockPositionRepoMockRecorder is the mock recorder for MockPositionRepo.
+type MockPositionRepoMockRecorder struct {
+	mock *MockPositionRepo
+}
+
+// NewMockPositionRepo creates a new mock instance.
+func NewMockPositionRepo(ctrl *gomock.Controller) *MockPositionRepo {
+	mock := &MockPositionRepo{ctrl: ctrl}
+	mock.recorder = &MockPositionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPositionRepo) EXPECT() *MockPositionRepoMockRecorder {
+	return m.recorder
+}
+
+// CreatePerson mocks base method.
+func (m *MockPositionRepo) CreatePerson(person models.Person) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePerson", person)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePerson indicates an expected call of CreatePerson.
+func (mr *MockPositionRepoMockRecorder) CreatePerson(person any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePerson", reflect.TypeOf((*MockPositionRepo)(nil).CreatePerson), person)
+}
+
+// CreatePosition mocks base method.
+func (m *MockPositionRepo) CreatePosition(position models.Position) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePosition", position)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePosition indicates an expected call of CreatePosition.
+func (mr *MockPositionRepoMockRecorder) CreatePosition(position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePosition", reflect.TypeOf((*MockPositionRepo)(nil).CreatePosition), position)
+}
+
+// EndPosition mocks base method.
+func (m *MockPositionRepo) EndPosition(id int, endDate models.Date) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndPosition", id, endDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndPosition indicates an expected call of EndPosition.
+func (mr *MockPositionRepoMockRecorder) EndPosition(id, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndPosition", reflect.TypeOf((*MockPositionRepo)(nil).EndPosition), id, endDate)
+}
+
+// GetOfficeHolders mocks base method.
+func (m *MockPositionRepo) GetOfficeHolders(org models.OrgRef, role models.PositionRole, date models.Date) ([]models.Position, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOfficeHolders", org, role, date)
+	ret0, _ := ret[0].([]models.Position)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOfficeHolders indicates an expected call of GetOfficeHolders.
+func (mr *MockPositionRepoMockRecorder) GetOfficeHolders(org, role, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOfficeHolders", reflect.TypeOf((*MockPositionRepo)(nil).GetOfficeHolders), org, role, date)
+}
+
+// GetPersonByID mocks base method.
+func (m *MockPositionRepo) GetPersonByID(id int) (*models.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPersonByID", id)
+	ret0, _ := ret[0].(*models.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPersonByID indicates an expected call of GetPersonByID.
+func (mr *MockPositionRepoMockRecorder) GetPersonByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPersonByID", reflect.TypeOf((*MockPositionRepo)(nil).GetPersonByID), id)
+}
+
+// GetPersonPositions mocks base method.
+func (m *MockPositionRepo) GetPersonPositions(personID int) ([]models.Position, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPersonPositions", personID)
+	ret0, _ := ret[0].([]models.Position)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPersonPositions indicates an expected call of GetPersonPositions.
+func (mr *MockPositionRepoMockRecorder) GetPersonPositions(personID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPersonPositions", reflect.TypeOf((*MockPositionRepo)(nil).GetPersonPositions), personID)
+}
+
+// GetPositionHistory mocks base method.
+func (m *MockPositionRepo) GetPositionHistory(org models.OrgRef, role models.PositionRole) ([]models.Position, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPositionHistory", org, role)
+	ret0, _ := ret[0].([]models.Position)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPositionHistory indicates an expected call of GetPositionHistory.
+func (mr *MockPositionRepoMockRecorder) GetPositionHistory(org, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPositionHistory", reflect.TypeOf((*MockPositionRepo)(nil).GetPositionHistory), org, role)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/repository/neo4j_repo_interface.go
+// Source: internal/repository/organization_repo_interface.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/repository/neo4j_repo_interface.go -destination=internal/repository/mocks/mock_repository.go -package=mocks
+//	mockgen -source=internal/repository/organization_repo_interface.go -destination=internal/repository/mocks/mock_repository.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -11,37 +11,289 @@ package mocks
 
 import (
 	models "go-mysql-backend/internal/models"
+	repository "go-mysql-backend/internal/repository"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
 )
 
-// MockNeo4jRepo is a mock of Neo4jRepo interface.
-type MockNeo4jRepo struct {
+// MockOrganizationRepo is a mock of OrganizationRepo interface.
+type MockOrganizationRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockNeo4jRepoMockRecorder
+	recorder *MockOrganizationRepoMockRecorder
 	isgomock struct{}
 }
 
-// MockNeo4jRepoMockRecorder is the mock recorder for MockNeo4jRepo.
-type MockNeo4jRepoMockRecorder struct {
-	mock *MockNeo4jRepo
+// MockOrganizationRepoMockRecorder is the mock recorder for MockOrganizationRepo.
+type MockOrganizationRepoMockRecorder struct {
+	mock *MockOrganizationRepo
 }
 
-// NewMockNeo4jRepo creates a new mock instance.
-func NewMockNeo4jRepo(ctrl *gomock.Controller) *MockNeo4jRepo {
-	mock := &MockNeo4jRepo{ctrl: ctrl}
-	mock.recorder = &MockNeo4jRepoMockRecorder{mock}
+// NewMockOrganizationRepo creates a new mock instance.
+func NewMockOrganizationRepo(ctrl *gomock.Controller) *MockOrganizationRepo {
+	mock := &MockOrganizationRepo{ctrl: ctrl}
+	mock.recorder = &MockOrganizationRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockNeo4jRepo) EXPECT() *MockNeo4jRepoMockRecorder {
+func (m *MockOrganizationRepo) EXPECT() *MockOrganizationRepoMockRecorder {
 	return m.recorder
 }
 
+// ApplyChangeSet mocks base method.
+func (m *MockOrganizationRepo) ApplyChangeSet(cs models.ChangeSet) (models.ChangeSetResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyChangeSet", cs)
+	ret0, _ := ret[0].(models.ChangeSetResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyChangeSet indicates an expected call of ApplyChangeSet.
+func (mr *MockOrganizationRepoMockRecorder) ApplyChangeSet(cs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyChangeSet", reflect.TypeOf((*MockOrganizationRepo)(nil).ApplyChangeSet), cs)
+}
+
+// AsOf mocks base method.
+func (m *MockOrganizationRepo) AsOf(date models.Date) repository.OrganizationRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AsOf", date)
+	ret0, _ := ret[0].(repository.OrganizationRepo)
+	return ret0
+}
+
+// AsOf indicates an expected call of AsOf.
+func (mr *MockOrganizationRepoMockRecorder) AsOf(date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AsOf", reflect.TypeOf((*MockOrganizationRepo)(nil).AsOf), date)
+}
+
+// CreateDepartment mocks base method.
+func (m *MockOrganizationRepo) CreateDepartment(dept models.Department) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDepartment", dept)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDepartment indicates an expected call of CreateDepartment.
+func (mr *MockOrganizationRepoMockRecorder) CreateDepartment(dept any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDepartment", reflect.TypeOf((*MockOrganizationRepo)(nil).CreateDepartment), dept)
+}
+
+// CreateMinistry mocks base method.
+func (m *MockOrganizationRepo) CreateMinistry(ministry models.Ministry) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMinistry", ministry)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMinistry indicates an expected call of CreateMinistry.
+func (mr *MockOrganizationRepoMockRecorder) CreateMinistry(ministry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMinistry", reflect.TypeOf((*MockOrganizationRepo)(nil).CreateMinistry), ministry)
+}
+
+// CreateOffice mocks base method.
+func (m *MockOrganizationRepo) CreateOffice(office models.Office) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOffice", office)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOffice indicates an expected call of CreateOffice.
+func (mr *MockOrganizationRepoMockRecorder) CreateOffice(office any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOffice", reflect.TypeOf((*MockOrganizationRepo)(nil).CreateOffice), office)
+}
+
+// DeleteDepartment mocks base method.
+func (m *MockOrganizationRepo) DeleteDepartment(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDepartment", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDepartment indicates an expected call of DeleteDepartment.
+func (mr *MockOrganizationRepoMockRecorder) DeleteDepartment(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDepartment", reflect.TypeOf((*MockOrganizationRepo)(nil).DeleteDepartment), id)
+}
+
+// DeleteMinistry mocks base method.
+func (m *MockOrganizationRepo) DeleteMinistry(id int, mode models.MinistryDeleteMode, reassignTo int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMinistry", id, mode, reassignTo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMinistry indicates an expected call of DeleteMinistry.
+func (mr *MockOrganizationRepoMockRecorder) DeleteMinistry(id, mode, reassignTo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMinistry", reflect.TypeOf((*MockOrganizationRepo)(nil).DeleteMinistry), id, mode, reassignTo)
+}
+
+// DeleteOffice mocks base method.
+func (m *MockOrganizationRepo) DeleteOffice(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOffice", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOffice indicates an expected call of DeleteOffice.
+func (mr *MockOrganizationRepoMockRecorder) DeleteOffice(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOffice", reflect.TypeOf((*MockOrganizationRepo)(nil).DeleteOffice), id)
+}
+
+// FindDepartmentsNearby mocks base method.
+func (m *MockOrganizationRepo) FindDepartmentsNearby(q models.NearbyQuery) ([]models.NearbyDepartment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDepartmentsNearby", q)
+	ret0, _ := ret[0].([]models.NearbyDepartment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDepartmentsNearby indicates an expected call of FindDepartmentsNearby.
+func (mr *MockOrganizationRepoMockRecorder) FindDepartmentsNearby(q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDepartmentsNearby", reflect.TypeOf((*MockOrganizationRepo)(nil).FindDepartmentsNearby), q)
+}
+
+// FindOfficesNearby mocks base method.
+func (m *MockOrganizationRepo) FindOfficesNearby(q models.NearbyQuery) ([]models.NearbyOffice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOfficesNearby", q)
+	ret0, _ := ret[0].([]models.NearbyOffice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOfficesNearby indicates an expected call of FindOfficesNearby.
+func (mr *MockOrganizationRepoMockRecorder) FindOfficesNearby(q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOfficesNearby", reflect.TypeOf((*MockOrganizationRepo)(nil).FindOfficesNearby), q)
+}
+
+// GetAllDepartments mocks base method.
+func (m *MockOrganizationRepo) GetAllDepartments() ([]models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllDepartments")
+	ret0, _ := ret[0].([]models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllDepartments indicates an expected call of GetAllDepartments.
+func (mr *MockOrganizationRepoMockRecorder) GetAllDepartments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllDepartments", reflect.TypeOf((*MockOrganizationRepo)(nil).GetAllDepartments))
+}
+
+// GetDepartmentByID mocks base method.
+func (m *MockOrganizationRepo) GetDepartmentByID(id int) (*models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentByID", id)
+	ret0, _ := ret[0].(*models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentByID indicates an expected call of GetDepartmentByID.
+func (mr *MockOrganizationRepoMockRecorder) GetDepartmentByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentByID", reflect.TypeOf((*MockOrganizationRepo)(nil).GetDepartmentByID), id)
+}
+
+// GetDepartmentOffices mocks base method.
+func (m *MockOrganizationRepo) GetDepartmentOffices(departmentID int) ([]models.Office, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentOffices", departmentID)
+	ret0, _ := ret[0].([]models.Office)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentOffices indicates an expected call of GetDepartmentOffices.
+func (mr *MockOrganizationRepoMockRecorder) GetDepartmentOffices(departmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentOffices", reflect.TypeOf((*MockOrganizationRepo)(nil).GetDepartmentOffices), departmentID)
+}
+
+// GetDepartmentsInBBox mocks base method.
+func (m *MockOrganizationRepo) GetDepartmentsInBBox(bbox models.BBox, limit int) ([]models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentsInBBox", bbox, limit)
+	ret0, _ := ret[0].([]models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentsInBBox indicates an expected call of GetDepartmentsInBBox.
+func (mr *MockOrganizationRepoMockRecorder) GetDepartmentsInBBox(bbox, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentsInBBox", reflect.TypeOf((*MockOrganizationRepo)(nil).GetDepartmentsInBBox), bbox, limit)
+}
+
+// GetDepartmentsPage mocks base method.
+func (m *MockOrganizationRepo) GetDepartmentsPage(p models.PageRequest) (models.DepartmentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepartmentsPage", p)
+	ret0, _ := ret[0].(models.DepartmentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepartmentsPage indicates an expected call of GetDepartmentsPage.
+func (mr *MockOrganizationRepoMockRecorder) GetDepartmentsPage(p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentsPage", reflect.TypeOf((*MockOrganizationRepo)(nil).GetDepartmentsPage), p)
+}
+
+// GetMinistriesInBBox mocks base method.
+func (m *MockOrganizationRepo) GetMinistriesInBBox(bbox models.BBox, limit int) ([]models.Ministry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMinistriesInBBox", bbox, limit)
+	ret0, _ := ret[0].([]models.Ministry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMinistriesInBBox indicates an expected call of GetMinistriesInBBox.
+func (mr *MockOrganizationRepoMockRecorder) GetMinistriesInBBox(bbox, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinistriesInBBox", reflect.TypeOf((*MockOrganizationRepo)(nil).GetMinistriesInBBox), bbox, limit)
+}
+
+// GetMinistriesPage mocks base method.
+func (m *MockOrganizationRepo) GetMinistriesPage(p models.PageRequest) (models.MinistryPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMinistriesPage", p)
+	ret0, _ := ret[0].(models.MinistryPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMinistriesPage indicates an expected call of GetMinistriesPage.
+func (mr *MockOrganizationRepoMockRecorder) GetMinistriesPage(p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinistriesPage", reflect.TypeOf((*MockOrganizationRepo)(nil).GetMinistriesPage), p)
+}
+
 // GetMinistriesWithDepartments mocks base method.
-func (m *MockNeo4jRepo) GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error) {
+func (m *MockOrganizationRepo) GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMinistriesWithDepartments")
 	ret0, _ := ret[0].([]models.MinistryWithDepartments)
@@ -50,13 +302,28 @@ func (m *MockNeo4jRepo) GetMinistriesWithDepartments() ([]models.MinistryWithDep
 }
 
 // GetMinistriesWithDepartments indicates an expected call of GetMinistriesWithDepartments.
-func (mr *MockNeo4jRepoMockRecorder) GetMinistriesWithDepartments() *gomock.Call {
+func (mr *MockOrganizationRepoMockRecorder) GetMinistriesWithDepartments() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinistriesWithDepartments", reflect.TypeOf((*MockNeo4jRepo)(nil).GetMinistriesWithDepartments))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinistriesWithDepartments", reflect.TypeOf((*MockOrganizationRepo)(nil).GetMinistriesWithDepartments))
+}
+
+// GetMinistryByID mocks base method.
+func (m *MockOrganizationRepo) GetMinistryByID(id int) (models.Ministry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMinistryByID", id)
+	ret0, _ := ret[0].(models.Ministry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMinistryByID indicates an expected call of GetMinistryByID.
+func (mr *MockOrganizationRepoMockRecorder) GetMinistryByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinistryByID", reflect.TypeOf((*MockOrganizationRepo)(nil).GetMinistryByID), id)
 }
 
 // GetMinistryByIDWithDepartments mocks base method.
-func (m *MockNeo4jRepo) GetMinistryByIDWithDepartments(id int) (models.MinistryWithDepartments, error) {
+func (m *MockOrganizationRepo) GetMinistryByIDWithDepartments(id int) (models.MinistryWithDepartments, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMinistryByIDWithDepartments", id)
 	ret0, _ := ret[0].(models.MinistryWithDepartments)
@@ -65,13 +332,139 @@ func (m *MockNeo4jRepo) GetMinistryByIDWithDepartments(id int) (models.MinistryW
 }
 
 // GetMinistryByIDWithDepartments indicates an expected call of GetMinistryByIDWithDepartments.
-func (mr *MockNeo4jRepoMockRecorder) GetMinistryByIDWithDepartments(id any) *gomock.Call {
+func (mr *MockOrganizationRepoMockRecorder) GetMinistryByIDWithDepartments(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinistryByIDWithDepartments", reflect.TypeOf((*MockOrganizationRepo)(nil).GetMinistryByIDWithDepartments), id)
+}
+
+// GetOfficeByID mocks base method.
+func (m *MockOrganizationRepo) GetOfficeByID(id int) (*models.Office, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOfficeByID", id)
+	ret0, _ := ret[0].(*models.Office)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOfficeByID indicates an expected call of GetOfficeByID.
+func (mr *MockOrganizationRepoMockRecorder) GetOfficeByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOfficeByID", reflect.TypeOf((*MockOrganizationRepo)(nil).GetOfficeByID), id)
+}
+
+// GetOrganizationTree mocks base method.
+func (m *MockOrganizationRepo) GetOrganizationTree(root models.OrgRef, depth int) (models.OrgNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationTree", root, depth)
+	ret0, _ := ret[0].(models.OrgNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationTree indicates an expected call of GetOrganizationTree.
+func (mr *MockOrganizationRepoMockRecorder) GetOrganizationTree(root, depth any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinistryByIDWithDepartments", reflect.TypeOf((*MockNeo4jRepo)(nil).GetMinistryByIDWithDepartments), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationTree", reflect.TypeOf((*MockOrganizationRepo)(nil).GetOrganizationTree), root, depth)
+}
+
+// Import mocks base method.
+func (m *MockOrganizationRepo) Import(plan models.ImportPlan) (models.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", plan)
+	ret0, _ := ret[0].(models.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockOrganizationRepoMockRecorder) Import(plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockOrganizationRepo)(nil).Import), plan)
+}
+
+// Search mocks base method.
+func (m *MockOrganizationRepo) Search(q models.SearchQuery) ([]models.SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", q)
+	ret0, _ := ret[0].([]models.SearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockOrganizationRepoMockRecorder) Search(q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockOrganizationRepo)(nil).Search), q)
+}
+
+// UpdateDepartment mocks base method.
+func (m *MockOrganizationRepo) UpdateDepartment(dept models.Department) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDepartment", dept)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDepartment indicates an expected call of UpdateDepartment.
+func (mr *MockOrganizationRepoMockRecorder) UpdateDepartment(dept any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDepartment", reflect.TypeOf((*MockOrganizationRepo)(nil).UpdateDepartment), dept)
+}
+
+// UpdateMinistry mocks base method.
+func (m *MockOrganizationRepo) UpdateMinistry(ministry models.Ministry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMinistry", ministry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMinistry indicates an expected call of UpdateMinistry.
+func (mr *MockOrganizationRepoMockRecorder) UpdateMinistry(ministry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMinistry", reflect.TypeOf((*MockOrganizationRepo)(nil).UpdateMinistry), ministry)
+}
+
+// UpdateOffice mocks base method.
+func (m *MockOrganizationRepo) UpdateOffice(office models.Office) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOffice", office)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOffice indicates an expected call of UpdateOffice.
+func (mr *MockOrganizationRepoMockRecorder) UpdateOffice(office any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOffice", reflect.TypeOf((*MockOrganizationRepo)(nil).UpdateOffice), office)
+}
+
+// MockSeeder is a mock of Seeder interface.
+type MockSeeder struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeederMockRecorder
+	isgomock struct{}
+}
+
+// MockSeederMockRecorder is the mock recorder for MockSeeder.
+type MockSeederMockRecorder struct {
+	mock *MockSeeder
+}
+
+// NewMockSeeder creates a new mock instance.
+func NewMockSeeder(ctrl *gomock.Controller) *MockSeeder {
+	mock := &MockSeeder{ctrl: ctrl}
+	mock.recorder = &MockSeederMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeeder) EXPECT() *MockSeederMockRecorder {
+	return m.recorder
 }
 
 // SeedDummyData mocks base method.
-func (m *MockNeo4jRepo) SeedDummyData() error {
+func (m *MockSeeder) SeedDummyData() error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SeedDummyData")
 	ret0, _ := ret[0].(error)
@@ -79,7 +472,7 @@ func (m *MockNeo4jRepo) SeedDummyData() error {
 }
 
 // SeedDummyData indicates an expected call of SeedDummyData.
-func (mr *MockNeo4jRepoMockRecorder) SeedDummyData() *gomock.Call {
+func (mr *MockSeederMockRecorder) SeedDummyData() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedDummyData", reflect.TypeOf((*MockNeo4jRepo)(nil).SeedDummyData))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedDummyData", reflect.TypeOf((*MockSeeder)(nil).SeedDummyData))
 }
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Neo4jAdminAreaRepository stores areas as :AdminArea nodes linked by
+// (parent)-[:CONTAINS]->(child), and departments as
+// (:Department)-[:LOCATED_IN]->(:AdminArea).
+type Neo4jAdminAreaRepository struct {
+	Driver neo4j.DriverWithContext
+}
+
+func NewNeo4jAdminAreaRepository(driver neo4j.DriverWithContext) *Neo4jAdminAreaRepository {
+	return &Neo4jAdminAreaRepository{Driver: driver}
+}
+
+const adminAreaReturn = `
+	a.id AS id,
+	a.code AS code,
+	a.name AS name,
+	a.level AS level,
+	p.id AS parent_id,
+	p.code AS parent_code`
+
+func (r *Neo4jAdminAreaRepository) GetAreas(level models.AdminLevel) ([]models.AdminArea, error) {
+	return r.readAreas(`
+		MATCH (a:AdminArea {level: $level})
+		OPTIONAL MATCH (p:AdminArea)-[:CONTAINS]->(a)
+		RETURN `+adminAreaReturn+`
+		ORDER BY a.code
+	`, map[string]interface{}{"level": string(level)})
+}
+
+func (r *Neo4jAdminAreaRepository) GetAreaByID(id int) (*models.AdminArea, error) {
+	areas, err := r.readAreas(`
+		MATCH (a:AdminArea {id: $id})
+		OPTIONAL MATCH (p:AdminArea)-[:CONTAINS]->(a)
+		RETURN `+adminAreaReturn+`, a.geometry AS geometry
+	`, map[string]interface{}{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	if len(areas) == 0 {
+		return nil, ErrNotFound
+	}
+	return &areas[0], nil
+}
+
+// GetAreaBoundaries returns every area that has a boundary, geometry
+// included, ordered from provinces down.
+func (r *Neo4jAdminAreaRepository) GetAreaBoundaries() ([]models.AdminArea, error) {
+	areas, err := r.readAreas(`
+		MATCH (a:AdminArea)
+		WHERE a.geometry IS NOT NULL
+		OPTIONAL MATCH (p:AdminArea)-[:CONTAINS]->(a)
+		RETURN `+adminAreaReturn+`, a.geometry AS geometry
+		ORDER BY a.id
+	`, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sortAreasByLevel(areas), nil
+}
+
+func (r *Neo4jAdminAreaRepository) GetChildAreas(id int) ([]models.AdminArea, error) {
+	if err := r.expectArea(id); err != nil {
+		return nil, err
+	}
+	return r.readAreas(`
+		MATCH (p:AdminArea {id: $id})-[:CONTAINS]->(a:AdminArea)
+		RETURN `+adminAreaReturn+`
+		ORDER BY a.code
+	`, map[string]interface{}{"id": id})
+}
+
+// GetAreaDepartments returns the departments located in the area or in any
+// area below it.
+func (r *Neo4jAdminAreaRepository) GetAreaDepartments(id int) ([]models.Department, error) {
+	if err := r.expectArea(id); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (:AdminArea {id: $id})-[:CONTAINS*0..]->(:AdminArea)<-[:LOCATED_IN]-(d:Department)
+		MATCH (d)-[:LOCATED_IN]->(da:AdminArea)
+		OPTIONAL MATCH (m:Ministry)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(d)
+		OPTIONAL MATCH (pd:Department)-[:HAS_UNIT]->(d)
+		RETURN DISTINCT
+			d.id AS dept_id,
+			d.name AS dept_name,
+			d.google_map_script AS dept_map,
+			m.id AS ministry_id,
+			d.latitude AS dept_latitude,
+			d.longitude AS dept_longitude,
+			d.address AS dept_address,
+			d.geometry AS dept_geometry,
+			da.id AS dept_admin_area_id,
+			coalesce(d.kind, 'department') AS dept_kind,
+			pd.id AS dept_parent_id
+		ORDER BY dept_id
+	`
+
+	result, err := session.Run(ctx, query, map[string]interface{}{"id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	departments := []models.Department{}
+	for result.Next(ctx) {
+		record := result.Record()
+
+		dept := models.Department{
+			ID:          int(record.Values[0].(int64)),
+			Name:        record.Values[1].(string),
+			Kind:        recordKind(record, "dept_kind"),
+			ParentID:    recordIntPtr(record, "dept_parent_id"),
+			AdminAreaID: recordIntPtr(record, "dept_admin_area_id"),
+			Location:    locationFromRecord(record, "dept"),
+		}
+		if script, ok := recordString(record, "dept_map"); ok {
+			dept.Google_map_script = script
+		}
+		if ministryID := recordIntPtr(record, "ministry_id"); ministryID != nil {
+			dept.MinistryID = *ministryID
+		}
+		departments = append(departments, dept)
+	}
+
+	if err = result.Err(); err != nil {
+		return nil, err
+	}
+	return departments, nil
+}
+
+// SaveAreas inserts or updates areas keyed by (level, code) in a single
+// write transaction, resolving parents from ParentCode.
+func (r *Neo4jAdminAreaRepository) SaveAreas(areas []models.AdminArea) error {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		for _, area := range sortAreasByLevel(areas) {
+			if err := saveArea(ctx, tx, area); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+func saveArea(ctx context.Context, tx neo4j.ManagedTransaction, area models.AdminArea) error {
+	var parentID interface{}
+	if area.Level.Parent() != "" {
+		result, err := tx.Run(ctx, `MATCH (p:AdminArea {level: $level, code: $code}) RETURN p.id`,
+			map[string]interface{}{"level": string(area.Level.Parent()), "code": area.ParentCode})
+		if err != nil {
+			return err
+		}
+		if !result.Next(ctx) {
+			return fmt.Errorf("%w: %s %q has unknown parent %q", ErrInvalidReference, area.Level, area.Code, area.ParentCode)
+		}
+		parentID = result.Record().Values[0]
+	}
+
+	result, err := tx.Run(ctx, `MATCH (a:AdminArea {level: $level, code: $code}) RETURN a.id`,
+		map[string]interface{}{"level": string(area.Level), "code": area.Code})
+	if err != nil {
+		return err
+	}
+	var id interface{}
+	if result.Next(ctx) {
+		id = result.Record().Values[0]
+	} else {
+		newID, err := nextID(ctx, tx, "AdminArea")
+		if err != nil {
+			return err
+		}
+		id = newID
+	}
+
+	var geometry interface{}
+	if len(area.Geometry) > 0 && string(area.Geometry) != "null" {
+		geometry = string(area.Geometry)
+	}
+
+	_, err = tx.Run(ctx, `
+		MERGE (a:AdminArea {level: $level, code: $code})
+		SET a.id = $id, a.name = $name, a.geometry = $geometry
+		WITH a
+		OPTIONAL MATCH (a)<-[old:CONTAINS]-(:AdminArea)
+		DELETE old
+		WITH DISTINCT a
+		OPTIONAL MATCH (p:AdminArea {id: $parentID})
+		FOREACH (_ IN CASE WHEN p IS NULL THEN [] ELSE [1] END | CREATE (p)-[:CONTAINS]->(a))
+	`, map[string]interface{}{
+		"level":    string(area.Level),
+		"code":     area.Code,
+		"id":       id,
+		"name":     area.Name,
+		"geometry": geometry,
+		"parentID": parentID,
+	})
+	return err
+}
+
+func (r *Neo4jAdminAreaRepository) SetDepartmentArea(departmentID int, areaID *int) error {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, `MATCH (d:Department {id: $id}) RETURN d.id`, map[string]interface{}{"id": departmentID})
+		if err != nil {
+			return nil, err
+		}
+		if !result.Next(ctx) {
+			return nil, ErrNotFound
+		}
+
+		if areaID != nil {
+			result, err := tx.Run(ctx, `MATCH (a:AdminArea {id: $id}) RETURN a.id`, map[string]interface{}{"id": *areaID})
+			if err != nil {
+				return nil, err
+			}
+			if !result.Next(ctx) {
+				return nil, ErrInvalidReference
+			}
+		}
+
+		_, err = tx.Run(ctx, `
+			MATCH (d:Department {id: $departmentID})
+			OPTIONAL MATCH (d)-[old:LOCATED_IN]->(:AdminArea)
+			DELETE old
+			WITH DISTINCT d
+			OPTIONAL MATCH (a:AdminArea {id: $areaID})
+			FOREACH (_ IN CASE WHEN a IS NULL THEN [] ELSE [1] END | CREATE (d)-[:LOCATED_IN]->(a))
+		`, map[string]interface{}{"departmentID": departmentID, "areaID": areaID})
+		return nil, err
+	})
+	return err
+}
+
+func (r *Neo4jAdminAreaRepository) expectArea(id int) error {
+	areas, err := r.readAreas(`
+		MATCH (a:AdminArea {id: $id})
+		OPTIONAL MATCH (p:AdminArea)-[:CONTAINS]->(a)
+		RETURN `+adminAreaReturn+`
+	`, map[string]interface{}{"id": id})
+	if err != nil {
+		return err
+	}
+	if len(areas) == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *Neo4jAdminAreaRepository) readAreas(query string, params map[string]interface{}) ([]models.AdminArea, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	areas := []models.AdminArea{}
+	for result.Next(ctx) {
+		record := result.Record()
+
+		area := models.AdminArea{
+			ID:       int(record.Values[0].(int64)),
+			Code:     record.Values[1].(string),
+			Name:     record.Values[2].(string),
+			Level:    models.AdminLevel(record.Values[3].(string)),
+			ParentID: recordIntPtr(record, "parent_id"),
+		}
+		if parentCode, ok := recordString(record, "parent_code"); ok {
+			area.ParentCode = parentCode
+		}
+		if geometry, ok := recordString(record, "geometry"); ok && geometry != "" {
+			area.Geometry = json.RawMessage(geometry)
+		}
+		areas = append(areas, area)
+	}
+
+	if err = result.Err(); err != nil {
+		return nil, err
+	}
+	return areas, nil
+}
@@ -0,0 +1,376 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// ApplyChangeSet applies every operation of cs in one write transaction,
+// in order, each on its own effective date. If an operation fails nothing
+// is applied and the error is a *models.ChangeOpError naming it.
+func (r *Neo4jRepository) ApplyChangeSet(cs models.ChangeSet) (models.ChangeSetResult, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result := models.ChangeSetResult{Operations: []models.ChangeOpResult{}}
+		for i, op := range cs.Resolve() {
+			c := newChangeTx(ctx, tx, op.EffectiveDate)
+			created, err := c.apply(op)
+			if err == nil {
+				err = c.log(op)
+			}
+			if err != nil {
+				return nil, &models.ChangeOpError{Index: i, Type: op.Type, Err: err}
+			}
+			result.Operations = append(result.Operations, models.ChangeOpResult{
+				Index: i, Type: op.Type, Gazette: op.Gazette, CreatedIDs: created,
+			})
+		}
+		result.Applied = len(result.Operations)
+		return result, nil
+	})
+	if err != nil {
+		return models.ChangeSetResult{}, err
+	}
+	return result.(models.ChangeSetResult), nil
+}
+
+// changeTx applies one operation. Each operation closes the open versions
+// of the nodes it changes, changes the graph, then opens new versions for
+// the nodes in changed with recordVersions.
+type changeTx struct {
+	ctx       context.Context
+	tx        neo4j.ManagedTransaction
+	effective models.Date
+	changed   changedIDs
+}
+
+func newChangeTx(ctx context.Context, tx neo4j.ManagedTransaction, effective models.Date) changeTx {
+	return changeTx{ctx: ctx, tx: tx, effective: effective, changed: changedIDs{}}
+}
+
+func (c changeTx) apply(op models.ChangeOp) ([]int, error) {
+	var created []int
+	var err error
+	switch op.Type {
+	case models.OpTransfer:
+		err = c.transfer(op.ID, op.ToMinistryID)
+	case models.OpRename:
+		err = c.rename(models.OrgRef{Entity: op.Entity, ID: op.ID}, op.Name, op.Names)
+	case models.OpMerge:
+		err = c.merge(op.IntoID, op.DepartmentIDs, op.Name, op.Names)
+	case models.OpSplit:
+		created, err = c.split(op.ID, op.Parts)
+	default:
+		err = fmt.Errorf("%w: unknown operation type %q", models.ErrInvalidChange, op.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return created, recordVersions(c.ctx, c.tx, c.effective, c.changed)
+}
+
+func (c changeTx) transfer(id, ministryID int) error {
+	if err := c.expect("Department", id); err != nil {
+		return err
+	}
+	if err := c.expect("Ministry", ministryID); err != nil {
+		return ErrInvalidReference
+	}
+	subtree, err := c.ids(`MATCH (:Department {id: $id})-[:HAS_UNIT*0..]->(u:Department) RETURN u.id`, id)
+	if err != nil {
+		return err
+	}
+	if err := c.closeVersions(departmentVersionNodes, subtree); err != nil {
+		return err
+	}
+	return c.run(`
+		MATCH (d:Department {id: $id}), (m:Ministry {id: $ministryID})
+		OPTIONAL MATCH ()-[r:HAS_DEPARTMENT|HAS_UNIT]->(d)
+		DELETE r
+		WITH DISTINCT d, m
+		CREATE (m)-[:HAS_DEPARTMENT]->(d)
+	`, map[string]interface{}{"id": id, "ministryID": ministryID})
+}
+
+// rename replaces the name of a ministry or department in every language;
+// translations the gazette does not give are removed.
+func (c changeTx) rename(ref models.OrgRef, name string, names models.LocalizedNames) error {
+	label, nodes := "Department", departmentVersionNodes
+	if ref.Entity == models.EntityMinistry {
+		label, nodes = "Ministry", ministryVersionNodes
+	}
+	if err := c.expect(label, ref.ID); err != nil {
+		return err
+	}
+	if err := c.closeVersions(nodes, []int{ref.ID}); err != nil {
+		return err
+	}
+	// label is one of our fixed node labels, never user input.
+	params := map[string]interface{}{"id": ref.ID, "name": name}
+	addNamesParams(params, name, names)
+	return c.run(`MATCH (n:`+label+` {id: $id}) SET n.name = $name, n += $names`, params)
+}
+
+func (c changeTx) merge(into int, ids []int, name string, names models.LocalizedNames) error {
+	if err := c.expect("Department", into); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := c.expect("Department", id); err != nil {
+			return fmt.Errorf("department %d: %w", id, err)
+		}
+		subtree, err := c.ids(`MATCH (:Department {id: $id})-[:HAS_UNIT*0..]->(u:Department) RETURN u.id`, id)
+		if err != nil {
+			return err
+		}
+		// into may be a division of id, but not further down: moving the
+		// division above it under into would make a cycle.
+		units, err := c.ids(`MATCH (:Department {id: $id})-[:HAS_UNIT]->(u:Department) RETURN u.id`, id)
+		if err != nil {
+			return err
+		}
+		if containsID(subtree, into) && !containsID(units, into) {
+			return ErrInvalidParent
+		}
+		if err := c.closeVersions(departmentVersionNodes, subtree); err != nil {
+			return err
+		}
+
+		err = c.run(`
+			MATCH (s:Department {id: $id})-[r:HAS_UNIT]->(u:Department), (i:Department {id: $into})
+			WHERE u.id <> $into
+			DELETE r
+			CREATE (i)-[:HAS_UNIT]->(u)
+		`, map[string]interface{}{"id": id, "into": into})
+		if err != nil {
+			return err
+		}
+		if err := c.moveOffices(id, into); err != nil {
+			return err
+		}
+		if err := c.dissolve(id); err != nil {
+			return err
+		}
+	}
+
+	if name == "" {
+		return nil
+	}
+	return c.rename(models.OrgRef{Entity: models.EntityDepartment, ID: into}, name, names)
+}
+
+func (c changeTx) split(id int, parts []models.SplitPart) ([]int, error) {
+	if err := c.expect("Department", id); err != nil {
+		return nil, err
+	}
+	units, err := c.ids(`MATCH (:Department {id: $id})-[:HAS_UNIT]->(u:Department) RETURN u.id`, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		for _, unitID := range part.UnitIDs {
+			if !containsID(units, unitID) {
+				return nil, fmt.Errorf("%w: department %d is not a division of department %d", models.ErrInvalidChange, unitID, id)
+			}
+		}
+	}
+	if err := c.closeVersions(departmentVersionNodes, append([]int{id}, units...)); err != nil {
+		return nil, err
+	}
+
+	created := make([]int, 0, len(parts))
+	for _, part := range parts {
+		partID, err := nextID(c.ctx, c.tx, "Department")
+		if err != nil {
+			return nil, err
+		}
+		kind := string(part.Kind)
+		if kind == "" {
+			kind = string(models.KindDepartment)
+		}
+		params := map[string]interface{}{"sourceID": id, "id": partID, "name": part.Name, "kind": kind}
+		addNamesParams(params, part.Name, part.Names)
+		// The part takes the source's place in the hierarchy, its location
+		// and its area.
+		err = c.run(`
+			MATCH (s:Department {id: $sourceID})
+			CREATE (p:Department {id: $id, name: $name, kind: $kind})
+			SET p += $names, p.google_map_script = s.google_map_script, p.latitude = s.latitude, p.longitude = s.longitude,
+				p.address = s.address, p.geometry = s.geometry
+			WITH s, p
+			OPTIONAL MATCH (pm:Ministry)-[:HAS_DEPARTMENT]->(s)
+			OPTIONAL MATCH (pd:Department)-[:HAS_UNIT]->(s)
+			OPTIONAL MATCH (s)-[:LOCATED_IN]->(a:AdminArea)
+			FOREACH (_ IN CASE WHEN pm IS NULL THEN [] ELSE [1] END | CREATE (pm)-[:HAS_DEPARTMENT]->(p))
+			FOREACH (_ IN CASE WHEN pd IS NULL THEN [] ELSE [1] END | CREATE (pd)-[:HAS_UNIT]->(p))
+			FOREACH (_ IN CASE WHEN a IS NULL THEN [] ELSE [1] END | CREATE (p)-[:LOCATED_IN]->(a))
+		`, params)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, partID)
+		c.changed.add(departmentVersionNodes, partID)
+
+		err = c.run(`
+			MATCH (s:Department {id: $sourceID})-[r:HAS_UNIT]->(u:Department), (p:Department {id: $id})
+			WHERE u.id IN $unitIDs
+			DELETE r
+			CREATE (p)-[:HAS_UNIT]->(u)
+		`, map[string]interface{}{"sourceID": id, "id": partID, "unitIDs": part.UnitIDs})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(created) > 0 {
+		if err := c.moveOffices(id, created[0]); err != nil {
+			return nil, err
+		}
+	}
+	return created, c.dissolve(id)
+}
+
+// moveOffices hands the offices of department from to department to.
+func (c changeTx) moveOffices(from, to int) error {
+	return c.run(`
+		MATCH (:Department {id: $from})-[r:HAS_OFFICE]->(o:Office), (t:Department {id: $to})
+		DELETE r
+		CREATE (t)-[:HAS_OFFICE]->(o)
+	`, map[string]interface{}{"from": from, "to": to})
+}
+
+// dissolve deletes a department. Its remaining divisions become top-level
+// departments of its ministry and its offices are deleted, as the foreign
+// keys do in Postgres; the terms held in it are kept.
+func (c changeTx) dissolve(id int) error {
+	err := c.run(`
+		MATCH (m:Ministry)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(s:Department {id: $id})
+		MATCH (s)-[r:HAS_UNIT]->(u:Department)
+		DELETE r
+		CREATE (m)-[:HAS_DEPARTMENT]->(u)
+	`, map[string]interface{}{"id": id})
+	if err != nil {
+		return err
+	}
+	err = c.run(`MATCH (:Department {id: $id})-[:HAS_OFFICE]->(o:Office) DETACH DELETE o`, map[string]interface{}{"id": id})
+	if err != nil {
+		return err
+	}
+	if err := c.keepTerms("Department", []int{id}); err != nil {
+		return err
+	}
+	return c.run(`MATCH (s:Department {id: $id}) DETACH DELETE s`, map[string]interface{}{"id": id})
+}
+
+// versionNodes names the version label of a node type and the property
+// holding the node id.
+type versionNodes struct {
+	label string
+	key   string
+}
+
+var (
+	ministryVersionNodes   = versionNodes{"MinistryVersion", "ministry_id"}
+	departmentVersionNodes = versionNodes{"DepartmentVersion", "department_id"}
+)
+
+// closeVersions ends the open versions of ids on the effective date, with
+// the same rules as versionTable.close in Postgres.
+func (c changeTx) closeVersions(v versionNodes, ids []int) error {
+	params := map[string]interface{}{"ids": ids, "effective": c.effective.String()}
+	result, err := c.tx.Run(c.ctx, fmt.Sprintf(`
+		MATCH (v:%s) WHERE v.%s IN $ids AND v.valid_to IS NULL AND v.valid_from > $effective
+		RETURN v.%[2]s AS id, v.valid_from AS valid_from LIMIT 1
+	`, v.label, v.key), params)
+	if err != nil {
+		return err
+	}
+	if result.Next(c.ctx) {
+		record := result.Record()
+		return fmt.Errorf("%w: %s %d changed on %s", ErrEffectiveDateOrder, v.label, record.Values[0], record.Values[1])
+	}
+	if err := result.Err(); err != nil {
+		return err
+	}
+
+	if err := c.run(fmt.Sprintf(`
+		MATCH (v:%s) WHERE v.%s IN $ids AND v.valid_to IS NULL AND v.valid_from = $effective
+		DELETE v
+	`, v.label, v.key), params); err != nil {
+		return err
+	}
+	if err := c.run(fmt.Sprintf(`
+		MATCH (v:%s) WHERE v.%s IN $ids AND v.valid_to IS NULL
+		SET v.valid_to = $effective
+	`, v.label, v.key), params); err != nil {
+		return err
+	}
+	c.changed.add(v, ids...)
+	return nil
+}
+
+// log keeps the applied operation with its gazette reference.
+func (c changeTx) log(op models.ChangeOp) error {
+	operation, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	return c.run(`
+		CREATE (:GazetteChange {gazette: $gazette, effective_date: $effective, type: $type,
+			operation: $operation, applied_at: datetime()})
+	`, map[string]interface{}{
+		"gazette": op.Gazette, "effective": op.EffectiveDate.String(), "type": string(op.Type), "operation": string(operation),
+	})
+}
+
+func (c changeTx) expect(label string, id int) error {
+	// label is one of our fixed node labels, never user input.
+	ids, err := c.ids(`MATCH (n:`+label+` {id: $id}) RETURN n.id`, id)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ids runs a query taking $id and returning a single id column.
+func (c changeTx) ids(query string, id int) ([]int, error) {
+	result, err := c.tx.Run(c.ctx, query, map[string]interface{}{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	var ids []int
+	for result.Next(c.ctx) {
+		ids = append(ids, int(result.Record().Values[0].(int64)))
+	}
+	return ids, result.Err()
+}
+
+func (c changeTx) run(query string, params map[string]interface{}) error {
+	result, err := c.tx.Run(c.ctx, query, params)
+	if err != nil {
+		return err
+	}
+	_, err = result.Consume(c.ctx)
+	return err
+}
+
+func containsID(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
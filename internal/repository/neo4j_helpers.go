@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// nextID returns the next value of a named counter stored on a :Sequence
+// node. New counters start above the largest id already used by label so
+// that seeded data is not overwritten.
+func nextID(ctx context.Context, tx neo4j.ManagedTransaction, label string) (int, error) {
+	// label is one of our fixed node labels, never user input.
+	result, err := tx.Run(ctx, `
+		OPTIONAL MATCH (n:`+label+`)
+		WITH coalesce(max(n.id), 0) AS maxID
+		MERGE (s:Sequence {name: $name})
+		ON CREATE SET s.value = maxID
+		SET s.value = s.value + 1
+		RETURN s.value
+	`, map[string]interface{}{"name": label})
+	if err != nil {
+		return 0, err
+	}
+	record, err := result.Single(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int(record.Values[0].(int64)), nil
+}
+
+// addLocationParams adds a "location" map of node properties for loc to
+// params, so queries can apply it with SET n += $location. Missing values
+// are sent as null, which removes the property.
+func addLocationParams(params map[string]interface{}, loc models.Location) {
+	location := map[string]interface{}{
+		"latitude":  nil,
+		"longitude": nil,
+		"address":   nil,
+		"geometry":  nil,
+	}
+	if loc.HasCoordinates() {
+		location["latitude"] = *loc.Latitude
+		location["longitude"] = *loc.Longitude
+	}
+	if loc.Address != "" {
+		location["address"] = loc.Address
+	}
+	if len(loc.Geometry) > 0 && string(loc.Geometry) != "null" {
+		location["geometry"] = string(loc.Geometry)
+	}
+	params["location"] = location
+}
+
+// localizedNameProperties maps the locales other than English to the node
+// properties holding their names; the English name is the name property.
+var localizedNameProperties = map[models.Locale]string{
+	models.LocaleSinhala: "name_si",
+	models.LocaleTamil:   "name_ta",
+}
+
+// addNamesParams adds a "names" map of the name_si and name_ta properties
+// for names to params, so queries can apply it with SET n += $names.
+// Missing names are sent as null, which removes the property. The map
+// also carries name_key, the phonetic key of name and names.
+func addNamesParams(params map[string]interface{}, name string, names models.LocalizedNames) {
+	properties := map[string]interface{}{"name_key": searchKey(name, names)}
+	for locale, property := range localizedNameProperties {
+		properties[property] = nil
+		if name := names[locale]; name != "" {
+			properties[property] = name
+		}
+	}
+	params["names"] = properties
+}
+
+// namesFromRecord reads the <prefix>_name, _name_si and _name_ta columns
+// of a record, or name, name_si and name_ta when prefix is empty.
+func namesFromRecord(record *neo4j.Record, prefix string) models.LocalizedNames {
+	key := "name"
+	if prefix != "" {
+		key = prefix + "_name"
+	}
+	names := models.LocalizedNames{}
+	if name, ok := recordString(record, key); ok && name != "" {
+		names[models.LocaleEnglish] = name
+	}
+	for locale, property := range localizedNameProperties {
+		if name, ok := recordString(record, key+property[len("name"):]); ok && name != "" {
+			names[locale] = name
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
+// locationFromRecord reads the <prefix>_latitude, _longitude, _address and
+// _geometry columns of a record.
+func locationFromRecord(record *neo4j.Record, prefix string) models.Location {
+	var loc models.Location
+
+	lat, _ := record.Get(prefix + "_latitude")
+	lon, _ := record.Get(prefix + "_longitude")
+	latF, okLat := lat.(float64)
+	lonF, okLon := lon.(float64)
+	if okLat && okLon {
+		loc.Latitude, loc.Longitude = &latF, &lonF
+	}
+
+	if address, ok := recordString(record, prefix+"_address"); ok {
+		loc.Address = address
+	}
+	if geometry, ok := recordString(record, prefix+"_geometry"); ok && geometry != "" {
+		loc.Geometry = json.RawMessage(geometry)
+	}
+	return loc
+}
+
+func recordString(record *neo4j.Record, key string) (string, bool) {
+	value, ok := record.Get(key)
+	if !ok || value == nil {
+		return "", false
+	}
+	s, ok := value.(string)
+	return s, ok
+}
+
+func recordIntPtr(record *neo4j.Record, key string) *int {
+	value, ok := record.Get(key)
+	if !ok || value == nil {
+		return nil
+	}
+	n, ok := value.(int64)
+	if !ok {
+		return nil
+	}
+	v := int(n)
+	return &v
+}
+
+// recordDate reads a YYYY-MM-DD property; a missing one is the zero Date.
+func recordDate(record *neo4j.Record, key string) (models.Date, error) {
+	s, ok := recordString(record, key)
+	if !ok || s == "" {
+		return models.Date{}, nil
+	}
+	return models.ParseDate(s)
+}
+
+// recordKind reads an organisation kind, which queries return with a
+// default already applied.
+func recordKind(record *neo4j.Record, key string) models.OrgKind {
+	kind, _ := recordString(record, key)
+	return models.OrgKind(kind)
+}
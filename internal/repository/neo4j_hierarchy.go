@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// orgLabels maps an organisation entity to its node label.
+var orgLabels = map[models.OrgEntity]string{
+	models.EntityMinistry:   "Ministry",
+	models.EntityDepartment: "Department",
+}
+
+// GetOrganizationTree returns root and its descendants down to depth levels
+// below it, following (:Ministry)-[:HAS_MINISTRY]->(:Ministry),
+// (:Ministry)-[:HAS_DEPARTMENT]->(:Department) and
+// (:Department)-[:HAS_UNIT]->(:Department).
+func (r *Neo4jRepository) GetOrganizationTree(root models.OrgRef, depth int) (models.OrgNode, error) {
+	label, ok := orgLabels[root.Entity]
+	if !ok {
+		return models.OrgNode{}, fmt.Errorf("unknown organisation entity %q", root.Entity)
+	}
+	if r.asOf != nil {
+		return r.getOrganizationTreeAsOf(root, depth)
+	}
+
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	params := map[string]interface{}{"id": root.ID, "defaultKind": string(root.Entity)}
+
+	// label comes from orgLabels, never from user input.
+	result, err := session.Run(ctx, fmt.Sprintf(`
+		MATCH (root:%s {id: $id})
+		RETURN root.name AS name, coalesce(root.kind, $defaultKind) AS kind,
+			root.name_si AS name_si, root.name_ta AS name_ta
+	`, label), params)
+	if err != nil {
+		return models.OrgNode{}, err
+	}
+	if !result.Next(ctx) {
+		if err := result.Err(); err != nil {
+			return models.OrgNode{}, err
+		}
+		return models.OrgNode{}, ErrNotFound
+	}
+	node := models.OrgNode{Entity: root.Entity, ID: root.ID, Kind: recordKind(result.Record(), "kind")}
+	node.Name, _ = recordString(result.Record(), "name")
+	node.Names = namesFromRecord(result.Record(), "")
+
+	if depth < 1 {
+		return assembleOrgTree(node, nil), nil
+	}
+
+	// Variable-length bounds cannot be parameters; depth is an int.
+	result, err = session.Run(ctx, fmt.Sprintf(`
+		MATCH path = (root:%s {id: $id})-[:HAS_MINISTRY|HAS_DEPARTMENT|HAS_UNIT*1..%d]->(n)
+		WITH n, length(path) AS depth, nodes(path)[-2] AS parent,
+			CASE WHEN n:Ministry THEN 'ministry' ELSE 'department' END AS entity
+		RETURN
+			entity,
+			n.id AS id,
+			n.name AS name,
+			coalesce(n.kind, entity) AS kind,
+			depth,
+			CASE WHEN parent:Ministry THEN 'ministry' ELSE 'department' END AS parent_entity,
+			parent.id AS parent_id,
+			n.name_si AS name_si,
+			n.name_ta AS name_ta
+		ORDER BY depth, entity DESC, id
+	`, label, depth), params)
+	if err != nil {
+		return models.OrgNode{}, err
+	}
+
+	var rows []orgTreeRow
+	for result.Next(ctx) {
+		record := result.Record()
+
+		var row orgTreeRow
+		entity, _ := recordString(record, "entity")
+		parentEntity, _ := recordString(record, "parent_entity")
+		row.node.Entity = models.OrgEntity(entity)
+		row.node.ID = int(record.Values[1].(int64))
+		row.node.Name, _ = recordString(record, "name")
+		row.node.Names = namesFromRecord(record, "")
+		row.node.Kind = recordKind(record, "kind")
+		row.node.Depth = int(record.Values[4].(int64))
+		row.parent.Entity = models.OrgEntity(parentEntity)
+		row.parent.ID = int(record.Values[6].(int64))
+		rows = append(rows, row)
+	}
+	if err := result.Err(); err != nil {
+		return models.OrgNode{}, err
+	}
+
+	return assembleOrgTree(node, rows), nil
+}
@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// In Neo4j the history of the structure is kept in version nodes,
+// (:MinistryVersion {ministry_id, name, name_si, name_ta, kind, parent_id,
+// valid_from, valid_to}) and (:DepartmentVersion {department_id, name,
+// name_si, name_ta, kind, parent_id, ministry_id, valid_from, valid_to}), with dates stored as YYYY-MM-DD strings so they
+// compare in order. A version is valid on [valid_from, valid_to).
+
+// AsOf returns a read-only view of the repository that answers read
+// queries from the version nodes as the structure stood on date. Locations,
+// map scripts and area links are not versioned and come from the current
+// nodes.
+func (r *Neo4jRepository) AsOf(date models.Date) OrganizationRepo {
+	return &Neo4jRepository{Driver: r.Driver, asOf: &date}
+}
+
+// readQuery picks the dated variant of a read query when the repository
+// is an as-of view, adding the date to params.
+func (r *Neo4jRepository) readQuery(current, dated string, params map[string]interface{}) string {
+	if r.asOf == nil {
+		return current
+	}
+	params["asOf"] = r.asOf.String()
+	return dated
+}
+
+// changedIDs lists, by version label, the ministries and departments a
+// change closed versions of or created. recordVersions opens versions for
+// those that are still there.
+type changedIDs map[versionNodes][]int
+
+func (c changedIDs) add(v versionNodes, ids ...int) {
+	c[v] = append(c[v], ids...)
+}
+
+// recordVersions opens a version, valid from effective, for every ministry
+// and department in changed that has none open yet. Records that predate
+// the history got theirs from the history_backfill migration.
+func recordVersions(ctx context.Context, tx neo4j.ManagedTransaction, effective models.Date, changed changedIDs) error {
+	if ids := changed[ministryVersionNodes]; len(ids) > 0 {
+		_, err := tx.Run(ctx, `
+			MATCH (m:Ministry) WHERE m.id IN $ids
+			  AND NOT EXISTS { MATCH (v:MinistryVersion {ministry_id: m.id}) WHERE v.valid_to IS NULL }
+			OPTIONAL MATCH (pm:Ministry)-[:HAS_MINISTRY]->(m)
+			CREATE (:MinistryVersion {
+				ministry_id: m.id, name: m.name, name_si: m.name_si, name_ta: m.name_ta, kind: coalesce(m.kind, 'ministry'),
+				parent_id: pm.id, valid_from: $effective
+			})
+		`, map[string]interface{}{"ids": ids, "effective": effective.String()})
+		if err != nil {
+			return err
+		}
+	}
+	if ids := changed[departmentVersionNodes]; len(ids) > 0 {
+		_, err := tx.Run(ctx, `
+			MATCH (d:Department) WHERE d.id IN $ids
+			  AND NOT EXISTS { MATCH (v:DepartmentVersion {department_id: d.id}) WHERE v.valid_to IS NULL }
+			OPTIONAL MATCH (m:Ministry)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(d)
+			OPTIONAL MATCH (pd:Department)-[:HAS_UNIT]->(d)
+			CREATE (:DepartmentVersion {
+				department_id: d.id, name: d.name, name_si: d.name_si, name_ta: d.name_ta, kind: coalesce(d.kind, 'department'),
+				parent_id: pd.id, ministry_id: m.id, valid_from: $effective
+			})
+		`, map[string]interface{}{"ids": ids, "effective": effective.String()})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The dated queries return the same columns, in the same order, as their
+// current counterparts in neo4j_repo.go so the records are read the same way.
+const (
+	ministriesAsOfQuery = `
+		MATCH (mv:MinistryVersion)
+		WHERE mv.valid_from <= $asOf AND (mv.valid_to IS NULL OR mv.valid_to > $asOf)
+		  AND ($ids IS NULL OR mv.ministry_id IN $ids)
+		OPTIONAL MATCH (m:Ministry {id: mv.ministry_id})
+		OPTIONAL MATCH (dv:DepartmentVersion)
+		WHERE dv.ministry_id = mv.ministry_id
+		  AND dv.valid_from <= $asOf AND (dv.valid_to IS NULL OR dv.valid_to > $asOf)
+		OPTIONAL MATCH (d:Department {id: dv.department_id})
+		OPTIONAL MATCH (d)-[:LOCATED_IN]->(da:AdminArea)
+		RETURN
+			mv.ministry_id AS ministry_id,
+			mv.name AS ministry_name,
+			m.google_map_script AS ministry_map,
+			dv.department_id AS dept_id,
+			dv.name AS dept_name,
+			d.google_map_script AS dept_map,
+			m.latitude AS ministry_latitude,
+			m.longitude AS ministry_longitude,
+			m.address AS ministry_address,
+			m.geometry AS ministry_geometry,
+			d.latitude AS dept_latitude,
+			d.longitude AS dept_longitude,
+			d.address AS dept_address,
+			d.geometry AS dept_geometry,
+			da.id AS dept_admin_area_id,
+			coalesce(mv.kind, 'ministry') AS ministry_kind,
+			mv.parent_id AS ministry_parent_id,
+			coalesce(dv.kind, 'department') AS dept_kind,
+			dv.parent_id AS dept_parent_id,
+			dv.name_si AS dept_name_si,
+			dv.name_ta AS dept_name_ta,
+			mv.name_si AS ministry_name_si,
+			mv.name_ta AS ministry_name_ta
+		ORDER BY ministry_id, dept_id
+	`
+
+	ministryAsOfQuery = `
+		MATCH (mv:MinistryVersion {ministry_id: $id})
+		WHERE mv.valid_from <= $asOf AND (mv.valid_to IS NULL OR mv.valid_to > $asOf)
+		OPTIONAL MATCH (m:Ministry {id: mv.ministry_id})
+		OPTIONAL MATCH (dv:DepartmentVersion)
+		WHERE dv.ministry_id = mv.ministry_id
+		  AND dv.valid_from <= $asOf AND (dv.valid_to IS NULL OR dv.valid_to > $asOf)
+		OPTIONAL MATCH (d:Department {id: dv.department_id})
+		OPTIONAL MATCH (d)-[:LOCATED_IN]->(da:AdminArea)
+		RETURN
+			mv.ministry_id AS ministry_id,
+			mv.name AS ministry_name,
+			m.google_map_script AS ministry_map,
+			dv.department_id AS dept_id,
+			dv.name AS dept_name,
+			d.google_map_script AS dept_map,
+			m.latitude AS ministry_latitude,
+			m.longitude AS ministry_longitude,
+			m.address AS ministry_address,
+			m.geometry AS ministry_geometry,
+			d.latitude AS dept_latitude,
+			d.longitude AS dept_longitude,
+			d.address AS dept_address,
+			d.geometry AS dept_geometry,
+			da.id AS dept_admin_area_id,
+			coalesce(mv.kind, 'ministry') AS ministry_kind,
+			mv.parent_id AS ministry_parent_id,
+			coalesce(dv.kind, 'department') AS dept_kind,
+			dv.parent_id AS dept_parent_id,
+			dv.name_si AS dept_name_si,
+			dv.name_ta AS dept_name_ta,
+			mv.name_si AS ministry_name_si,
+			mv.name_ta AS ministry_name_ta
+		ORDER BY dept_id
+	`
+
+	departmentAsOfQuery = `
+		MATCH (dv:DepartmentVersion {department_id: $id})
+		WHERE dv.valid_from <= $asOf AND (dv.valid_to IS NULL OR dv.valid_to > $asOf)
+		OPTIONAL MATCH (d:Department {id: dv.department_id})
+		OPTIONAL MATCH (d)-[:LOCATED_IN]->(da:AdminArea)
+		RETURN
+			dv.department_id AS dept_id,
+			dv.name AS dept_name,
+			d.google_map_script AS dept_map,
+			dv.ministry_id AS ministry_id,
+			d.latitude AS dept_latitude,
+			d.longitude AS dept_longitude,
+			d.address AS dept_address,
+			d.geometry AS dept_geometry,
+			da.id AS dept_admin_area_id,
+			coalesce(dv.kind, 'department') AS dept_kind,
+			dv.parent_id AS dept_parent_id,
+			dv.name_si AS dept_name_si,
+			dv.name_ta AS dept_name_ta
+	`
+
+	departmentsNearbyAsOfQuery = `
+		MATCH (dv:DepartmentVersion)
+		WHERE dv.valid_from <= $asOf AND (dv.valid_to IS NULL OR dv.valid_to > $asOf)
+		MATCH (d:Department {id: dv.department_id})
+		WHERE d.latitude IS NOT NULL AND d.longitude IS NOT NULL
+		OPTIONAL MATCH (d)-[:LOCATED_IN]->(da:AdminArea)
+		WITH dv, d, da, point.distance(
+			point({latitude: d.latitude, longitude: d.longitude}),
+			point({latitude: $lat, longitude: $lon})
+		) / 1000.0 AS distance_km
+		WHERE distance_km <= $radius
+		RETURN
+			dv.department_id AS dept_id,
+			dv.name AS dept_name,
+			d.google_map_script AS dept_map,
+			dv.ministry_id AS ministry_id,
+			d.latitude AS dept_latitude,
+			d.longitude AS dept_longitude,
+			d.address AS dept_address,
+			d.geometry AS dept_geometry,
+			distance_km,
+			da.id AS dept_admin_area_id,
+			coalesce(dv.kind, 'department') AS dept_kind,
+			dv.parent_id AS dept_parent_id,
+			dv.name_si AS dept_name_si,
+			dv.name_ta AS dept_name_ta
+		ORDER BY distance_km, dept_id
+		LIMIT $limit
+	`
+
+	orgVersionsAsOfQuery = `
+		MATCH (mv:MinistryVersion)
+		WHERE mv.valid_from <= $asOf AND (mv.valid_to IS NULL OR mv.valid_to > $asOf)
+		RETURN 'ministry' AS entity, mv.ministry_id AS id, mv.name AS name,
+			coalesce(mv.kind, 'ministry') AS kind, mv.parent_id AS parent_id, null AS ministry_id,
+			mv.name_si AS name_si, mv.name_ta AS name_ta
+		UNION ALL
+		MATCH (dv:DepartmentVersion)
+		WHERE dv.valid_from <= $asOf AND (dv.valid_to IS NULL OR dv.valid_to > $asOf)
+		RETURN 'department' AS entity, dv.department_id AS id, dv.name AS name,
+			coalesce(dv.kind, 'department') AS kind, dv.parent_id AS parent_id, dv.ministry_id AS ministry_id,
+			dv.name_si AS name_si, dv.name_ta AS name_ta
+	`
+)
+
+// getOrganizationTreeAsOf builds the tree from the versions valid on
+// r.asOf. Variable-length paths do not apply to version nodes, so the
+// edges are assembled here and walked breadth first.
+func (r *Neo4jRepository) getOrganizationTreeAsOf(root models.OrgRef, depth int) (models.OrgNode, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, orgVersionsAsOfQuery, map[string]interface{}{"asOf": r.asOf.String()})
+	if err != nil {
+		return models.OrgNode{}, err
+	}
+
+	nodes := make(map[models.OrgRef]models.OrgNode)
+	children := make(map[models.OrgRef][]models.OrgRef)
+	for result.Next(ctx) {
+		record := result.Record()
+
+		entity, _ := recordString(record, "entity")
+		node := models.OrgNode{Entity: models.OrgEntity(entity), ID: int(record.Values[1].(int64)), Kind: recordKind(record, "kind")}
+		node.Name, _ = recordString(record, "name")
+		node.Names = namesFromRecord(record, "")
+		ref := models.OrgRef{Entity: node.Entity, ID: node.ID}
+		nodes[ref] = node
+
+		var parent models.OrgRef
+		switch parentID, ministryID := recordIntPtr(record, "parent_id"), recordIntPtr(record, "ministry_id"); {
+		case parentID != nil:
+			parent = models.OrgRef{Entity: node.Entity, ID: *parentID}
+		case node.Entity == models.EntityDepartment && ministryID != nil:
+			parent = models.OrgRef{Entity: models.EntityMinistry, ID: *ministryID}
+		default:
+			continue
+		}
+		children[parent] = append(children[parent], ref)
+	}
+	if err := result.Err(); err != nil {
+		return models.OrgNode{}, err
+	}
+
+	top, ok := nodes[root]
+	if !ok {
+		return models.OrgNode{}, ErrNotFound
+	}
+	return assembleOrgTree(top, walkOrgTree(root, depth, nodes, children)), nil
+}
+
+// walkOrgTree lists the descendants of root down to depth levels, ordered
+// by depth with ministries before departments, as the tree queries do.
+func walkOrgTree(root models.OrgRef, depth int, nodes map[models.OrgRef]models.OrgNode, children map[models.OrgRef][]models.OrgRef) []orgTreeRow {
+	var rows []orgTreeRow
+	level := []models.OrgRef{root}
+	seen := map[models.OrgRef]bool{root: true}
+	for d := 1; d <= depth && len(level) > 0; d++ {
+		var next []orgTreeRow
+		for _, parent := range level {
+			for _, ref := range children[parent] {
+				if seen[ref] {
+					continue
+				}
+				seen[ref] = true
+				node := nodes[ref]
+				node.Depth = d
+				next = append(next, orgTreeRow{node: node, parent: parent})
+			}
+		}
+		sortOrgTreeRows(next)
+		level = level[:0]
+		for _, row := range next {
+			level = append(level, models.OrgRef{Entity: row.node.Entity, ID: row.node.ID})
+		}
+		rows = append(rows, next...)
+	}
+	return rows
+}
+
+// sortOrgTreeRows orders one level of a tree as the tree queries do:
+// ministries before departments, then by id.
+func sortOrgTreeRows(rows []orgTreeRow) {
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i].node, rows[j].node
+		if a.Entity != b.Entity {
+			return a.Entity > b.Entity
+		}
+		return a.ID < b.ID
+	})
+}
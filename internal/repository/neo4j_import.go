@@ -0,0 +1,15 @@
+package repository
+
+import "go-mysql-backend/internal/models"
+
+// Import creates the records of plan in one write transaction, effective
+// today. If one fails nothing is created and the error is a
+// *models.ImportRowError naming it.
+func (r *Neo4jRepository) Import(plan models.ImportPlan) (models.ImportResult, error) {
+	var result models.ImportResult
+	err := r.change(func(c changeTx) (err error) {
+		result, err = importPlan(plan, c.createMinistry, c.createDepartment, c.createOffice)
+		return err
+	})
+	return result, err
+}
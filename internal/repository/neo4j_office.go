@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Offices are stored as (:Department)-[:HAS_OFFICE]->(:Office) with the
+// location properties of the department nodes, phone_numbers as a list of
+// strings, email, and hours and closures as JSON lists.
+const officeReturn = `
+	o.id AS id,
+	d.id AS department_id,
+	o.name AS name,
+	o.phone_numbers AS phone_numbers,
+	o.email AS email,
+	o.hours AS hours,
+	o.closures AS closures,
+	o.latitude AS office_latitude,
+	o.longitude AS office_longitude,
+	o.address AS office_address,
+	o.geometry AS office_geometry`
+
+// GetDepartmentOffices lists the offices of a department by id.
+func (r *Neo4jRepository) GetDepartmentOffices(departmentID int) ([]models.Office, error) {
+	return r.readOffices(`
+		MATCH (d:Department {id: $id})-[:HAS_OFFICE]->(o:Office)
+		RETURN `+officeReturn+`
+		ORDER BY o.id
+	`, map[string]interface{}{"id": departmentID})
+}
+
+func (r *Neo4jRepository) GetOfficeByID(id int) (*models.Office, error) {
+	offices, err := r.readOffices(`
+		MATCH (d:Department)-[:HAS_OFFICE]->(o:Office {id: $id})
+		RETURN `+officeReturn, map[string]interface{}{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	if len(offices) == 0 {
+		return nil, ErrNotFound
+	}
+	return &offices[0], nil
+}
+
+// CreateOffice adds an office to an existing department.
+func (r *Neo4jRepository) CreateOffice(office models.Office) (int, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	id, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		return newChangeTx(ctx, tx, models.Today()).createOffice(office)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id.(int), nil
+}
+
+func (c changeTx) createOffice(office models.Office) (int, error) {
+	id, err := nextID(c.ctx, c.tx, "Office")
+	if err != nil {
+		return 0, err
+	}
+
+	params, err := officeParams(office)
+	if err != nil {
+		return 0, err
+	}
+	params["id"] = id
+	result, err := c.tx.Run(c.ctx, `
+		MATCH (d:Department {id: $departmentID})
+		CREATE (d)-[:HAS_OFFICE]->(o:Office {id: $id})
+		SET o += $office, o += $location
+		RETURN o.id
+	`, params)
+	if err != nil {
+		return 0, err
+	}
+	if !result.Next(c.ctx) {
+		if err := result.Err(); err != nil {
+			return 0, err
+		}
+		return 0, ErrInvalidReference
+	}
+	return id, nil
+}
+
+// UpdateOffice replaces the details of an office. DepartmentID moves the
+// office to another department.
+func (r *Neo4jRepository) UpdateOffice(office models.Office) error {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		params, err := officeParams(office)
+		if err != nil {
+			return nil, err
+		}
+		params["id"] = office.ID
+
+		result, err := tx.Run(ctx, `
+			MATCH (o:Office {id: $id})
+			OPTIONAL MATCH (d:Department {id: $departmentID})
+			RETURN d IS NOT NULL AS department_exists
+		`, params)
+		if err != nil {
+			return nil, err
+		}
+		if !result.Next(ctx) {
+			if err := result.Err(); err != nil {
+				return nil, err
+			}
+			return nil, ErrNotFound
+		}
+		if exists, _ := result.Record().Values[0].(bool); !exists {
+			return nil, ErrInvalidReference
+		}
+
+		_, err = tx.Run(ctx, `
+			MATCH (o:Office {id: $id}), (d:Department {id: $departmentID})
+			OPTIONAL MATCH ()-[r:HAS_OFFICE]->(o)
+			DELETE r
+			WITH DISTINCT o, d
+			CREATE (d)-[:HAS_OFFICE]->(o)
+			SET o += $office, o += $location
+		`, params)
+		return nil, err
+	})
+	return err
+}
+
+func (r *Neo4jRepository) DeleteOffice(id int) error {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, `MATCH (o:Office {id: $id}) DETACH DELETE o`, map[string]interface{}{"id": id})
+		if err != nil {
+			return nil, err
+		}
+		summary, err := result.Consume(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if summary.Counters().NodesDeleted() == 0 {
+			return nil, ErrNotFound
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// FindOfficesNearby returns the offices within q.RadiusKm of the query
+// point, nearest first, using point.distance on WGS-84 points.
+func (r *Neo4jRepository) FindOfficesNearby(q models.NearbyQuery) ([]models.NearbyOffice, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		MATCH (d:Department)-[:HAS_OFFICE]->(o:Office)
+		WHERE o.latitude IS NOT NULL AND o.longitude IS NOT NULL
+		WITH d, o, point.distance(
+			point({latitude: o.latitude, longitude: o.longitude}),
+			point({latitude: $lat, longitude: $lon})
+		) / 1000.0 AS distance_km
+		WHERE distance_km <= $radius
+		RETURN `+officeReturn+`,
+			distance_km
+		ORDER BY distance_km, o.id
+		LIMIT $limit
+	`, map[string]interface{}{
+		"lat":    q.Latitude,
+		"lon":    q.Longitude,
+		"radius": q.RadiusKm,
+		"limit":  int64(q.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	offices := []models.NearbyOffice{}
+	for result.Next(ctx) {
+		record := result.Record()
+		office, err := officeFromRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		distance, _ := record.Get("distance_km")
+		distanceKm, _ := distance.(float64)
+		offices = append(offices, models.NearbyOffice{Office: office, DistanceKm: distanceKm})
+	}
+	if err = result.Err(); err != nil {
+		return nil, err
+	}
+	return offices, nil
+}
+
+// officeParams holds the office properties as $office and its location as
+// $location, with $departmentID for the owning department.
+func officeParams(office models.Office) (map[string]interface{}, error) {
+	var email interface{}
+	if office.Email != "" {
+		email = office.Email
+	}
+	hours, closures, err := encodeSchedule(office)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{
+		"departmentID": office.DepartmentID,
+		"office": map[string]interface{}{
+			"name":          office.Name,
+			"phone_numbers": nonNil(office.PhoneNumbers),
+			"email":         email,
+			"hours":         hours,
+			"closures":      closures,
+		},
+	}
+	addLocationParams(params, office.Location)
+	return params, nil
+}
+
+func (r *Neo4jRepository) readOffices(query string, params map[string]interface{}) ([]models.Office, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	offices := []models.Office{}
+	for result.Next(ctx) {
+		office, err := officeFromRecord(result.Record())
+		if err != nil {
+			return nil, err
+		}
+		offices = append(offices, office)
+	}
+
+	if err = result.Err(); err != nil {
+		return nil, err
+	}
+	return offices, nil
+}
+
+// officeFromRecord reads the officeReturn columns.
+func officeFromRecord(record *neo4j.Record) (models.Office, error) {
+	office := models.Office{
+		ID:           int(record.Values[0].(int64)),
+		DepartmentID: int(record.Values[1].(int64)),
+		Location:     locationFromRecord(record, "office"),
+	}
+	office.Name, _ = recordString(record, "name")
+	office.Email, _ = recordString(record, "email")
+	if numbers, ok := record.Values[3].([]interface{}); ok {
+		for _, n := range numbers {
+			if s, ok := n.(string); ok {
+				office.PhoneNumbers = append(office.PhoneNumbers, s)
+			}
+		}
+	}
+	hours, _ := recordString(record, "hours")
+	closures, _ := recordString(record, "closures")
+	if err := decodeSchedule([]byte(hours), []byte(closures), &office); err != nil {
+		return models.Office{}, err
+	}
+	return office, nil
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// orgFilter narrows the ministry and department lists: to ids unless it
+// is nil, to the nodes located in bbox unless it is nil, and to the first
+// limit by id unless limit is 0.
+type orgFilter struct {
+	ids   []int
+	bbox  *models.BBox
+	limit int
+}
+
+func (f orgFilter) params() map[string]interface{} {
+	params := map[string]interface{}{"ids": nil, "bbox": nil}
+	if f.ids != nil {
+		params["ids"] = f.ids
+	}
+	if f.bbox != nil {
+		params["bbox"] = map[string]interface{}{
+			"minLon": f.bbox.MinLon, "minLat": f.bbox.MinLat, "maxLon": f.bbox.MaxLon, "maxLat": f.bbox.MaxLat,
+		}
+	}
+	return params
+}
+
+// query appends the limit to a list query.
+func (f orgFilter) query(query string, params map[string]interface{}) string {
+	if f.limit <= 0 {
+		return query
+	}
+	params["limit"] = int64(f.limit)
+	return query + " LIMIT $limit"
+}
+
+// bboxCypher is true when $bbox is null or the coordinates of node n fall
+// inside it, including boxes that cross the antimeridian, as bboxCondition
+// does in Postgres.
+func bboxCypher(n string) string {
+	return fmt.Sprintf(`($bbox IS NULL OR (
+		%[1]s.latitude >= $bbox.minLat AND %[1]s.latitude <= $bbox.maxLat
+		AND (
+			($bbox.minLon <= $bbox.maxLon AND %[1]s.longitude >= $bbox.minLon AND %[1]s.longitude <= $bbox.maxLon)
+			OR ($bbox.minLon > $bbox.maxLon AND (%[1]s.longitude >= $bbox.minLon OR %[1]s.longitude <= $bbox.maxLon))
+		)
+	))`, n)
+}
+
+// The list queries return the ministry and department columns of
+// GetMinistryByIDWithDepartments and GetDepartmentByID, read by name.
+var (
+	ministriesQuery = `
+		MATCH (m:Ministry)
+		WHERE ($ids IS NULL OR m.id IN $ids) AND ` + bboxCypher("m") + `
+		OPTIONAL MATCH (pm:Ministry)-[:HAS_MINISTRY]->(m)
+		RETURN
+			m.id AS ministry_id,
+			m.name AS ministry_name,
+			m.google_map_script AS ministry_map,
+			m.latitude AS ministry_latitude,
+			m.longitude AS ministry_longitude,
+			m.address AS ministry_address,
+			m.geometry AS ministry_geometry,
+			coalesce(m.kind, 'ministry') AS ministry_kind,
+			pm.id AS ministry_parent_id,
+			m.name_si AS ministry_name_si,
+			m.name_ta AS ministry_name_ta
+		ORDER BY ministry_id
+	`
+
+	ministriesListAsOfQuery = `
+		MATCH (mv:MinistryVersion)
+		WHERE mv.valid_from <= $asOf AND (mv.valid_to IS NULL OR mv.valid_to > $asOf)
+		  AND ($ids IS NULL OR mv.ministry_id IN $ids)
+		OPTIONAL MATCH (m:Ministry {id: mv.ministry_id})
+		WITH mv, m
+		WHERE ` + bboxCypher("m") + `
+		RETURN
+			mv.ministry_id AS ministry_id,
+			mv.name AS ministry_name,
+			m.google_map_script AS ministry_map,
+			m.latitude AS ministry_latitude,
+			m.longitude AS ministry_longitude,
+			m.address AS ministry_address,
+			m.geometry AS ministry_geometry,
+			coalesce(mv.kind, 'ministry') AS ministry_kind,
+			mv.parent_id AS ministry_parent_id,
+			mv.name_si AS ministry_name_si,
+			mv.name_ta AS ministry_name_ta
+		ORDER BY ministry_id
+	`
+
+	departmentsQuery = `
+		MATCH (d:Department)
+		WHERE ($ids IS NULL OR d.id IN $ids) AND ` + bboxCypher("d") + `
+		OPTIONAL MATCH (m:Ministry)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(d)
+		OPTIONAL MATCH (pd:Department)-[:HAS_UNIT]->(d)
+		OPTIONAL MATCH (d)-[:LOCATED_IN]->(da:AdminArea)
+		RETURN
+			d.id AS dept_id,
+			d.name AS dept_name,
+			d.google_map_script AS dept_map,
+			m.id AS ministry_id,
+			d.latitude AS dept_latitude,
+			d.longitude AS dept_longitude,
+			d.address AS dept_address,
+			d.geometry AS dept_geometry,
+			da.id AS dept_admin_area_id,
+			coalesce(d.kind, 'department') AS dept_kind,
+			pd.id AS dept_parent_id,
+			d.name_si AS dept_name_si,
+			d.name_ta AS dept_name_ta
+		ORDER BY dept_id
+	`
+
+	departmentsAsOfQuery = `
+		MATCH (dv:DepartmentVersion)
+		WHERE dv.valid_from <= $asOf AND (dv.valid_to IS NULL OR dv.valid_to > $asOf)
+		  AND ($ids IS NULL OR dv.department_id IN $ids)
+		OPTIONAL MATCH (d:Department {id: dv.department_id})
+		WITH dv, d
+		WHERE ` + bboxCypher("d") + `
+		OPTIONAL MATCH (d)-[:LOCATED_IN]->(da:AdminArea)
+		RETURN
+			dv.department_id AS dept_id,
+			dv.name AS dept_name,
+			d.google_map_script AS dept_map,
+			dv.ministry_id AS ministry_id,
+			d.latitude AS dept_latitude,
+			d.longitude AS dept_longitude,
+			d.address AS dept_address,
+			d.geometry AS dept_geometry,
+			da.id AS dept_admin_area_id,
+			coalesce(dv.kind, 'department') AS dept_kind,
+			dv.parent_id AS dept_parent_id,
+			dv.name_si AS dept_name_si,
+			dv.name_ta AS dept_name_ta
+		ORDER BY dept_id
+	`
+)
+
+// GetAllDepartments returns every department, divisions and units
+// included, ordered by id.
+func (r *Neo4jRepository) GetAllDepartments() ([]models.Department, error) {
+	return r.readDepartments(orgFilter{})
+}
+
+// GetMinistryByID returns a ministry without its departments, or
+// ErrNotFound.
+func (r *Neo4jRepository) GetMinistryByID(id int) (models.Ministry, error) {
+	ministries, err := r.readMinistries(orgFilter{ids: []int{id}})
+	if err != nil {
+		return models.Ministry{}, err
+	}
+	if len(ministries) == 0 {
+		return models.Ministry{}, ErrNotFound
+	}
+	return ministries[0], nil
+}
+
+// GetDepartmentsInBBox returns up to limit departments located inside bbox,
+// ordered by id.
+func (r *Neo4jRepository) GetDepartmentsInBBox(bbox models.BBox, limit int) ([]models.Department, error) {
+	return r.readDepartments(orgFilter{bbox: &bbox, limit: limit})
+}
+
+// GetMinistriesInBBox returns up to limit ministries located inside bbox,
+// ordered by id.
+func (r *Neo4jRepository) GetMinistriesInBBox(bbox models.BBox, limit int) ([]models.Ministry, error) {
+	return r.readMinistries(orgFilter{bbox: &bbox, limit: limit})
+}
+
+func (r *Neo4jRepository) readMinistries(f orgFilter) ([]models.Ministry, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	params := f.params()
+	query := f.query(r.readQuery(ministriesQuery, ministriesListAsOfQuery, params), params)
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	ministries := []models.Ministry{}
+	for result.Next(ctx) {
+		ministries = append(ministries, ministryFromRecord(result.Record()))
+	}
+	return ministries, result.Err()
+}
+
+func (r *Neo4jRepository) readDepartments(f orgFilter) ([]models.Department, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	params := f.params()
+	query := f.query(r.readQuery(departmentsQuery, departmentsAsOfQuery, params), params)
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	departments := []models.Department{}
+	for result.Next(ctx) {
+		departments = append(departments, departmentFromRecord(result.Record()))
+	}
+	return departments, result.Err()
+}
+
+// ministryFromRecord reads the ministry_ columns of a record.
+func ministryFromRecord(record *neo4j.Record) models.Ministry {
+	ministry := models.Ministry{
+		Names:    namesFromRecord(record, "ministry"),
+		Kind:     recordKind(record, "ministry_kind"),
+		ParentID: recordIntPtr(record, "ministry_parent_id"),
+		Location: locationFromRecord(record, "ministry"),
+	}
+	if id := recordIntPtr(record, "ministry_id"); id != nil {
+		ministry.ID = *id
+	}
+	ministry.Name, _ = recordString(record, "ministry_name")
+	ministry.Google_map_script, _ = recordString(record, "ministry_map")
+	return ministry
+}
+
+// departmentFromRecord reads the dept_ columns and ministry_id of a record.
+func departmentFromRecord(record *neo4j.Record) models.Department {
+	dept := models.Department{
+		Names:       namesFromRecord(record, "dept"),
+		Kind:        recordKind(record, "dept_kind"),
+		ParentID:    recordIntPtr(record, "dept_parent_id"),
+		AdminAreaID: recordIntPtr(record, "dept_admin_area_id"),
+		Location:    locationFromRecord(record, "dept"),
+	}
+	if id := recordIntPtr(record, "dept_id"); id != nil {
+		dept.ID = *id
+	}
+	if ministryID := recordIntPtr(record, "ministry_id"); ministryID != nil {
+		dept.MinistryID = *ministryID
+	}
+	dept.Name, _ = recordString(record, "dept_name")
+	dept.Google_map_script, _ = recordString(record, "dept_map")
+	return dept
+}
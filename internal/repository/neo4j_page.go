@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const (
+	ministryIDsQuery     = `MATCH (m:Ministry) RETURN m.id AS id ORDER BY id`
+	ministryIDsAsOfQuery = `
+		MATCH (mv:MinistryVersion)
+		WHERE mv.valid_from <= $asOf AND (mv.valid_to IS NULL OR mv.valid_to > $asOf)
+		RETURN mv.ministry_id AS id ORDER BY id
+	`
+
+	departmentIDsQuery     = `MATCH (d:Department) RETURN d.id AS id ORDER BY id`
+	departmentIDsAsOfQuery = `
+		MATCH (dv:DepartmentVersion)
+		WHERE dv.valid_from <= $asOf AND (dv.valid_to IS NULL OR dv.valid_to > $asOf)
+		RETURN dv.department_id AS id ORDER BY id
+	`
+)
+
+// GetMinistriesPage returns a page of ministries, each with all of its
+// departments. The ministry ids are listed and paged here, then only the
+// ministries on the page are read.
+func (r *Neo4jRepository) GetMinistriesPage(p models.PageRequest) (models.MinistryPage, error) {
+	ids, err := r.readIDs(ministryIDsQuery, ministryIDsAsOfQuery)
+	if err != nil {
+		return models.MinistryPage{}, err
+	}
+
+	page := models.MinistryPage{Limit: p.Limit, Total: len(ids), Items: []models.MinistryWithDepartments{}}
+	var pageIDs []int
+	pageIDs, page.Offset = pageOfIDs(ids, p)
+	if len(pageIDs) == 0 {
+		return page, nil
+	}
+	page.Items, err = r.ministriesWithDepartments(pageIDs)
+	return page, err
+}
+
+// GetDepartmentsPage returns a page of departments.
+func (r *Neo4jRepository) GetDepartmentsPage(p models.PageRequest) (models.DepartmentPage, error) {
+	ids, err := r.readIDs(departmentIDsQuery, departmentIDsAsOfQuery)
+	if err != nil {
+		return models.DepartmentPage{}, err
+	}
+
+	page := models.DepartmentPage{Limit: p.Limit, Total: len(ids), Items: []models.Department{}}
+	var pageIDs []int
+	pageIDs, page.Offset = pageOfIDs(ids, p)
+	if len(pageIDs) == 0 {
+		return page, nil
+	}
+	page.Items, err = r.readDepartments(orgFilter{ids: pageIDs})
+	return page, err
+}
+
+// readIDs runs a read query returning an id column, or its dated variant
+// on an as-of view.
+func (r *Neo4jRepository) readIDs(current, dated string) ([]int, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	params := map[string]interface{}{}
+	result, err := session.Run(ctx, r.readQuery(current, dated, params), params)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int{}
+	for result.Next(ctx) {
+		id, _ := result.Record().Values[0].(int64)
+		ids = append(ids, int(id))
+	}
+	return ids, result.Err()
+}
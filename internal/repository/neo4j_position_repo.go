@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Neo4jPositionRepository stores people as :Person nodes and each term as
+// (:Person)-[:HOLDS_POSITION {id, role, entity, organization_id, start_date,
+// end_date}]->(org), where org is a :Ministry or :Department. Dates are
+// YYYY-MM-DD strings. Terms are found by their entity and organization_id,
+// not by org, so that, as in Postgres, they outlive their organisation:
+// keepTerms moves them to a (:FormerOrganization {entity, id, name}) node
+// before a ministry or department is deleted.
+type Neo4jPositionRepository struct {
+	Driver neo4j.DriverWithContext
+}
+
+func NewNeo4jPositionRepository(driver neo4j.DriverWithContext) *Neo4jPositionRepository {
+	return &Neo4jPositionRepository{Driver: driver}
+}
+
+const positionReturn = `
+	t.id AS id,
+	p.id AS person_id,
+	p.name AS person_name,
+	t.role AS role,
+	t.entity AS entity,
+	t.organization_id AS organization_id,
+	o.name AS organization_name,
+	t.start_date AS start_date,
+	t.end_date AS end_date`
+
+func (r *Neo4jPositionRepository) CreatePerson(person models.Person) (int, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	id, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		id, err := nextID(ctx, tx, "Person")
+		if err != nil {
+			return nil, err
+		}
+		var title interface{}
+		if person.Title != "" {
+			title = person.Title
+		}
+		_, err = tx.Run(ctx, `CREATE (:Person {id: $id, name: $name, title: $title})`, map[string]interface{}{
+			"id": id, "name": person.Name, "title": title,
+		})
+		return id, err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id.(int), nil
+}
+
+func (r *Neo4jPositionRepository) GetPersonByID(id int) (*models.Person, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `MATCH (p:Person {id: $id}) RETURN p.id AS id, p.name AS name, p.title AS title`,
+		map[string]interface{}{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	if !result.Next(ctx) {
+		if err := result.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrNotFound
+	}
+
+	record := result.Record()
+	person := models.Person{ID: int(record.Values[0].(int64))}
+	person.Name, _ = recordString(record, "name")
+	person.Title, _ = recordString(record, "title")
+	return &person, nil
+}
+
+// CreatePosition records a term. The person and the organisation must
+// exist.
+func (r *Neo4jPositionRepository) CreatePosition(position models.Position) (int, error) {
+	label, ok := orgLabels[position.Entity]
+	if !ok {
+		return 0, ErrInvalidReference
+	}
+
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	id, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		id, err := nextID(ctx, tx, "Position")
+		if err != nil {
+			return nil, err
+		}
+
+		var endDate interface{}
+		if !position.EndDate.IsZero() {
+			endDate = position.EndDate.String()
+		}
+		// label comes from orgLabels, never from user input.
+		result, err := tx.Run(ctx, `
+			MATCH (p:Person {id: $personID}), (o:`+label+` {id: $organizationID})
+			CREATE (p)-[t:HOLDS_POSITION {id: $id, role: $role, entity: $entity, organization_id: $organizationID,
+				start_date: $startDate, end_date: $endDate}]->(o)
+			RETURN t.id
+		`, map[string]interface{}{
+			"personID":       position.PersonID,
+			"organizationID": position.OrganizationID,
+			"id":             id,
+			"role":           string(position.Role),
+			"entity":         string(position.Entity),
+			"startDate":      position.StartDate.String(),
+			"endDate":        endDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !result.Next(ctx) {
+			if err := result.Err(); err != nil {
+				return nil, err
+			}
+			return nil, ErrInvalidReference
+		}
+		return id, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id.(int), nil
+}
+
+// EndPosition sets the day a term ends, which must be after it started.
+func (r *Neo4jPositionRepository) EndPosition(id int, endDate models.Date) error {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, `MATCH (:Person)-[t:HOLDS_POSITION {id: $id}]->() RETURN t.start_date`,
+			map[string]interface{}{"id": id})
+		if err != nil {
+			return nil, err
+		}
+		if !result.Next(ctx) {
+			if err := result.Err(); err != nil {
+				return nil, err
+			}
+			return nil, ErrNotFound
+		}
+		start, _ := result.Record().Values[0].(string)
+		startDate, err := models.ParseDate(start)
+		if err != nil {
+			return nil, err
+		}
+		if !endDate.After(startDate) {
+			return nil, ErrInvalidTerm
+		}
+
+		_, err = tx.Run(ctx, `MATCH (:Person)-[t:HOLDS_POSITION {id: $id}]->() SET t.end_date = $endDate`,
+			map[string]interface{}{"id": id, "endDate": endDate.String()})
+		return nil, err
+	})
+	return err
+}
+
+// GetOfficeHolders returns the terms of org that cover date.
+func (r *Neo4jPositionRepository) GetOfficeHolders(org models.OrgRef, role models.PositionRole, date models.Date) ([]models.Position, error) {
+	return r.readPositions(`
+		MATCH (p:Person)-[t:HOLDS_POSITION {entity: $entity, organization_id: $id}]->(o)
+		WHERE ($role = '' OR t.role = $role)
+		  AND t.start_date <= $date AND (t.end_date IS NULL OR t.end_date > $date)
+		RETURN `+positionReturn+`
+		ORDER BY t.start_date, t.id
+	`, map[string]interface{}{"entity": string(org.Entity), "id": org.ID, "role": string(role), "date": date.String()})
+}
+
+func (r *Neo4jPositionRepository) GetPositionHistory(org models.OrgRef, role models.PositionRole) ([]models.Position, error) {
+	return r.readPositions(`
+		MATCH (p:Person)-[t:HOLDS_POSITION {entity: $entity, organization_id: $id}]->(o)
+		WHERE $role = '' OR t.role = $role
+		RETURN `+positionReturn+`
+		ORDER BY t.start_date, t.id
+	`, map[string]interface{}{"entity": string(org.Entity), "id": org.ID, "role": string(role)})
+}
+
+// GetPersonPositions returns the portfolio history of a person.
+func (r *Neo4jPositionRepository) GetPersonPositions(personID int) ([]models.Position, error) {
+	if _, err := r.GetPersonByID(personID); err != nil {
+		return nil, err
+	}
+	return r.readPositions(`
+		MATCH (p:Person {id: $id})-[t:HOLDS_POSITION]->(o)
+		RETURN `+positionReturn+`
+		ORDER BY t.start_date, t.id
+	`, map[string]interface{}{"id": personID})
+}
+
+// keepTerms moves the terms held in the label nodes with ids to their
+// FormerOrganization nodes, which keep the name the organisation had last,
+// so that deleting the nodes leaves the terms in place.
+func (c changeTx) keepTerms(label string, ids []int) error {
+	entity := models.EntityDepartment
+	if label == "Ministry" {
+		entity = models.EntityMinistry
+	}
+	// label is one of our fixed node labels, never user input.
+	return c.run(`
+		MATCH (p:Person)-[t:HOLDS_POSITION]->(o:`+label+`) WHERE o.id IN $ids
+		MERGE (f:FormerOrganization {entity: $entity, id: o.id})
+		SET f.name = o.name
+		CREATE (p)-[k:HOLDS_POSITION]->(f)
+		SET k = properties(t)
+		DELETE t
+	`, map[string]interface{}{"ids": ids, "entity": string(entity)})
+}
+
+func (r *Neo4jPositionRepository) readPositions(query string, params map[string]interface{}) ([]models.Position, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := []models.Position{}
+	for result.Next(ctx) {
+		record := result.Record()
+
+		position := models.Position{
+			ID:             int(record.Values[0].(int64)),
+			PersonID:       int(record.Values[1].(int64)),
+			OrganizationID: int(record.Values[5].(int64)),
+		}
+		position.PersonName, _ = recordString(record, "person_name")
+		role, _ := recordString(record, "role")
+		position.Role = models.PositionRole(role)
+		entity, _ := recordString(record, "entity")
+		position.Entity = models.OrgEntity(entity)
+		position.OrganizationName, _ = recordString(record, "organization_name")
+		if position.StartDate, err = recordDate(record, "start_date"); err != nil {
+			return nil, err
+		}
+		if position.EndDate, err = recordDate(record, "end_date"); err != nil {
+			return nil, err
+		}
+		positions = append(positions, position)
+	}
+
+	if err = result.Err(); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
@@ -13,6 +13,8 @@ import (
 
 type Neo4jRepository struct {
 	Driver neo4j.DriverWithContext
+	// asOf, when set, makes reads answer from the version nodes; see AsOf.
+	asOf *models.Date
 }
 
 func NewNeo4jRepository(driver neo4j.DriverWithContext) *Neo4jRepository {
@@ -20,28 +22,63 @@ func NewNeo4jRepository(driver neo4j.DriverWithContext) *Neo4jRepository {
 }
 
 func (r *Neo4jRepository) GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error) {
+	return r.ministriesWithDepartments(nil)
+}
+
+// ministriesWithDepartments reads the ministries with ids, or all of them
+// when ids is nil, ordered by id.
+func (r *Neo4jRepository) ministriesWithDepartments(ids []int) ([]models.MinistryWithDepartments, error) {
 	ctx := context.Background()
 	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
 	query := `
-		MATCH (m:Ministry)-[:HAS_DEPARTMENT]->(d:Department)
+		MATCH (m:Ministry)
+		WHERE $ids IS NULL OR m.id IN $ids
+		OPTIONAL MATCH (pm:Ministry)-[:HAS_MINISTRY]->(m)
+		OPTIONAL MATCH (m)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(d:Department)
+		OPTIONAL MATCH (pd:Department)-[:HAS_UNIT]->(d)
+		OPTIONAL MATCH (d)-[:LOCATED_IN]->(da:AdminArea)
 		RETURN
 			m.id AS ministry_id,
 			m.name AS ministry_name,
 			m.google_map_script AS ministry_map,
 			d.id AS dept_id,
 			d.name AS dept_name,
-			d.google_map_script AS dept_map
-		ORDER BY m.id
+			d.google_map_script AS dept_map,
+			m.latitude AS ministry_latitude,
+			m.longitude AS ministry_longitude,
+			m.address AS ministry_address,
+			m.geometry AS ministry_geometry,
+			d.latitude AS dept_latitude,
+			d.longitude AS dept_longitude,
+			d.address AS dept_address,
+			d.geometry AS dept_geometry,
+			da.id AS dept_admin_area_id,
+			coalesce(m.kind, 'ministry') AS ministry_kind,
+			pm.id AS ministry_parent_id,
+			coalesce(d.kind, 'department') AS dept_kind,
+			pd.id AS dept_parent_id,
+			d.name_si AS dept_name_si,
+			d.name_ta AS dept_name_ta,
+			m.name_si AS ministry_name_si,
+			m.name_ta AS ministry_name_ta
+		ORDER BY m.id, d.id
 	`
 
-	result, err := session.Run(ctx, query, nil)
+	params := map[string]interface{}{"ids": nil}
+	if ids != nil {
+		params["ids"] = ids
+	}
+	query = r.readQuery(query, ministriesAsOfQuery, params)
+
+	result, err := session.Run(ctx, query, params)
 	if err != nil {
 		return nil, err
 	}
 
 	ministryMap := make(map[int]*models.MinistryWithDepartments)
+	var order []int
 
 	for result.Next(ctx) {
 		record := result.Record()
@@ -54,29 +91,43 @@ func (r *Neo4jRepository) GetMinistriesWithDepartments() ([]models.MinistryWithD
 			ministryMapScript = record.Values[2].(string)
 		}
 
-		deptID := int(record.Values[3].(int64))
-		deptName := record.Values[4].(string)
-
-		deptMap := ""
-		if record.Values[5] != nil {
-			deptMap = record.Values[5].(string)
-		}
-
 		if _, exists := ministryMap[ministryID]; !exists {
+			order = append(order, ministryID)
 			ministryMap[ministryID] = &models.MinistryWithDepartments{
 				Ministry: models.Ministry{
 					ID:                ministryID,
 					Name:              ministryName,
+					Names:             namesFromRecord(record, "ministry"),
+					Kind:              recordKind(record, "ministry_kind"),
+					ParentID:          recordIntPtr(record, "ministry_parent_id"),
 					Google_map_script: ministryMapScript,
+					Location:          locationFromRecord(record, "ministry"),
 				},
 			}
 		}
 
+		if record.Values[3] == nil { // ministry without departments
+			continue
+		}
+
+		deptID := int(record.Values[3].(int64))
+		deptName := record.Values[4].(string)
+
+		deptMap := ""
+		if record.Values[5] != nil {
+			deptMap = record.Values[5].(string)
+		}
+
 		department := models.Department{
 			ID:                deptID,
 			Name:              deptName,
+			Names:             namesFromRecord(record, "dept"),
+			Kind:              recordKind(record, "dept_kind"),
+			ParentID:          recordIntPtr(record, "dept_parent_id"),
 			MinistryID:        ministryID,
 			Google_map_script: deptMap,
+			AdminAreaID:       recordIntPtr(record, "dept_admin_area_id"),
+			Location:          locationFromRecord(record, "dept"),
 		}
 
 		ministryMap[ministryID].Departments = append(ministryMap[ministryID].Departments, department)
@@ -86,9 +137,9 @@ func (r *Neo4jRepository) GetMinistriesWithDepartments() ([]models.MinistryWithD
 		return nil, err
 	}
 
-	var ministries []models.MinistryWithDepartments
-	for _, m := range ministryMap {
-		ministries = append(ministries, *m)
+	ministries := []models.MinistryWithDepartments{}
+	for _, id := range order {
+		ministries = append(ministries, *ministryMap[id])
 	}
 
 	return ministries, nil
@@ -101,20 +152,41 @@ func (r *Neo4jRepository) GetMinistryByIDWithDepartments(ministryID int) (models
 
 	query := `
 		MATCH (m:Ministry {id: $id})
-		OPTIONAL MATCH (m)-[:HAS_DEPARTMENT]->(d:Department)
+		OPTIONAL MATCH (pm:Ministry)-[:HAS_MINISTRY]->(m)
+		OPTIONAL MATCH (m)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(d:Department)
+		OPTIONAL MATCH (pd:Department)-[:HAS_UNIT]->(d)
+		OPTIONAL MATCH (d)-[:LOCATED_IN]->(da:AdminArea)
 		RETURN
 			m.id AS ministry_id,
 			m.name AS ministry_name,
 			m.google_map_script AS ministry_map,
 			d.id AS dept_id,
 			d.name AS dept_name,
-			d.google_map_script AS dept_map
+			d.google_map_script AS dept_map,
+			m.latitude AS ministry_latitude,
+			m.longitude AS ministry_longitude,
+			m.address AS ministry_address,
+			m.geometry AS ministry_geometry,
+			d.latitude AS dept_latitude,
+			d.longitude AS dept_longitude,
+			d.address AS dept_address,
+			d.geometry AS dept_geometry,
+			da.id AS dept_admin_area_id,
+			coalesce(m.kind, 'ministry') AS ministry_kind,
+			pm.id AS ministry_parent_id,
+			coalesce(d.kind, 'department') AS dept_kind,
+			pd.id AS dept_parent_id,
+			d.name_si AS dept_name_si,
+			d.name_ta AS dept_name_ta,
+			m.name_si AS ministry_name_si,
+			m.name_ta AS ministry_name_ta
 		ORDER BY d.id
 	`
 
 	params := map[string]interface{}{
 		"id": ministryID,
 	}
+	query = r.readQuery(query, ministryAsOfQuery, params)
 
 	result, err := session.Run(ctx, query, params)
 	if err != nil {
@@ -134,6 +206,10 @@ func (r *Neo4jRepository) GetMinistryByIDWithDepartments(ministryID int) (models
 			if record.Values[2] != nil {
 				ministryWithDepts.Ministry.Google_map_script = record.Values[2].(string)
 			}
+			ministryWithDepts.Ministry.Names = namesFromRecord(record, "ministry")
+			ministryWithDepts.Ministry.Kind = recordKind(record, "ministry_kind")
+			ministryWithDepts.Ministry.ParentID = recordIntPtr(record, "ministry_parent_id")
+			ministryWithDepts.Ministry.Location = locationFromRecord(record, "ministry")
 			foundMinistry = true
 		}
 
@@ -148,8 +224,13 @@ func (r *Neo4jRepository) GetMinistryByIDWithDepartments(ministryID int) (models
 			department := models.Department{
 				ID:                deptID,
 				Name:              deptName,
+				Names:             namesFromRecord(record, "dept"),
+				Kind:              recordKind(record, "dept_kind"),
+				ParentID:          recordIntPtr(record, "dept_parent_id"),
 				MinistryID:        ministryID,
 				Google_map_script: deptMap,
+				AdminAreaID:       recordIntPtr(record, "dept_admin_area_id"),
+				Location:          locationFromRecord(record, "dept"),
 			}
 			ministryWithDepts.Departments = append(ministryWithDepts.Departments, department)
 		}
@@ -160,11 +241,180 @@ func (r *Neo4jRepository) GetMinistryByIDWithDepartments(ministryID int) (models
 	}
 
 	if !foundMinistry {
-		return models.MinistryWithDepartments{}, fmt.Errorf("ministry with ID %d not found", ministryID)
+		return models.MinistryWithDepartments{}, ErrNotFound
 	}
 
 	return ministryWithDepts, nil
 }
+
+// GetDepartmentByID returns a department with its offices, or nil if there
+// is none. Offices are not versioned, so dated reads leave them out.
+func (r *Neo4jRepository) GetDepartmentByID(id int) (*models.Department, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (d:Department {id: $id})
+		OPTIONAL MATCH (m:Ministry)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(d)
+		OPTIONAL MATCH (pd:Department)-[:HAS_UNIT]->(d)
+		OPTIONAL MATCH (d)-[:LOCATED_IN]->(da:AdminArea)
+		RETURN
+			d.id AS dept_id,
+			d.name AS dept_name,
+			d.google_map_script AS dept_map,
+			m.id AS ministry_id,
+			d.latitude AS dept_latitude,
+			d.longitude AS dept_longitude,
+			d.address AS dept_address,
+			d.geometry AS dept_geometry,
+			da.id AS dept_admin_area_id,
+			coalesce(d.kind, 'department') AS dept_kind,
+			pd.id AS dept_parent_id,
+			d.name_si AS dept_name_si,
+			d.name_ta AS dept_name_ta
+	`
+
+	params := map[string]interface{}{"id": id}
+	query = r.readQuery(query, departmentAsOfQuery, params)
+
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Next(ctx) {
+		return nil, result.Err()
+	}
+
+	dept := departmentFromRecord(result.Record())
+	if r.asOf == nil {
+		if dept.Offices, err = r.GetDepartmentOffices(id); err != nil {
+			return nil, err
+		}
+	}
+	return &dept, nil
+}
+
+// FindDepartmentsNearby returns the departments within q.RadiusKm of the
+// query point, nearest first, using point.distance on WGS-84 points.
+func (r *Neo4jRepository) FindDepartmentsNearby(q models.NearbyQuery) ([]models.NearbyDepartment, error) {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (d:Department)
+		WHERE d.latitude IS NOT NULL AND d.longitude IS NOT NULL
+		OPTIONAL MATCH (m:Ministry)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(d)
+		OPTIONAL MATCH (pd:Department)-[:HAS_UNIT]->(d)
+		OPTIONAL MATCH (d)-[:LOCATED_IN]->(da:AdminArea)
+		WITH m, d, pd, da, point.distance(
+			point({latitude: d.latitude, longitude: d.longitude}),
+			point({latitude: $lat, longitude: $lon})
+		) / 1000.0 AS distance_km
+		WHERE distance_km <= $radius
+		RETURN
+			d.id AS dept_id,
+			d.name AS dept_name,
+			d.google_map_script AS dept_map,
+			m.id AS ministry_id,
+			d.latitude AS dept_latitude,
+			d.longitude AS dept_longitude,
+			d.address AS dept_address,
+			d.geometry AS dept_geometry,
+			distance_km,
+			da.id AS dept_admin_area_id,
+			coalesce(d.kind, 'department') AS dept_kind,
+			pd.id AS dept_parent_id,
+			d.name_si AS dept_name_si,
+			d.name_ta AS dept_name_ta
+		ORDER BY distance_km, d.id
+		LIMIT $limit
+	`
+
+	params := map[string]interface{}{
+		"lat":    q.Latitude,
+		"lon":    q.Longitude,
+		"radius": q.RadiusKm,
+		"limit":  int64(q.Limit),
+	}
+	query = r.readQuery(query, departmentsNearbyAsOfQuery, params)
+
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	departments := []models.NearbyDepartment{}
+	for result.Next(ctx) {
+		record := result.Record()
+
+		dept := models.NearbyDepartment{
+			Department: models.Department{
+				ID:          int(record.Values[0].(int64)),
+				Name:        record.Values[1].(string),
+				Names:       namesFromRecord(record, "dept"),
+				Kind:        recordKind(record, "dept_kind"),
+				ParentID:    recordIntPtr(record, "dept_parent_id"),
+				AdminAreaID: recordIntPtr(record, "dept_admin_area_id"),
+				Location:    locationFromRecord(record, "dept"),
+			},
+			DistanceKm: record.Values[8].(float64),
+		}
+		if record.Values[2] != nil {
+			dept.Google_map_script = record.Values[2].(string)
+		}
+		if record.Values[3] != nil {
+			dept.MinistryID = int(record.Values[3].(int64))
+		}
+		departments = append(departments, dept)
+	}
+
+	if err = result.Err(); err != nil {
+		return nil, err
+	}
+	return departments, nil
+}
+
+func (r *Neo4jRepository) UpdateMinistryLocation(id int, loc models.Location) error {
+	return r.updateLocation("Ministry", id, loc)
+}
+
+func (r *Neo4jRepository) UpdateDepartmentLocation(id int, loc models.Location) error {
+	return r.updateLocation("Department", id, loc)
+}
+
+func (r *Neo4jRepository) updateLocation(label string, id int, loc models.Location) error {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	params := map[string]interface{}{"id": id}
+	addLocationParams(params, loc)
+
+	// label is one of our fixed node labels, never user input.
+	query := fmt.Sprintf(`
+		MATCH (n:%s {id: $id})
+		SET n += $location
+		RETURN n.id
+	`, label)
+
+	found, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return false, err
+		}
+		return result.Next(ctx), result.Err()
+	})
+	if err != nil {
+		return err
+	}
+	if !found.(bool) {
+		return ErrNotFound
+	}
+	return nil
+}
+
 func generateRandomMinistryName() string {
 	prefixes := []string{"Ministry of", "Department of", "Office of"}
 	topics := []string{"Innovation", "Agriculture", "Wellbeing", "Technology", "Security", "Environment", "Commerce"}
@@ -177,10 +427,27 @@ func generateRandomDepartmentName() string {
 	return fmt.Sprintf("%s Department", randomItem(roles))
 }
 
+func generateRandomDivisionName() string {
+	roles := []string{"Finance", "Administration", "Legal", "Procurement", "Internal Audit", "IT"}
+	return fmt.Sprintf("%s Division", randomItem(roles))
+}
+
 func randomItem(list []string) string {
 	return list[rand.Intn(len(list))]
 }
 
+// randomSriLankaLocation returns a point inside Sri Lanka's bounding box.
+func randomSriLankaLocation() models.Location {
+	lat := 5.92 + rand.Float64()*(9.83-5.92)
+	lon := 79.65 + rand.Float64()*(81.88-79.65)
+	cities := []string{"Colombo", "Kandy", "Galle", "Jaffna", "Kurunegala", "Batticaloa", "Anuradhapura"}
+	return models.Location{
+		Latitude:  &lat,
+		Longitude: &lon,
+		Address:   fmt.Sprintf("%d Main Street, %s", rand.Intn(500)+1, randomItem(cities)),
+	}
+}
+
 func (r *Neo4jRepository) SeedDummyData() error {
 	ctx := context.Background()
 	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
@@ -190,6 +457,8 @@ func (r *Neo4jRepository) SeedDummyData() error {
 
 	numMinistries := 200
 	departmentsPerMinistry := 10
+	stateMinistries := 20       // placed under the first ministries
+	divisionsPerDepartment := 2 // under the first department of each ministry
 
 	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		deptGlobalID := 1 // ensure unique department IDs globally
@@ -200,13 +469,16 @@ func (r *Neo4jRepository) SeedDummyData() error {
 			ministryMap := fmt.Sprintf("<script src='map/%d.js'></script>", ministryID)
 
 			// Create Ministry
-			_, err := tx.Run(ctx, `
-				CREATE (m:Ministry {id: $id, name: $name, google_map_script: $map})
-			`, map[string]interface{}{
+			params := map[string]interface{}{
 				"id":   ministryID,
 				"name": ministryName,
 				"map":  ministryMap,
-			})
+			}
+			addLocationParams(params, randomSriLankaLocation())
+			_, err := tx.Run(ctx, `
+				CREATE (m:Ministry {id: $id, name: $name, kind: 'ministry', google_map_script: $map})
+				SET m += $location
+			`, params)
 			if err != nil {
 				return nil, err
 			}
@@ -217,28 +489,82 @@ func (r *Neo4jRepository) SeedDummyData() error {
 				deptName := generateRandomDepartmentName()
 				deptMap := fmt.Sprintf("<script src='dept/%d.js'></script>", deptID)
 
+				params := map[string]interface{}{
+					"ministryID": ministryID,
+					"deptID":     deptID,
+					"name":       deptName,
+					"map":        deptMap,
+				}
+				addLocationParams(params, randomSriLankaLocation())
 				_, err := tx.Run(ctx, `
 					MATCH (m:Ministry {id: $ministryID})
 					CREATE (d:Department {
 						id: $deptID,
 						name: $name,
+						kind: 'department',
 						google_map_script: $map
 					})
+					SET d += $location
 					CREATE (m)-[:HAS_DEPARTMENT]->(d)
-				`, map[string]interface{}{
-					"ministryID": ministryID,
-					"deptID":     deptID,
-					"name":       deptName,
-					"map":        deptMap,
-				})
+				`, params)
 				if err != nil {
 					return nil, err
 				}
 
 				deptGlobalID++
+
+				if j > 0 {
+					continue
+				}
+
+				// Divisions hang off their department only; their ministry
+				// is reached through (m)-[:HAS_DEPARTMENT]->()-[:HAS_UNIT*]->(u).
+				for k := 0; k < divisionsPerDepartment; k++ {
+					params := map[string]interface{}{
+						"parentID": deptID,
+						"id":       deptGlobalID,
+						"name":     generateRandomDivisionName(),
+					}
+					addLocationParams(params, randomSriLankaLocation())
+					_, err := tx.Run(ctx, `
+						MATCH (d:Department {id: $parentID})
+						CREATE (u:Department {id: $id, name: $name, kind: 'division'})
+						SET u += $location
+						CREATE (d)-[:HAS_UNIT]->(u)
+					`, params)
+					if err != nil {
+						return nil, err
+					}
+					deptGlobalID++
+				}
 			}
 		}
-		return nil, nil
+
+		for i := 1; i <= stateMinistries; i++ {
+			params := map[string]interface{}{
+				"parentID": i,
+				"id":       numMinistries + i,
+				"name":     "State " + generateRandomMinistryName(),
+			}
+			addLocationParams(params, randomSriLankaLocation())
+			_, err := tx.Run(ctx, `
+				MATCH (m:Ministry {id: $parentID})
+				CREATE (s:Ministry {id: $id, name: $name, kind: 'state_ministry'})
+				SET s += $location
+				CREATE (m)-[:HAS_MINISTRY]->(s)
+			`, params)
+			if err != nil {
+				return nil, err
+			}
+		}
+		changed := changedIDs{}
+		for id := 1; id <= numMinistries+stateMinistries; id++ {
+			changed.add(ministryVersionNodes, id)
+		}
+		for id := 1; id < deptGlobalID; id++ {
+			changed.add(departmentVersionNodes, id)
+		}
+		return nil, recordVersions(ctx, tx, models.Today(), changed)
 	})
 
 	return err
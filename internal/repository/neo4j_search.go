@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/search"
+	"go-mysql-backend/internal/translit"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// searchIndex is the full-text index over the names, phonetic keys and
+// addresses of ministries, departments and offices. The Neo4j migrations
+// create it; full-text indexes cannot be altered, so the name changes,
+// in a new migration, whenever the indexed properties do.
+const searchIndex = "organization_search_v2"
+
+// luceneSearchQuery matches the terms of text against every indexed
+// property, or its phonetic keys against name_key.
+func luceneSearchQuery(text string, terms []string) string {
+	query := search.LuceneQuery(terms)
+	if keys := translit.Keys(text); len(keys) > 0 {
+		query = "(" + query + ") OR name_key:(" + search.LuceneQuery(keys) + ")"
+	}
+	return query
+}
+
+// Search returns the best matches for q from the full-text index, with
+// snippets highlighted here since Lucene scores but does not highlight.
+// Like the Postgres search it always reads the current structure.
+func (r *Neo4jRepository) Search(q models.SearchQuery) ([]models.SearchHit, error) {
+	terms := search.Terms(q.Text)
+	if len(terms) == 0 {
+		return []models.SearchHit{}, nil
+	}
+	labels := make([]string, len(q.Entities))
+	for i, entity := range q.Entities {
+		labels[i] = string(entity)
+	}
+
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `
+		CALL db.index.fulltext.queryNodes('`+searchIndex+`', $query) YIELD node, score
+		WITH node, score,
+			CASE WHEN node:Ministry THEN 'ministry' WHEN node:Department THEN 'department' ELSE 'office' END AS entity,
+			CASE WHEN node:Ministry THEN 1 WHEN node:Department THEN 2 ELSE 3 END AS entity_order
+		WHERE entity IN $entities
+		OPTIONAL MATCH (m:Ministry)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(node:Department)
+		OPTIONAL MATCH (d:Department)-[:HAS_OFFICE]->(node:Office)
+		RETURN
+			entity,
+			node.id AS id,
+			node.name AS name,
+			node.name_si AS name_si,
+			node.name_ta AS name_ta,
+			node.address AS address,
+			coalesce(m.id, d.id) AS parent_id,
+			score
+		ORDER BY score DESC, entity_order, id
+		LIMIT $limit
+	`, map[string]interface{}{
+		"query":    luceneSearchQuery(q.Text, terms),
+		"entities": labels,
+		"limit":    int64(q.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hits := []models.SearchHit{}
+	for result.Next(ctx) {
+		record := result.Record()
+
+		entity, _ := recordString(record, "entity")
+		hit := models.SearchHit{
+			Entity:   models.SearchEntity(entity),
+			ID:       int(record.Values[1].(int64)),
+			ParentID: recordIntPtr(record, "parent_id"),
+			Score:    record.Values[7].(float64),
+		}
+		hit.Name, _ = recordString(record, "name")
+		if hit.Entity != models.SearchOffice {
+			hit.Names = namesFromRecord(record, "")
+		}
+
+		text := []string{hit.Name}
+		for _, key := range []string{"name_si", "name_ta", "address"} {
+			if value, ok := recordString(record, key); ok && value != "" {
+				text = append(text, value)
+			}
+		}
+		hit.Snippet = search.Highlight(strings.Join(text, " · "), terms)
+		hits = append(hits, hit)
+	}
+	return hits, result.Err()
+}
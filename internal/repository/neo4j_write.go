@@ -0,0 +1,363 @@
+package repository
+
+import (
+	"context"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// The writes below keep the same rules as their Postgres counterparts. A
+// department's ministry is not stored on the node: a top-level department
+// hangs off its ministry with HAS_DEPARTMENT and a division off its parent
+// with HAS_UNIT, so moving a department moves everything below it.
+
+// change runs fn in one write transaction as a change effective today,
+// then opens versions for the nodes fn closed versions of or created.
+func (r *Neo4jRepository) change(fn func(c changeTx) error) error {
+	ctx := context.Background()
+	session := r.Driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		c := newChangeTx(ctx, tx, models.Today())
+		if err := fn(c); err != nil {
+			return nil, err
+		}
+		return nil, recordVersions(ctx, tx, c.effective, c.changed)
+	})
+	return err
+}
+
+func (r *Neo4jRepository) CreateMinistry(ministry models.Ministry) (int, error) {
+	var id int
+	err := r.change(func(c changeTx) (err error) {
+		id, err = c.createMinistry(ministry)
+		return err
+	})
+	return id, err
+}
+
+func (r *Neo4jRepository) CreateDepartment(dept models.Department) (int, error) {
+	var id int
+	err := r.change(func(c changeTx) (err error) {
+		id, err = c.createDepartment(dept)
+		return err
+	})
+	return id, err
+}
+
+func (r *Neo4jRepository) UpdateMinistry(ministry models.Ministry) error {
+	return r.change(func(c changeTx) error {
+		return c.updateMinistry(ministry)
+	})
+}
+
+// UpdateDepartment saves dept. When its ministry changes, the divisions and
+// units below it move to the new ministry as well.
+func (r *Neo4jRepository) UpdateDepartment(dept models.Department) error {
+	return r.change(func(c changeTx) error {
+		return c.updateDepartment(dept)
+	})
+}
+
+// DeleteMinistry removes a ministry. What happens to its departments is
+// decided by mode; reassignTo is only used with models.DeleteReassign.
+func (r *Neo4jRepository) DeleteMinistry(id int, mode models.MinistryDeleteMode, reassignTo int) error {
+	return r.change(func(c changeTx) error {
+		return c.deleteMinistry(id, mode, reassignTo)
+	})
+}
+
+// DeleteDepartment removes a department and its offices. Its divisions
+// become top-level departments of its ministry.
+func (r *Neo4jRepository) DeleteDepartment(id int) error {
+	return r.change(func(c changeTx) error {
+		if err := c.expect("Department", id); err != nil {
+			return err
+		}
+		units, err := c.ids(`MATCH (:Department {id: $id})-[:HAS_UNIT]->(u:Department) RETURN u.id`, id)
+		if err != nil {
+			return err
+		}
+		if err := c.closeVersions(departmentVersionNodes, append([]int{id}, units...)); err != nil {
+			return err
+		}
+		return c.dissolve(id)
+	})
+}
+
+func (c changeTx) createMinistry(ministry models.Ministry) (int, error) {
+	if ministry.ParentID != nil {
+		if err := c.checkMinistryParent(0, *ministry.ParentID); err != nil {
+			return 0, err
+		}
+	}
+
+	id, err := nextID(c.ctx, c.tx, "Ministry")
+	if err != nil {
+		return 0, err
+	}
+	ministry.ID = id
+	if err := c.run(`CREATE (:Ministry {id: $id})`, map[string]interface{}{"id": id}); err != nil {
+		return 0, err
+	}
+	c.changed.add(ministryVersionNodes, id)
+	return id, c.saveMinistry(ministry)
+}
+
+func (c changeTx) createDepartment(dept models.Department) (int, error) {
+	if err := c.checkDepartmentRefs(0, dept); err != nil {
+		return 0, err
+	}
+
+	id, err := nextID(c.ctx, c.tx, "Department")
+	if err != nil {
+		return 0, err
+	}
+	dept.ID = id
+	if err := c.run(`CREATE (:Department {id: $id})`, map[string]interface{}{"id": id}); err != nil {
+		return 0, err
+	}
+	c.changed.add(departmentVersionNodes, id)
+	return id, c.saveDepartment(dept)
+}
+
+func (c changeTx) updateMinistry(ministry models.Ministry) error {
+	if err := c.expect("Ministry", ministry.ID); err != nil {
+		return err
+	}
+	if ministry.ParentID != nil {
+		if err := c.checkMinistryParent(ministry.ID, *ministry.ParentID); err != nil {
+			return err
+		}
+	}
+	if err := c.closeVersions(ministryVersionNodes, []int{ministry.ID}); err != nil {
+		return err
+	}
+	return c.saveMinistry(ministry)
+}
+
+func (c changeTx) updateDepartment(dept models.Department) error {
+	if err := c.expect("Department", dept.ID); err != nil {
+		return err
+	}
+	if err := c.checkDepartmentRefs(dept.ID, dept); err != nil {
+		return err
+	}
+
+	// The versions below the department change only when its ministry does.
+	changed := []int{dept.ID}
+	ministries, err := c.ids(`
+		MATCH (m:Ministry)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(:Department {id: $id})
+		RETURN m.id
+	`, dept.ID)
+	if err != nil {
+		return err
+	}
+	if !containsID(ministries, dept.MinistryID) {
+		if changed, err = c.ids(`MATCH (:Department {id: $id})-[:HAS_UNIT*0..]->(u:Department) RETURN u.id`, dept.ID); err != nil {
+			return err
+		}
+	}
+	if err := c.closeVersions(departmentVersionNodes, changed); err != nil {
+		return err
+	}
+	return c.saveDepartment(dept)
+}
+
+func (c changeTx) deleteMinistry(id int, mode models.MinistryDeleteMode, reassignTo int) error {
+	if err := c.expect("Ministry", id); err != nil {
+		return err
+	}
+
+	departments, err := c.ids(`
+		MATCH (:Ministry {id: $id})-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(d:Department)
+		RETURN d.id
+	`, id)
+	if err != nil {
+		return err
+	}
+	subMinistries, err := c.ids(`MATCH (:Ministry {id: $id})-[:HAS_MINISTRY]->(s:Ministry) RETURN s.id`, id)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case models.DeleteCascade:
+		if err := c.closeVersions(departmentVersionNodes, departments); err != nil {
+			return err
+		}
+		params := map[string]interface{}{"ids": departments}
+		if err := c.run(`MATCH (d:Department)-[:HAS_OFFICE]->(o:Office) WHERE d.id IN $ids DETACH DELETE o`, params); err != nil {
+			return err
+		}
+		if err := c.keepTerms("Department", departments); err != nil {
+			return err
+		}
+		if err := c.run(`MATCH (d:Department) WHERE d.id IN $ids DETACH DELETE d`, params); err != nil {
+			return err
+		}
+	case models.DeleteReassign:
+		if reassignTo == id {
+			return ErrInvalidReassignTarget
+		}
+		if err := c.expect("Ministry", reassignTo); err != nil {
+			return ErrInvalidReassignTarget
+		}
+		if err := c.closeVersions(departmentVersionNodes, departments); err != nil {
+			return err
+		}
+		err := c.run(`
+			MATCH (:Ministry {id: $id})-[r:HAS_DEPARTMENT]->(d:Department), (t:Ministry {id: $reassignTo})
+			DELETE r
+			CREATE (t)-[:HAS_DEPARTMENT]->(d)
+		`, map[string]interface{}{"id": id, "reassignTo": reassignTo})
+		if err != nil {
+			return err
+		}
+	default:
+		if len(departments) > 0 {
+			return ErrMinistryHasDepartments
+		}
+	}
+
+	// State ministries below it are detached and become top-level.
+	if err := c.closeVersions(ministryVersionNodes, append([]int{id}, subMinistries...)); err != nil {
+		return err
+	}
+	if err := c.keepTerms("Ministry", []int{id}); err != nil {
+		return err
+	}
+	return c.run(`MATCH (m:Ministry {id: $id}) DETACH DELETE m`, map[string]interface{}{"id": id})
+}
+
+// checkMinistryParent rejects a parent ministry that does not exist or
+// would put the ministry id below itself; id is 0 for a new ministry.
+func (c changeTx) checkMinistryParent(id, parentID int) error {
+	if err := c.expect("Ministry", parentID); err != nil {
+		return ErrInvalidReference
+	}
+	if id == 0 {
+		return nil
+	}
+	below, err := c.ids(`MATCH (:Ministry {id: $id})-[:HAS_MINISTRY*0..]->(s:Ministry) RETURN s.id`, id)
+	if err != nil {
+		return err
+	}
+	if containsID(below, parentID) {
+		return ErrInvalidParent
+	}
+	return nil
+}
+
+// checkDepartmentRefs rejects a department whose ministry, parent or area
+// does not exist, or whose parent belongs to another ministry or would put
+// the department id below itself; id is 0 for a new department.
+func (c changeTx) checkDepartmentRefs(id int, dept models.Department) error {
+	if err := c.expect("Ministry", dept.MinistryID); err != nil {
+		return ErrInvalidReference
+	}
+	if dept.AdminAreaID != nil {
+		if err := c.expect("AdminArea", *dept.AdminAreaID); err != nil {
+			return ErrInvalidReference
+		}
+	}
+	if dept.ParentID == nil {
+		return nil
+	}
+
+	parentID := *dept.ParentID
+	if err := c.expect("Department", parentID); err != nil {
+		return ErrInvalidReference
+	}
+	ministries, err := c.ids(`
+		MATCH (m:Ministry)-[:HAS_DEPARTMENT]->(:Department)-[:HAS_UNIT*0..]->(:Department {id: $id})
+		RETURN m.id
+	`, parentID)
+	if err != nil {
+		return err
+	}
+	if !containsID(ministries, dept.MinistryID) {
+		return ErrInvalidParent
+	}
+	if id == 0 {
+		return nil
+	}
+	below, err := c.ids(`MATCH (:Department {id: $id})-[:HAS_UNIT*0..]->(u:Department) RETURN u.id`, id)
+	if err != nil {
+		return err
+	}
+	if containsID(below, parentID) {
+		return ErrInvalidParent
+	}
+	return nil
+}
+
+// saveMinistry writes the properties of an existing ministry node and
+// links it to its parent.
+func (c changeTx) saveMinistry(ministry models.Ministry) error {
+	kind := ministry.Kind
+	if kind == "" {
+		kind = models.KindMinistry
+	}
+	params := map[string]interface{}{
+		"id":       ministry.ID,
+		"parentID": ministry.ParentID,
+		"ministry": map[string]interface{}{
+			"name": ministry.Name, "kind": string(kind), "google_map_script": ministry.Google_map_script,
+		},
+	}
+	addNamesParams(params, ministry.Name, ministry.Names)
+	addLocationParams(params, ministry.Location)
+
+	return c.run(`
+		MATCH (m:Ministry {id: $id})
+		SET m += $ministry, m += $names, m += $location
+		WITH m
+		OPTIONAL MATCH (:Ministry)-[r:HAS_MINISTRY]->(m)
+		DELETE r
+		WITH DISTINCT m
+		OPTIONAL MATCH (p:Ministry {id: $parentID})
+		FOREACH (_ IN CASE WHEN p IS NULL THEN [] ELSE [1] END | CREATE (p)-[:HAS_MINISTRY]->(m))
+	`, params)
+}
+
+// saveDepartment writes the properties of an existing department node and
+// links it to its parent department, or to its ministry when it has none,
+// and to its area.
+func (c changeTx) saveDepartment(dept models.Department) error {
+	kind := dept.Kind
+	if kind == "" {
+		kind = models.KindDepartment
+	}
+	params := map[string]interface{}{
+		"id":         dept.ID,
+		"ministryID": dept.MinistryID,
+		"parentID":   dept.ParentID,
+		"areaID":     dept.AdminAreaID,
+		"department": map[string]interface{}{
+			"name": dept.Name, "kind": string(kind), "google_map_script": dept.Google_map_script,
+		},
+	}
+	addNamesParams(params, dept.Name, dept.Names)
+	addLocationParams(params, dept.Location)
+
+	return c.run(`
+		MATCH (d:Department {id: $id}), (m:Ministry {id: $ministryID})
+		SET d += $department, d += $names, d += $location
+		WITH d, m
+		OPTIONAL MATCH ()-[r:HAS_DEPARTMENT|HAS_UNIT]->(d)
+		DELETE r
+		WITH DISTINCT d, m
+		OPTIONAL MATCH (d)-[l:LOCATED_IN]->(:AdminArea)
+		DELETE l
+		WITH DISTINCT d, m
+		OPTIONAL MATCH (p:Department {id: $parentID})
+		OPTIONAL MATCH (a:AdminArea {id: $areaID})
+		FOREACH (_ IN CASE WHEN p IS NULL THEN [1] ELSE [] END | CREATE (m)-[:HAS_DEPARTMENT]->(d))
+		FOREACH (_ IN CASE WHEN p IS NULL THEN [] ELSE [1] END | CREATE (p)-[:HAS_UNIT]->(d))
+		FOREACH (_ IN CASE WHEN a IS NULL THEN [] ELSE [1] END | CREATE (d)-[:LOCATED_IN]->(a))
+	`, params)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import "go-mysql-backend/internal/models"
+
+// orgTreeRow is one descendant returned by a tree query, with a reference
+// to its parent.
+type orgTreeRow struct {
+	node   models.OrgNode
+	parent models.OrgRef
+}
+
+type orgTreeBuilder struct {
+	node     models.OrgNode
+	children []*orgTreeBuilder
+}
+
+// assembleOrgTree nests rows under root. Rows must be ordered by depth so a
+// parent is always seen before its children; rows whose parent is missing
+// are dropped.
+func assembleOrgTree(root models.OrgNode, rows []orgTreeRow) models.OrgNode {
+	top := &orgTreeBuilder{node: root}
+	byRef := map[models.OrgRef]*orgTreeBuilder{{Entity: root.Entity, ID: root.ID}: top}
+
+	for _, row := range rows {
+		parent, ok := byRef[row.parent]
+		if !ok {
+			continue
+		}
+		ref := models.OrgRef{Entity: row.node.Entity, ID: row.node.ID}
+		if _, seen := byRef[ref]; seen {
+			continue
+		}
+		child := &orgTreeBuilder{node: row.node}
+		parent.children = append(parent.children, child)
+		byRef[ref] = child
+	}
+	return top.build()
+}
+
+func (b *orgTreeBuilder) build() models.OrgNode {
+	node := b.node
+	node.Children = make([]models.OrgNode, 0, len(b.children))
+	for _, child := range b.children {
+		node.Children = append(node.Children, child.build())
+	}
+	return node
+}
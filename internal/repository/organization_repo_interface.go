@@ -0,0 +1,48 @@
+package repository
+
+import "go-mysql-backend/internal/models"
+
+// OrganizationRepo is the storage contract for ministries, departments and
+// their offices. OrganizationRepository (Postgres), SQLiteRepository,
+// Neo4jRepository and MemoryRepository implement it, and
+// repotest.TestOrganizationRepo checks that a backend keeps it.
+//
+// Lists are ordered by id. A missing ministry, department or office is
+// ErrNotFound, except GetDepartmentByID, which returns nil; a missing
+// ministry, parent or area that a write refers to is ErrInvalidReference.
+type OrganizationRepo interface {
+	GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error)
+	GetMinistriesPage(p models.PageRequest) (models.MinistryPage, error)
+	GetAllDepartments() ([]models.Department, error)
+	GetDepartmentsPage(p models.PageRequest) (models.DepartmentPage, error)
+	CreateMinistry(ministry models.Ministry) (int, error)
+	CreateDepartment(dept models.Department) (int, error)
+	GetMinistryByID(id int) (models.Ministry, error)
+	GetMinistryByIDWithDepartments(id int) (models.MinistryWithDepartments, error)
+	GetDepartmentByID(id int) (*models.Department, error)
+	UpdateMinistry(ministry models.Ministry) error
+	UpdateDepartment(dept models.Department) error
+	DeleteMinistry(id int, mode models.MinistryDeleteMode, reassignTo int) error
+	DeleteDepartment(id int) error
+	FindDepartmentsNearby(q models.NearbyQuery) ([]models.NearbyDepartment, error)
+	GetDepartmentsInBBox(bbox models.BBox, limit int) ([]models.Department, error)
+	GetMinistriesInBBox(bbox models.BBox, limit int) ([]models.Ministry, error)
+	GetOrganizationTree(root models.OrgRef, depth int) (models.OrgNode, error)
+	ApplyChangeSet(cs models.ChangeSet) (models.ChangeSetResult, error)
+	Import(plan models.ImportPlan) (models.ImportResult, error)
+	GetDepartmentOffices(departmentID int) ([]models.Office, error)
+	GetOfficeByID(id int) (*models.Office, error)
+	CreateOffice(office models.Office) (int, error)
+	UpdateOffice(office models.Office) error
+	DeleteOffice(id int) error
+	FindOfficesNearby(q models.NearbyQuery) ([]models.NearbyOffice, error)
+	Search(q models.SearchQuery) ([]models.SearchHit, error)
+	// AsOf returns a read-only view answering reads as of date.
+	AsOf(date models.Date) OrganizationRepo
+}
+
+// Seeder is implemented by backends that can fill themselves with random
+// demonstration data.
+type Seeder interface {
+	SeedDummyData() error
+}
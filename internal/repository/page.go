@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"sort"
+
+	"go-mysql-backend/internal/models"
+)
+
+// pageOfIDs returns the ids on the page p selects from ids, which are
+// sorted, and the position of the first of them. It is used where the
+// ids are cheap to list in full; Postgres pages in SQL instead.
+func pageOfIDs(ids []int, p models.PageRequest) ([]int, int) {
+	if p.Cursor == nil {
+		start := min(p.Offset, len(ids))
+		return ids[start:min(start+p.Limit, len(ids))], p.Offset
+	}
+
+	// i is the position of the first id at or after the cursor.
+	i := sort.SearchInts(ids, p.Cursor.ID)
+	if p.Cursor.Before {
+		start := max(i-p.Limit, 0)
+		return ids[start:i], start
+	}
+	if i < len(ids) && ids[i] == p.Cursor.ID {
+		i++
+	}
+	return ids[i:min(i+p.Limit, len(ids))], i
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+)
+
+// PositionRepository keeps terms in position_term. A term names its
+// organisation by entity and id without a foreign key, so tenure history
+// outlives a ministry or department that has been dissolved. Its SQL also
+// runs on the SQLite schema.
+type PositionRepository struct {
+	DB *sql.DB
+}
+
+func NewPositionRepository(db *sql.DB) *PositionRepository {
+	return &PositionRepository{DB: db}
+}
+
+// positionSelect reads a term with the names of its holder and its
+// organisation. A dissolved organisation keeps its last recorded name.
+const positionSelect = `
+	SELECT t.id, t.person_id, p.name, t.role, t.entity, t.organization_id,
+		COALESCE(m.name, d.name, CASE t.entity
+			WHEN 'ministry' THEN (SELECT v.name FROM ministry_version v
+				WHERE v.ministry_id = t.organization_id ORDER BY v.valid_from DESC LIMIT 1)
+			ELSE (SELECT v.name FROM department_version v
+				WHERE v.department_id = t.organization_id ORDER BY v.valid_from DESC LIMIT 1)
+		END),
+		t.start_date, t.end_date
+	FROM position_term t
+	JOIN person p ON p.id = t.person_id
+	LEFT JOIN ministry m ON t.entity = 'ministry' AND m.id = t.organization_id
+	LEFT JOIN department d ON t.entity = 'department' AND d.id = t.organization_id`
+
+func (r *PositionRepository) CreatePerson(person models.Person) (int, error) {
+	var id int
+	err := r.DB.QueryRow(`INSERT INTO person (name, title) VALUES ($1, $2) RETURNING id`,
+		person.Name, nullString(person.Title)).Scan(&id)
+	return id, err
+}
+
+func (r *PositionRepository) GetPersonByID(id int) (*models.Person, error) {
+	var person models.Person
+	var title sql.NullString
+	err := r.DB.QueryRow(`SELECT id, name, title FROM person WHERE id = $1`, id).Scan(&person.ID, &person.Name, &title)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	person.Title = title.String
+	return &person, nil
+}
+
+// CreatePosition records a term. The person and the organisation must
+// exist.
+func (r *PositionRepository) CreatePosition(position models.Position) (int, error) {
+	var exists bool
+	// The entity picks one of two fixed table names.
+	table := "department"
+	if position.Entity == models.EntityMinistry {
+		table = "ministry"
+	}
+	err := r.DB.QueryRow(fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1)`, table), position.OrganizationID).Scan(&exists)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, ErrInvalidReference
+	}
+
+	var id int
+	err = r.DB.QueryRow(`
+		INSERT INTO position_term (person_id, role, entity, organization_id, start_date, end_date)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id
+	`, position.PersonID, position.Role, position.Entity, position.OrganizationID, position.StartDate, position.EndDate).Scan(&id)
+	if err != nil {
+		return 0, translateConstraintError(err)
+	}
+	return id, nil
+}
+
+// EndPosition sets the day a term ends, which must be after it started.
+func (r *PositionRepository) EndPosition(id int, endDate models.Date) error {
+	var startDate models.Date
+	err := r.DB.QueryRow(`SELECT start_date FROM position_term WHERE id = $1`, id).Scan(&startDate)
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	if !endDate.After(startDate) {
+		return ErrInvalidTerm
+	}
+
+	res, err := r.DB.Exec(`UPDATE position_term SET end_date = $1 WHERE id = $2`, endDate, id)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+// GetOfficeHolders returns the terms of org that cover date.
+func (r *PositionRepository) GetOfficeHolders(org models.OrgRef, role models.PositionRole, date models.Date) ([]models.Position, error) {
+	rows, err := r.DB.Query(positionSelect+`
+		WHERE t.entity = $1 AND t.organization_id = $2 AND ($3 = '' OR t.role = $3)
+		  AND t.start_date <= $4 AND (t.end_date IS NULL OR t.end_date > $4)
+		ORDER BY t.start_date, t.id
+	`, org.Entity, org.ID, role, date)
+	if err != nil {
+		return nil, err
+	}
+	return scanPositions(rows)
+}
+
+func (r *PositionRepository) GetPositionHistory(org models.OrgRef, role models.PositionRole) ([]models.Position, error) {
+	rows, err := r.DB.Query(positionSelect+`
+		WHERE t.entity = $1 AND t.organization_id = $2 AND ($3 = '' OR t.role = $3)
+		ORDER BY t.start_date, t.id
+	`, org.Entity, org.ID, role)
+	if err != nil {
+		return nil, err
+	}
+	return scanPositions(rows)
+}
+
+func (r *PositionRepository) GetPersonPositions(personID int) ([]models.Position, error) {
+	if _, err := r.GetPersonByID(personID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.DB.Query(positionSelect+`
+		WHERE t.person_id = $1
+		ORDER BY t.start_date, t.id
+	`, personID)
+	if err != nil {
+		return nil, err
+	}
+	return scanPositions(rows)
+}
+
+func scanPositions(rows *sql.Rows) ([]models.Position, error) {
+	defer rows.Close()
+
+	positions := []models.Position{}
+	for rows.Next() {
+		var p models.Position
+		var orgName sql.NullString
+		if err := rows.Scan(&p.ID, &p.PersonID, &p.PersonName, &p.Role, &p.Entity, &p.OrganizationID,
+			&orgName, &p.StartDate, &p.EndDate); err != nil {
+			return nil, err
+		}
+		p.OrganizationName = orgName.String
+		positions = append(positions, p)
+	}
+	return positions, rows.Err()
+}
@@ -0,0 +1,16 @@
+package repository
+
+import "go-mysql-backend/internal/models"
+
+// PositionRepo stores people and the terms they hold in ministries and
+// departments. An empty role matches every role. Terms are returned oldest
+// first.
+type PositionRepo interface {
+	CreatePerson(person models.Person) (int, error)
+	GetPersonByID(id int) (*models.Person, error)
+	CreatePosition(position models.Position) (int, error)
+	EndPosition(id int, endDate models.Date) error
+	GetOfficeHolders(org models.OrgRef, role models.PositionRole, date models.Date) ([]models.Position, error)
+	GetPositionHistory(org models.OrgRef, role models.PositionRole) ([]models.Position, error)
+	GetPersonPositions(personID int) ([]models.Position, error)
+}
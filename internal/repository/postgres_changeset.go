@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+)
+
+// ApplyChangeSet applies every operation of cs in one transaction, in
+// order, each on its own effective date. If an operation fails nothing is
+// applied and the error is a *models.ChangeOpError naming it.
+func (r *OrganizationRepository) ApplyChangeSet(cs models.ChangeSet) (models.ChangeSetResult, error) {
+	var result models.ChangeSetResult
+	err := withTx(r.DB, func(tx *sql.Tx) (err error) {
+		result, err = applyChangeSet(tx, cs)
+		return err
+	})
+	return result, err
+}
+
+// applyChangeSet applies and logs the operations of cs in q, stopping at
+// the first that fails.
+func applyChangeSet(q dbtx, cs models.ChangeSet) (models.ChangeSetResult, error) {
+	result := models.ChangeSetResult{Operations: []models.ChangeOpResult{}}
+	for i, op := range cs.Resolve() {
+		created, err := applyChangeOp(q, op)
+		if err == nil {
+			err = logChangeOp(q, op)
+		}
+		if err != nil {
+			return models.ChangeSetResult{}, &models.ChangeOpError{Index: i, Type: op.Type, Err: err}
+		}
+		result.Operations = append(result.Operations, models.ChangeOpResult{
+			Index: i, Type: op.Type, Gazette: op.Gazette, CreatedIDs: created,
+		})
+	}
+	result.Applied = len(result.Operations)
+	return result, nil
+}
+
+func applyChangeOp(q dbtx, op models.ChangeOp) ([]int, error) {
+	switch op.Type {
+	case models.OpTransfer:
+		return nil, transferDepartment(q, op.ID, op.ToMinistryID, op.EffectiveDate)
+	case models.OpRename:
+		return nil, renameOrganization(q, models.OrgRef{Entity: op.Entity, ID: op.ID}, op.Name, op.Names, op.EffectiveDate)
+	case models.OpMerge:
+		return nil, mergeDepartments(q, op.IntoID, op.DepartmentIDs, op.Name, op.Names, op.EffectiveDate)
+	case models.OpSplit:
+		return splitDepartment(q, op.ID, op.Parts, op.EffectiveDate)
+	}
+	return nil, fmt.Errorf("%w: unknown operation type %q", models.ErrInvalidChange, op.Type)
+}
+
+// transferDepartment moves a department, with its divisions, to a ministry
+// as a top-level department.
+func transferDepartment(q dbtx, id, ministryID int, effective models.Date) error {
+	dept, err := loadDepartment(q, id)
+	if err != nil {
+		return err
+	}
+	dept.MinistryID = ministryID
+	dept.ParentID = nil
+	return updateDepartment(q, dept, effective)
+}
+
+// renameOrganization replaces the name of a ministry or department in every
+// language; translations the gazette does not give are dropped.
+func renameOrganization(q dbtx, ref models.OrgRef, name string, names models.LocalizedNames, effective models.Date) error {
+	if ref.Entity == models.EntityMinistry {
+		ministry, err := loadMinistry(q, ref.ID)
+		if err != nil {
+			return err
+		}
+		ministry.Name, ministry.Names = name, names
+		return updateMinistry(q, ministry, effective)
+	}
+
+	dept, err := loadDepartment(q, ref.ID)
+	if err != nil {
+		return err
+	}
+	dept.Name, dept.Names = name, names
+	return updateDepartment(q, dept, effective)
+}
+
+// mergeDepartments moves the divisions and offices of each of ids to into
+// and removes the merged departments.
+func mergeDepartments(q dbtx, into int, ids []int, name string, names models.LocalizedNames, effective models.Date) error {
+	target, err := loadDepartment(q, into)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := loadDepartment(q, id); err != nil {
+			return fmt.Errorf("department %d: %w", id, err)
+		}
+		units, err := queryIDs(q, `SELECT id FROM department WHERE parent_id = $1 AND id <> $2`, id, into)
+		if err != nil {
+			return err
+		}
+		for _, unitID := range units {
+			unit, err := loadDepartment(q, unitID)
+			if err != nil {
+				return err
+			}
+			unit.ParentID = &target.ID
+			unit.MinistryID = target.MinistryID
+			if err := updateDepartment(q, unit, effective); err != nil {
+				return err
+			}
+		}
+		if err := moveOffices(q, id, into); err != nil {
+			return err
+		}
+		if err := deleteDepartment(q, id, effective); err != nil {
+			return err
+		}
+	}
+
+	if name == "" {
+		return nil
+	}
+	// Reload: into may have been detached from a merged parent.
+	return renameOrganization(q, models.OrgRef{Entity: models.EntityDepartment, ID: into}, name, names, effective)
+}
+
+// splitDepartment replaces a department with one new department per part,
+// under the same ministry and parent and at the same location. Each part
+// takes the divisions it claims; the rest become top-level departments.
+// The offices go to the first part.
+func splitDepartment(q dbtx, id int, parts []models.SplitPart, effective models.Date) ([]int, error) {
+	source, err := loadDepartment(q, id)
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]int, 0, len(parts))
+	for _, part := range parts {
+		partID, err := createDepartment(q, models.Department{
+			Name:        part.Name,
+			Names:       part.Names,
+			Kind:        part.Kind,
+			ParentID:    source.ParentID,
+			MinistryID:  source.MinistryID,
+			AdminAreaID: source.AdminAreaID,
+			Location:    source.Location,
+		}, effective)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, partID)
+
+		for _, unitID := range part.UnitIDs {
+			unit, err := loadDepartment(q, unitID)
+			if err != nil {
+				return nil, fmt.Errorf("division %d: %w", unitID, err)
+			}
+			if unit.ParentID == nil || *unit.ParentID != id {
+				return nil, fmt.Errorf("%w: department %d is not a division of department %d", models.ErrInvalidChange, unitID, id)
+			}
+			unit.ParentID = &partID
+			if err := updateDepartment(q, unit, effective); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(created) > 0 {
+		if err := moveOffices(q, id, created[0]); err != nil {
+			return nil, err
+		}
+	}
+	return created, deleteDepartment(q, id, effective)
+}
+
+// loadMinistry and loadDepartment read and lock a live row.
+func loadMinistry(q dbtx, id int) (models.Ministry, error) {
+	var m ministryRow
+	err := q.QueryRow(`SELECT `+ministryColumns+` FROM ministry m WHERE m.id = $1 FOR UPDATE`, id).Scan(m.dest()...)
+	if err == sql.ErrNoRows {
+		return models.Ministry{}, ErrNotFound
+	} else if err != nil {
+		return models.Ministry{}, err
+	}
+	return m.toModel(), nil
+}
+
+func loadDepartment(q dbtx, id int) (models.Department, error) {
+	var d departmentRow
+	err := q.QueryRow(`SELECT `+departmentColumns+` FROM department d WHERE d.id = $1 FOR UPDATE`, id).Scan(d.dest()...)
+	if err == sql.ErrNoRows {
+		return models.Department{}, ErrNotFound
+	} else if err != nil {
+		return models.Department{}, err
+	}
+	return d.toModel(), nil
+}
+
+// logChangeOp keeps the applied operation with its gazette reference.
+func logChangeOp(q dbtx, op models.ChangeOp) error {
+	operation, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	_, err = q.Exec(`INSERT INTO gazette_change (gazette, effective_date, type, operation) VALUES ($1, $2, $3, $4)`,
+		op.Gazette, op.EffectiveDate, op.Type, string(operation))
+	return err
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+)
+
+// orgTreeQuery walks the hierarchy below ($1, $2) for up to $3 levels. The
+// edges CTE flattens the three parent/child relations (ministry to state
+// ministry, ministry to top-level department, department to division) into
+// one table; path guards against cycles in bad data. %[1]s and %[2]s are
+// the ministry and department sources.
+const orgTreeQuery = `
+	WITH RECURSIVE edges AS (
+		SELECT 'ministry'::text AS parent_entity, parent_id, 'ministry'::text AS entity, id, name, names, kind
+		FROM %[1]s ministry WHERE parent_id IS NOT NULL
+		UNION ALL
+		SELECT 'ministry', ministry_id, 'department', id, name, names, kind
+		FROM %[2]s department WHERE parent_id IS NULL AND ministry_id IS NOT NULL
+		UNION ALL
+		SELECT 'department', parent_id, 'department', id, name, names, kind
+		FROM %[2]s department WHERE parent_id IS NOT NULL
+	),
+	tree AS (
+		SELECT e.entity, e.id, e.parent_entity, e.parent_id, e.name, e.names, e.kind, 1 AS depth,
+			ARRAY[$1::text || ':' || $2::int, e.entity || ':' || e.id] AS path
+		FROM edges e
+		WHERE e.parent_entity = $1::text AND e.parent_id = $2::int AND $3::int >= 1
+		UNION ALL
+		SELECT e.entity, e.id, e.parent_entity, e.parent_id, e.name, e.names, e.kind, t.depth + 1,
+			t.path || (e.entity || ':' || e.id)
+		FROM tree t
+		JOIN edges e ON e.parent_entity = t.entity AND e.parent_id = t.id
+		WHERE t.depth < $3::int AND NOT (e.entity || ':' || e.id) = ANY (t.path)
+	)
+	SELECT entity, id, parent_entity, parent_id, name, names, kind, depth
+	FROM tree
+	ORDER BY depth, entity DESC, id
+`
+
+// GetOrganizationTree returns root and its descendants down to depth
+// levels below it.
+func (r *OrganizationRepository) GetOrganizationTree(root models.OrgRef, depth int) (models.OrgNode, error) {
+	if !root.Entity.Valid() {
+		return models.OrgNode{}, fmt.Errorf("unknown organisation entity %q", root.Entity)
+	}
+
+	source := r.ministrySource()
+	if root.Entity == models.EntityDepartment {
+		source = r.departmentSource()
+	}
+
+	node := models.OrgNode{Entity: root.Entity, ID: root.ID}
+	var names []byte
+	err := r.DB.QueryRow(`SELECT name, names, kind FROM `+source+` root WHERE id = $1`, root.ID).
+		Scan(&node.Name, &names, &node.Kind)
+	if err == sql.ErrNoRows {
+		return models.OrgNode{}, ErrNotFound
+	} else if err != nil {
+		return models.OrgNode{}, err
+	}
+
+	node.Names = decodeNames(names)
+
+	query := fmt.Sprintf(orgTreeQuery, r.ministrySource(), r.departmentSource())
+	rows, err := r.DB.Query(query, root.Entity, root.ID, depth)
+	if err != nil {
+		return models.OrgNode{}, err
+	}
+	defer rows.Close()
+
+	var tree []orgTreeRow
+	for rows.Next() {
+		var row orgTreeRow
+		if err := rows.Scan(&row.node.Entity, &row.node.ID, &row.parent.Entity, &row.parent.ID,
+			&row.node.Name, &names, &row.node.Kind, &row.node.Depth); err != nil {
+			return models.OrgNode{}, err
+		}
+		row.node.Names = decodeNames(names)
+		tree = append(tree, row)
+	}
+	if err := rows.Err(); err != nil {
+		return models.OrgNode{}, err
+	}
+
+	return assembleOrgTree(node, tree), nil
+}
+
+// ancestorQuery reports whether $2 is $1 or one of its ancestors in the
+// given table.
+const ancestorQuery = `
+	WITH RECURSIVE ancestors AS (
+		SELECT id, parent_id FROM %[1]s WHERE id = $1
+		UNION
+		SELECT t.id, t.parent_id FROM %[1]s t JOIN ancestors a ON t.id = a.parent_id
+	)
+	SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)
+`
+
+// checkMinistryParent rejects a parent ministry that does not exist, or that
+// is the ministry itself or one of its descendants. id is 0 for a new
+// ministry.
+func checkMinistryParent(q dbtx, id, parentID int) error {
+	var exists bool
+	if err := q.QueryRow(`SELECT EXISTS (SELECT 1 FROM ministry WHERE id = $1)`, parentID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrInvalidReference
+	}
+	return checkNoCycle(q, "ministry", id, parentID)
+}
+
+// checkDepartmentParent rejects a parent department that does not exist,
+// belongs to another ministry, or would put the department below itself.
+func checkDepartmentParent(q dbtx, id, parentID, ministryID int) error {
+	var parentMinistry sql.NullInt64
+	err := q.QueryRow(`SELECT ministry_id FROM department WHERE id = $1 FOR SHARE`, parentID).Scan(&parentMinistry)
+	if err == sql.ErrNoRows {
+		return ErrInvalidReference
+	} else if err != nil {
+		return err
+	}
+	if int(parentMinistry.Int64) != ministryID {
+		return ErrInvalidParent
+	}
+	return checkNoCycle(q, "department", id, parentID)
+}
+
+func checkNoCycle(q dbtx, table string, id, parentID int) error {
+	if id == 0 {
+		return nil
+	}
+	if id == parentID {
+		return ErrInvalidParent
+	}
+	var cycle bool
+	if err := q.QueryRow(fmt.Sprintf(ancestorQuery, table), parentID, id).Scan(&cycle); err != nil {
+		return err
+	}
+	if cycle {
+		return ErrInvalidParent
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+)
+
+// AsOf returns a read-only view of the repository that answers read
+// queries from ministry_version and department_version as the structure
+// stood on date. Locations, map scripts and area links are not versioned
+// and come from the current rows.
+func (r *OrganizationRepository) AsOf(date models.Date) OrganizationRepo {
+	return &OrganizationRepository{DB: r.DB, asOf: &date}
+}
+
+// ministrySource and departmentSource return the table, or the dated
+// subquery, that read queries select from. Both produce the columns of the
+// live tables.
+func (r *OrganizationRepository) ministrySource() string {
+	return ministrySource(r.asOf)
+}
+
+func (r *OrganizationRepository) departmentSource() string {
+	return departmentSource(r.asOf)
+}
+
+func ministrySource(asOf *models.Date) string {
+	if asOf == nil {
+		return "ministry"
+	}
+	// asOf is a parsed models.Date, so the literal is always YYYY-MM-DD.
+	return fmt.Sprintf(`(
+		SELECT v.ministry_id AS id, v.name, v.names, v.kind, v.parent_id,
+			l.google_map_script, l.latitude, l.longitude, l.address, l.geometry
+		FROM ministry_version v
+		LEFT JOIN ministry l ON l.id = v.ministry_id
+		WHERE v.valid_from <= DATE '%[1]s' AND (v.valid_to IS NULL OR v.valid_to > DATE '%[1]s')
+	)`, asOf)
+}
+
+func departmentSource(asOf *models.Date) string {
+	if asOf == nil {
+		return "department"
+	}
+	return fmt.Sprintf(`(
+		SELECT v.department_id AS id, v.name, v.names, v.kind, v.parent_id, v.ministry_id,
+			l.google_map_script, l.admin_area_id, l.latitude, l.longitude, l.address, l.geometry
+		FROM department_version v
+		LEFT JOIN department l ON l.id = v.department_id
+		WHERE v.valid_from <= DATE '%[1]s' AND (v.valid_to IS NULL OR v.valid_to > DATE '%[1]s')
+	)`, asOf)
+}
+
+// versionTable describes a history table holding snapshots of the
+// versioned columns of a live table over [valid_from, valid_to).
+type versionTable struct {
+	table   string // history table
+	key     string // column holding the live row id
+	source  string // live table
+	columns string // versioned columns, named the same in both tables
+}
+
+var (
+	ministryVersions   = versionTable{"ministry_version", "ministry_id", "ministry", "name, names, kind, parent_id"}
+	departmentVersions = versionTable{"department_version", "department_id", "department", "name, names, kind, parent_id, ministry_id"}
+)
+
+// record snapshots the live row id as the version starting on effective,
+// closing the open version. A version opened on the same day is replaced,
+// so several edits on one day leave a single version.
+func (v versionTable) record(q dbtx, id int, effective models.Date) error {
+	if err := v.close(q, id, effective); err != nil {
+		return err
+	}
+	_, err := q.Exec(fmt.Sprintf(`
+		INSERT INTO %[1]s (%[2]s, %[4]s, valid_from)
+		SELECT id, %[4]s, $2 FROM %[3]s WHERE id = $1
+	`, v.table, v.key, v.source, v.columns), id, effective)
+	return err
+}
+
+// close ends the open version of id on effective. It fails with
+// ErrEffectiveDateOrder when the open version starts after effective,
+// since history is only ever appended to.
+func (v versionTable) close(q dbtx, id int, effective models.Date) error {
+	var openFrom models.Date
+	err := q.QueryRow(fmt.Sprintf(`SELECT valid_from FROM %s WHERE %s = $1 AND valid_to IS NULL FOR UPDATE`, v.table, v.key), id).
+		Scan(&openFrom)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	switch {
+	case openFrom.After(effective):
+		return fmt.Errorf("%w: %s %d changed on %s", ErrEffectiveDateOrder, v.source, id, openFrom)
+	case openFrom.Equal(effective):
+		_, err = q.Exec(fmt.Sprintf(`DELETE FROM %s WHERE %s = $1 AND valid_to IS NULL`, v.table, v.key), id)
+	default:
+		_, err = q.Exec(fmt.Sprintf(`UPDATE %s SET valid_to = $2 WHERE %s = $1 AND valid_to IS NULL`, v.table, v.key), id, effective)
+	}
+	return err
+}
+
+// queryIDs runs a query selecting a single id column.
+func queryIDs(q dbtx, query string, args ...interface{}) ([]int, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// recordAll snapshots each of ids.
+func (v versionTable) recordAll(q dbtx, ids []int, effective models.Date) error {
+	for _, id := range ids {
+		if err := v.record(q, id, effective); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"database/sql"
+
+	"go-mysql-backend/internal/models"
+)
+
+// Import creates the records of plan in one transaction, effective
+// today. If one fails nothing is created and the error is a
+// *models.ImportRowError naming it.
+func (r *OrganizationRepository) Import(plan models.ImportPlan) (models.ImportResult, error) {
+	var result models.ImportResult
+	err := withTx(r.DB, func(tx *sql.Tx) (err error) {
+		result, err = importRows(tx, plan, createOffice)
+		return err
+	})
+	return result, err
+}
+
+// importRows imports plan in q with the Postgres statements, which the
+// SQLite backend shares apart from creating offices.
+func importRows(q dbtx, plan models.ImportPlan, createOffice func(dbtx, models.Office) (int, error)) (models.ImportResult, error) {
+	effective := models.Today()
+	return importPlan(plan,
+		func(m models.Ministry) (int, error) { return createMinistry(q, m, effective) },
+		func(d models.Department) (int, error) { return createDepartment(q, d, effective) },
+		func(o models.Office) (int, error) { return createOffice(q, o) })
+}
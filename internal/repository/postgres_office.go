@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+
+	"go-mysql-backend/internal/geo"
+	"go-mysql-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// officeColumns are the columns read into officeRow. Queries must alias
+// the table o.
+const officeColumns = `o.id, o.department_id, o.name, o.phone_numbers, o.email, o.hours, o.closures, o.latitude, o.longitude, o.address, o.geometry`
+
+type officeRow struct {
+	ID           int
+	DepartmentID int
+	Name         string
+	PhoneNumbers pq.StringArray
+	Email        sql.NullString
+	Hours        []byte
+	Closures     []byte
+	Location     nullLocation
+}
+
+func (o *officeRow) dest() []interface{} {
+	return append([]interface{}{&o.ID, &o.DepartmentID, &o.Name, &o.PhoneNumbers, &o.Email, &o.Hours, &o.Closures}, o.Location.dest()...)
+}
+
+func (o officeRow) toModel() (models.Office, error) {
+	office := models.Office{
+		ID:           o.ID,
+		DepartmentID: o.DepartmentID,
+		Name:         o.Name,
+		PhoneNumbers: []string(o.PhoneNumbers),
+		Email:        o.Email.String,
+		Location:     o.Location.toModel(),
+	}
+	if err := decodeSchedule(o.Hours, o.Closures, &office); err != nil {
+		return models.Office{}, err
+	}
+	return office, nil
+}
+
+// decodeSchedule reads the hours and closures stored as JSON lists.
+func decodeSchedule(hours, closures []byte, office *models.Office) error {
+	if len(hours) > 0 {
+		if err := json.Unmarshal(hours, &office.Hours); err != nil {
+			return err
+		}
+	}
+	if len(closures) > 0 {
+		if err := json.Unmarshal(closures, &office.Closures); err != nil {
+			return err
+		}
+	}
+	if len(office.Hours) == 0 {
+		office.Hours = nil
+	}
+	if len(office.Closures) == 0 {
+		office.Closures = nil
+	}
+	return nil
+}
+
+// encodeSchedule returns the hours and closures of office as JSON lists.
+func encodeSchedule(office models.Office) (string, string, error) {
+	hours, err := json.Marshal(nonNil(office.Hours))
+	if err != nil {
+		return "", "", err
+	}
+	closures, err := json.Marshal(nonNil(office.Closures))
+	if err != nil {
+		return "", "", err
+	}
+	return string(hours), string(closures), nil
+}
+
+func nonNil[T any](list []T) []T {
+	if list == nil {
+		return []T{}
+	}
+	return list
+}
+
+// GetDepartmentOffices lists the offices of a department, head office
+// first by id.
+func (r *OrganizationRepository) GetDepartmentOffices(departmentID int) ([]models.Office, error) {
+	rows, err := r.DB.Query(`SELECT `+officeColumns+` FROM office o WHERE o.department_id = $1 ORDER BY o.id`, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	offices := []models.Office{}
+	for rows.Next() {
+		var o officeRow
+		if err := rows.Scan(o.dest()...); err != nil {
+			return nil, err
+		}
+		office, err := o.toModel()
+		if err != nil {
+			return nil, err
+		}
+		offices = append(offices, office)
+	}
+	return offices, rows.Err()
+}
+
+func (r *OrganizationRepository) GetOfficeByID(id int) (*models.Office, error) {
+	var o officeRow
+	err := r.DB.QueryRow(`SELECT `+officeColumns+` FROM office o WHERE o.id = $1`, id).Scan(o.dest()...)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	office, err := o.toModel()
+	if err != nil {
+		return nil, err
+	}
+	return &office, nil
+}
+
+// FindOfficesNearby returns the offices within q.RadiusKm of the query
+// point, nearest first, using the haversine great-circle distance.
+func (r *OrganizationRepository) FindOfficesNearby(q models.NearbyQuery) ([]models.NearbyOffice, error) {
+	// Degrees of latitude spanned by the radius, used to prefilter rows.
+	latSpan := q.RadiusKm / (geo.EarthRadiusKm * math.Pi / 180)
+
+	rows, err := r.DB.Query(`
+		SELECT `+officeColumns+`, o.distance_km
+		FROM (
+			SELECT office.*, 2 * $5::float8 * ASIN(LEAST(1, SQRT(
+				POWER(SIN(RADIANS(latitude - $1) / 2), 2) +
+				COS(RADIANS($1)) * COS(RADIANS(latitude)) *
+				POWER(SIN(RADIANS(longitude - $2) / 2), 2)
+			))) AS distance_km
+			FROM office
+			WHERE latitude IS NOT NULL AND longitude IS NOT NULL
+			  AND latitude BETWEEN $1 - $6::float8 AND $1 + $6::float8
+		) o
+		WHERE o.distance_km <= $3
+		ORDER BY o.distance_km, o.id
+		LIMIT $4
+	`, q.Latitude, q.Longitude, q.RadiusKm, q.Limit, geo.EarthRadiusKm, latSpan)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	offices := []models.NearbyOffice{}
+	for rows.Next() {
+		var o officeRow
+		var distance float64
+		if err := rows.Scan(append(o.dest(), &distance)...); err != nil {
+			return nil, err
+		}
+		office, err := o.toModel()
+		if err != nil {
+			return nil, err
+		}
+		offices = append(offices, models.NearbyOffice{Office: office, DistanceKm: distance})
+	}
+	return offices, rows.Err()
+}
+
+// CreateOffice adds an office to an existing department.
+func (r *OrganizationRepository) CreateOffice(office models.Office) (int, error) {
+	return createOffice(r.DB, office)
+}
+
+func createOffice(q dbtx, office models.Office) (int, error) {
+	hours, closures, err := encodeSchedule(office)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = q.QueryRow(`INSERT INTO office (department_id, name, phone_numbers, email, hours, closures, latitude, longitude, address, geometry)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
+		office.DepartmentID, office.Name, pq.Array(nonNil(office.PhoneNumbers)), nullString(office.Email), hours, closures,
+		office.Latitude, office.Longitude, nullString(office.Address), nullGeometry(office.Geometry)).Scan(&id)
+	if err != nil {
+		return 0, translateConstraintError(err)
+	}
+	return id, nil
+}
+
+// UpdateOffice replaces the details of an office. DepartmentID moves the
+// office to another department.
+func (r *OrganizationRepository) UpdateOffice(office models.Office) error {
+	hours, closures, err := encodeSchedule(office)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.DB.Exec(`UPDATE office SET department_id = $1, name = $2, phone_numbers = $3, email = $4,
+		hours = $5, closures = $6, latitude = $7, longitude = $8, address = $9, geometry = $10 WHERE id = $11`,
+		office.DepartmentID, office.Name, pq.Array(nonNil(office.PhoneNumbers)), nullString(office.Email), hours, closures,
+		office.Latitude, office.Longitude, nullString(office.Address), nullGeometry(office.Geometry), office.ID)
+	if err != nil {
+		return translateConstraintError(err)
+	}
+	return expectRowsAffected(res)
+}
+
+func (r *OrganizationRepository) DeleteOffice(id int) error {
+	res, err := r.DB.Exec(`DELETE FROM office WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+// moveOffices hands the offices of department from to department to, as
+// when departments are merged or split.
+func moveOffices(q dbtx, from, to int) error {
+	_, err := q.Exec(`UPDATE office SET department_id = $1 WHERE department_id = $2`, to, from)
+	return err
+}
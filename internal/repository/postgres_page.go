@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"go-mysql-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// GetMinistriesPage returns a page of ministries, each with all of its
+// departments. Pages are cut over ministries, never within one.
+func (r *OrganizationRepository) GetMinistriesPage(p models.PageRequest) (models.MinistryPage, error) {
+	page := models.MinistryPage{Limit: p.Limit, Items: []models.MinistryWithDepartments{}}
+	ids, err := pageIDs(r.DB, r.ministrySource(), p, &page.Offset, &page.Total)
+	if err != nil || len(ids) == 0 {
+		return page, err
+	}
+
+	rows, err := r.DB.Query(`
+		SELECT `+ministryColumns+`, `+departmentColumns+`
+		FROM `+r.ministrySource()+` m
+		LEFT JOIN `+r.departmentSource()+` d ON m.id = d.ministry_id
+		WHERE m.id = ANY($1)
+		ORDER BY m.id, d.id
+	`, pq.Array(ids))
+	if err != nil {
+		return page, err
+	}
+	page.Items, err = scanMinistriesWithDepartments(rows)
+	return page, err
+}
+
+// GetDepartmentsPage returns a page of departments.
+func (r *OrganizationRepository) GetDepartmentsPage(p models.PageRequest) (models.DepartmentPage, error) {
+	page := models.DepartmentPage{Limit: p.Limit, Items: []models.Department{}}
+	ids, err := pageIDs(r.DB, r.departmentSource(), p, &page.Offset, &page.Total)
+	if err != nil || len(ids) == 0 {
+		return page, err
+	}
+
+	rows, err := r.DB.Query(`SELECT `+departmentColumns+` FROM `+r.departmentSource()+` d WHERE d.id = ANY($1) ORDER BY d.id`,
+		pq.Array(ids))
+	if err != nil {
+		return page, err
+	}
+	page.Items, err = scanDepartments(rows)
+	return page, err
+}
+
+// pageIDs returns the ids, in order, of the rows of source on the page p
+// selects, and sets offset to the position of the first of them and total
+// to the number of rows. Cursor pages are read by keyset on the primary
+// key rather than by skipping rows.
+func pageIDs(q dbtx, source string, p models.PageRequest, offset, total *int) ([]int, error) {
+	var ids []int
+	var err error
+	switch {
+	case p.Cursor == nil:
+		ids, err = queryIDs(q, `SELECT id FROM `+source+` s ORDER BY id LIMIT $1 OFFSET $2`, p.Limit, p.Offset)
+	case p.Cursor.Before:
+		ids, err = queryIDs(q, `
+			SELECT id FROM (SELECT id FROM `+source+` s WHERE id < $2 ORDER BY id DESC LIMIT $1) p
+			ORDER BY id`, p.Limit, p.Cursor.ID)
+	default:
+		ids, err = queryIDs(q, `SELECT id FROM `+source+` s WHERE id > $2 ORDER BY id LIMIT $1`, p.Limit, p.Cursor.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The offset is the number of rows before the page: before its first
+	// row, or before the cursor when the page is empty.
+	pivot := 0
+	switch {
+	case len(ids) > 0:
+		pivot = ids[0]
+	case p.Cursor != nil && !p.Cursor.Before:
+		pivot = p.Cursor.ID + 1
+	}
+	if err := q.QueryRow(`SELECT count(*), count(*) FILTER (WHERE id < $1) FROM `+source+` s`, pivot).Scan(total, offset); err != nil {
+		return nil, err
+	}
+	if p.Cursor == nil {
+		*offset = p.Offset
+	}
+	return ids, nil
+}
@@ -2,13 +2,18 @@ package repository
 
 import (
 	"database/sql"
+	"go-mysql-backend/internal/geo"
 	"go-mysql-backend/internal/models"
+	"math"
 
 	_ "github.com/lib/pq"
 )
 
 type OrganizationRepository struct {
 	DB *sql.DB
+
+	// asOf is set on the dated views returned by AsOf.
+	asOf *models.Date
 }
 
 func NewOrganizationRepository(db *sql.DB) *OrganizationRepository {
@@ -17,234 +22,426 @@ func NewOrganizationRepository(db *sql.DB) *OrganizationRepository {
 
 func (r *OrganizationRepository) GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error) {
 	rows, err := r.DB.Query(`
-        SELECT 
-            m.id, m.name, m.google_map_script,
-            d.id, d.name, d.ministry_id, d.google_map_script
-        FROM ministry m
-        LEFT JOIN department d ON m.id = d.ministry_id
-        ORDER BY m.id
+        SELECT ` + ministryColumns + `, ` + departmentColumns + `
+        FROM ` + r.ministrySource() + ` m
+        LEFT JOIN ` + r.departmentSource() + ` d ON m.id = d.ministry_id
+        ORDER BY m.id, d.id
     `)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return scanMinistriesWithDepartments(rows)
+}
 
-	ministriesMap := make(map[int]*models.MinistryWithDepartments)
+func (r *OrganizationRepository) GetAllDepartments() ([]models.Department, error) {
+	rows, err := r.DB.Query(`SELECT ` + departmentColumns + ` FROM ` + r.departmentSource() + ` d ORDER BY d.id`)
+	if err != nil {
+		return nil, err
+	}
+	return scanDepartments(rows)
+}
 
-	for rows.Next() {
-		var mID int
-		var mName string
-		var mMapScript sql.NullString
-		var dID sql.NullInt64
-		var dName sql.NullString
-		var dMinistryID sql.NullInt64
-		var dMapScript sql.NullString
-
-		err := rows.Scan(
-			&mID, &mName, &mMapScript,
-			&dID, &dName, &dMinistryID, &dMapScript,
-		)
-		if err != nil {
-			return nil, err
-		}
+func (r *OrganizationRepository) CreateMinistry(ministry models.Ministry) (int, error) {
+	var id int
+	err := withTx(r.DB, func(tx *sql.Tx) (err error) {
+		id, err = createMinistry(tx, ministry, models.Today())
+		return err
+	})
+	return id, err
+}
 
-		if _, exists := ministriesMap[mID]; !exists {
-			ministriesMap[mID] = &models.MinistryWithDepartments{
-				Ministry: models.Ministry{
-					ID:                mID,
-					Name:              mName,
-					Google_map_script: mMapScript.String,
-				},
-			}
-		}
+func createMinistry(q dbtx, ministry models.Ministry, effective models.Date) (int, error) {
+	var id int
+	err := q.QueryRow(`INSERT INTO ministry (name, kind, parent_id, google_map_script, latitude, longitude, address, geometry, names, search_key)
+		VALUES ($1, COALESCE($2, 'ministry'), $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
+		ministry.Name, nullString(string(ministry.Kind)), ministry.ParentID, ministry.Google_map_script,
+		ministry.Latitude, ministry.Longitude, nullString(ministry.Address), nullGeometry(ministry.Geometry),
+		encodeNames(ministry.Names), searchKey(ministry.Name, ministry.Names)).Scan(&id)
+	if err != nil {
+		return 0, translateConstraintError(err)
+	}
+	return id, ministryVersions.record(q, id, effective)
+}
 
-		if dID.Valid {
-			dept := models.Department{
-				ID:                int(dID.Int64),
-				Name:              dName.String,
-				MinistryID:        int(dMinistryID.Int64),
-				Google_map_script: dMapScript.String,
-			}
-			ministriesMap[mID].Departments = append(ministriesMap[mID].Departments, dept)
+func (r *OrganizationRepository) CreateDepartment(dept models.Department) (int, error) {
+	var id int
+	err := withTx(r.DB, func(tx *sql.Tx) (err error) {
+		id, err = createDepartment(tx, dept, models.Today())
+		return err
+	})
+	return id, err
+}
+
+func createDepartment(q dbtx, dept models.Department, effective models.Date) (int, error) {
+	if dept.ParentID != nil {
+		if err := checkDepartmentParent(q, 0, *dept.ParentID, dept.MinistryID); err != nil {
+			return 0, err
 		}
 	}
 
-	var ministries []models.MinistryWithDepartments
-	for _, m := range ministriesMap {
-		ministries = append(ministries, *m)
+	var id int
+	err := q.QueryRow(`INSERT INTO department (name, kind, parent_id, ministry_id, google_map_script, admin_area_id, latitude, longitude, address, geometry, names, search_key)
+		VALUES ($1, COALESCE($2, 'department'), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`,
+		dept.Name, nullString(string(dept.Kind)), dept.ParentID, dept.MinistryID, dept.Google_map_script, dept.AdminAreaID,
+		dept.Latitude, dept.Longitude, nullString(dept.Address), nullGeometry(dept.Geometry), encodeNames(dept.Names),
+		searchKey(dept.Name, dept.Names)).Scan(&id)
+	if err != nil {
+		return 0, translateConstraintError(err)
 	}
+	return id, departmentVersions.record(q, id, effective)
+}
 
-	return ministries, nil
+func (r *OrganizationRepository) GetMinistryByID(id int) (models.Ministry, error) {
+	var m ministryRow
+	err := r.DB.QueryRow(`SELECT `+ministryColumns+` FROM `+r.ministrySource()+` m WHERE m.id = $1`, id).Scan(m.dest()...)
+	if err == sql.ErrNoRows {
+		return models.Ministry{}, ErrNotFound
+	} else if err != nil {
+		return models.Ministry{}, err
+	}
+	return m.toModel(), nil
 }
 
-func (r *OrganizationRepository) GetMinistriesWithDepartmentsPaginated(limit, offset int) ([]models.MinistryWithDepartments, error) {
-	query := `
-        SELECT 
-            m.id, m.name, m.google_map_script,
-            d.id, d.name, d.google_map_script, d.ministry_id
-        FROM ministry m
-        LEFT JOIN department d ON m.id = d.ministry_id
-        ORDER BY m.id
-        LIMIT $1 OFFSET $2
-    `
-	rows, err := r.DB.Query(query, limit, offset)
+func (r *OrganizationRepository) GetMinistryByIDWithDepartments(id int) (models.MinistryWithDepartments, error) {
+	var ministryWithDepts models.MinistryWithDepartments
+
+	rows, err := r.DB.Query(`
+		SELECT `+ministryColumns+`, `+departmentColumns+`
+		FROM `+r.ministrySource()+` m
+		LEFT JOIN `+r.departmentSource()+` d ON m.id = d.ministry_id
+		WHERE m.id = $1
+		ORDER BY d.id
+	`, id)
 	if err != nil {
-		return nil, err
+		return ministryWithDepts, err
 	}
 	defer rows.Close()
 
-	ministriesMap := make(map[int]*models.MinistryWithDepartments)
-
 	for rows.Next() {
-		var mID int
-		var mName, mMap string
-		var dID sql.NullInt64
-		var dName, dMap sql.NullString
-		var dMinistryID sql.NullInt64
+		var m ministryRow
+		var d departmentRow
 
-		if err := rows.Scan(&mID, &mName, &mMap, &dID, &dName, &dMap, &dMinistryID); err != nil {
-			return nil, err
+		if err := rows.Scan(append(m.dest(), d.dest()...)...); err != nil {
+			return ministryWithDepts, err
 		}
 
-		if _, exists := ministriesMap[mID]; !exists {
-			ministriesMap[mID] = &models.MinistryWithDepartments{
-				Ministry: models.Ministry{
-					ID:                mID,
-					Name:              mName,
-					Google_map_script: mMap,
-				},
-			}
+		// Assign ministry details once
+		if ministryWithDepts.Ministry.ID == 0 {
+			ministryWithDepts.Ministry = m.toModel()
 		}
 
-		if dID.Valid {
-			dept := models.Department{
-				ID:                int(dID.Int64),
-				Name:              dName.String,
-				MinistryID:        int(dMinistryID.Int64),
-				Google_map_script: dMap.String,
-			}
-			ministriesMap[mID].Departments = append(ministriesMap[mID].Departments, dept)
+		// Add department if present
+		if d.ID.Valid {
+			ministryWithDepts.Departments = append(ministryWithDepts.Departments, d.toModel())
 		}
 	}
 
-	var ministries []models.MinistryWithDepartments
-	for _, m := range ministriesMap {
-		ministries = append(ministries, *m)
+	if err := rows.Err(); err != nil {
+		return ministryWithDepts, err
+	}
+	if ministryWithDepts.Ministry.ID == 0 {
+		return ministryWithDepts, ErrNotFound
 	}
 
-	return ministries, nil
+	return ministryWithDepts, nil
 }
 
-func (r *OrganizationRepository) GetAllDepartments() ([]models.Department, error) {
-	rows, err := r.DB.Query(`SELECT id, name, ministry_id, google_map_script FROM department`)
-	if err != nil {
+func (r *OrganizationRepository) GetDepartmentByID(id int) (*models.Department, error) {
+	row := r.DB.QueryRow(`SELECT `+departmentColumns+` FROM `+r.departmentSource()+` d WHERE d.id = $1`, id)
+
+	var d departmentRow
+	err := row.Scan(d.dest()...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var departments []models.Department
-	for rows.Next() {
-		var d models.Department
-		err := rows.Scan(&d.ID, &d.Name, &d.MinistryID, &d.Google_map_script)
-		if err != nil {
+	dept := d.toModel()
+	// Offices are not versioned, so dated reads leave them out.
+	if r.asOf == nil {
+		if dept.Offices, err = r.GetDepartmentOffices(id); err != nil {
 			return nil, err
 		}
-		departments = append(departments, d)
 	}
+	return &dept, nil
+}
 
-	return departments, nil
+func (r *OrganizationRepository) UpdateMinistry(ministry models.Ministry) error {
+	return withTx(r.DB, func(tx *sql.Tx) error {
+		return updateMinistry(tx, ministry, models.Today())
+	})
 }
 
-func (r *OrganizationRepository) CreateMinistry(ministry models.Ministry) (int, error) {
-	var id int
-	err := r.DB.QueryRow(`INSERT INTO ministry (name, google_map_script) VALUES ($1, $2) RETURNING id`,
-		ministry.Name, ministry.Google_map_script).Scan(&id)
-	return id, err
+func updateMinistry(q dbtx, ministry models.Ministry, effective models.Date) error {
+	if ministry.ParentID != nil {
+		if err := checkMinistryParent(q, ministry.ID, *ministry.ParentID); err != nil {
+			return err
+		}
+	}
+
+	res, err := q.Exec(`UPDATE ministry SET name = $1, kind = COALESCE($2, 'ministry'), parent_id = $3, google_map_script = $4,
+		latitude = $5, longitude = $6, address = $7, geometry = $8, names = $9, search_key = $10 WHERE id = $11`,
+		ministry.Name, nullString(string(ministry.Kind)), ministry.ParentID, ministry.Google_map_script,
+		ministry.Latitude, ministry.Longitude, nullString(ministry.Address), nullGeometry(ministry.Geometry),
+		encodeNames(ministry.Names), searchKey(ministry.Name, ministry.Names), ministry.ID)
+	if err != nil {
+		return translateConstraintError(err)
+	}
+	if err := expectRowsAffected(res); err != nil {
+		return err
+	}
+	return ministryVersions.record(q, ministry.ID, effective)
 }
 
-func (r *OrganizationRepository) CreateDepartment(dept models.Department) (int, error) {
-	var id int
-	err := r.DB.QueryRow(`INSERT INTO department (name, ministry_id, google_map_script) VALUES ($1, $2, $3) RETURNING id`, dept.Name, dept.MinistryID, dept.Google_map_script).Scan(&id)
-	return id, err
+// UpdateDepartment saves dept. When its ministry changes, the divisions and
+// units below it move to the new ministry as well.
+func (r *OrganizationRepository) UpdateDepartment(dept models.Department) error {
+	return withTx(r.DB, func(tx *sql.Tx) error {
+		return updateDepartment(tx, dept, models.Today())
+	})
 }
 
-func (r *OrganizationRepository) GetMinistryByID(id int) (models.Ministry, error) {
-	var ministry models.Ministry
-	err := r.DB.QueryRow(`SELECT id, name, google_map_script FROM ministry WHERE id = $1`, id).Scan(
-		&ministry.ID, &ministry.Name, &ministry.Google_map_script)
+func updateDepartment(q dbtx, dept models.Department, effective models.Date) error {
+	if dept.ParentID != nil {
+		if err := checkDepartmentParent(q, dept.ID, *dept.ParentID, dept.MinistryID); err != nil {
+			return err
+		}
+	}
+
+	res, err := q.Exec(`UPDATE department SET name = $1, kind = COALESCE($2, 'department'), parent_id = $3, ministry_id = $4,
+		google_map_script = $5, admin_area_id = $6, latitude = $7, longitude = $8, address = $9, geometry = $10, names = $11,
+		search_key = $12 WHERE id = $13`,
+		dept.Name, nullString(string(dept.Kind)), dept.ParentID, dept.MinistryID, dept.Google_map_script, dept.AdminAreaID,
+		dept.Latitude, dept.Longitude, nullString(dept.Address), nullGeometry(dept.Geometry), encodeNames(dept.Names),
+		searchKey(dept.Name, dept.Names), dept.ID)
+	if err != nil {
+		return translateConstraintError(err)
+	}
+	if err := expectRowsAffected(res); err != nil {
+		return err
+	}
+	if err := departmentVersions.record(q, dept.ID, effective); err != nil {
+		return err
+	}
+
+	moved, err := queryIDs(q, `
+		WITH RECURSIVE units AS (
+			SELECT id FROM department WHERE parent_id = $1
+			UNION
+			SELECT d.id FROM department d JOIN units u ON d.parent_id = u.id
+		)
+		UPDATE department SET ministry_id = $2
+		WHERE id IN (SELECT id FROM units) AND ministry_id IS DISTINCT FROM $2
+		RETURNING id
+	`, dept.ID, dept.MinistryID)
 	if err != nil {
-		return ministry, err
+		return translateConstraintError(err)
 	}
-	return ministry, nil
+	return departmentVersions.recordAll(q, moved, effective)
 }
 
-func (r *OrganizationRepository) GetMinistryByIDWithDepartments(id int) (models.MinistryWithDepartments, error) {
-	var ministryWithDepts models.MinistryWithDepartments
+func (r *OrganizationRepository) UpdateMinistryLocation(id int, loc models.Location) error {
+	res, err := r.DB.Exec(`UPDATE ministry SET latitude = $1, longitude = $2, address = $3, geometry = $4 WHERE id = $5`,
+		loc.Latitude, loc.Longitude, nullString(loc.Address), nullGeometry(loc.Geometry), id)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+func (r *OrganizationRepository) UpdateDepartmentLocation(id int, loc models.Location) error {
+	res, err := r.DB.Exec(`UPDATE department SET latitude = $1, longitude = $2, address = $3, geometry = $4 WHERE id = $5`,
+		loc.Latitude, loc.Longitude, nullString(loc.Address), nullGeometry(loc.Geometry), id)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+// FindDepartmentsNearby returns the departments within q.RadiusKm of the
+// query point, nearest first, using the haversine great-circle distance.
+func (r *OrganizationRepository) FindDepartmentsNearby(q models.NearbyQuery) ([]models.NearbyDepartment, error) {
+	// Degrees of latitude spanned by the radius, used to prefilter rows.
+	latSpan := q.RadiusKm / (geo.EarthRadiusKm * math.Pi / 180)
 
 	rows, err := r.DB.Query(`
-		SELECT 
-			m.id, m.name, m.google_map_script,
-			d.id, d.name, d.google_map_script, d.ministry_id
-		FROM ministry m
-		LEFT JOIN department d ON m.id = d.ministry_id
-		WHERE m.id = $1
-	`, id)
+		SELECT `+departmentColumns+`, d.distance_km
+		FROM (
+			SELECT src.*, 2 * $5::float8 * ASIN(LEAST(1, SQRT(
+				POWER(SIN(RADIANS(latitude - $1) / 2), 2) +
+				COS(RADIANS($1)) * COS(RADIANS(latitude)) *
+				POWER(SIN(RADIANS(longitude - $2) / 2), 2)
+			))) AS distance_km
+			FROM `+r.departmentSource()+` src
+			WHERE latitude IS NOT NULL AND longitude IS NOT NULL
+			  AND latitude BETWEEN $1 - $6::float8 AND $1 + $6::float8
+		) d
+		WHERE d.distance_km <= $3
+		ORDER BY d.distance_km, d.id
+		LIMIT $4
+	`, q.Latitude, q.Longitude, q.RadiusKm, q.Limit, geo.EarthRadiusKm, latSpan)
 	if err != nil {
-		return ministryWithDepts, err
+		return nil, err
 	}
 	defer rows.Close()
 
+	departments := []models.NearbyDepartment{}
 	for rows.Next() {
-		var mID int
-		var mName, mScript string
-		var dID sql.NullInt64
-		var dName, dScript sql.NullString
-		var dMinistryID sql.NullInt64
-
-		err := rows.Scan(&mID, &mName, &mScript, &dID, &dName, &dScript, &dMinistryID)
-		if err != nil {
-			return ministryWithDepts, err
+		var d departmentRow
+		var distance float64
+		if err := rows.Scan(append(d.dest(), &distance)...); err != nil {
+			return nil, err
 		}
+		departments = append(departments, models.NearbyDepartment{Department: d.toModel(), DistanceKm: distance})
+	}
 
-		// Assign ministry details once
-		if ministryWithDepts.Ministry.ID == 0 {
-			ministryWithDepts.Ministry = models.Ministry{
-				ID:                mID,
-				Name:              mName,
-				Google_map_script: mScript,
-			}
-		}
+	return departments, rows.Err()
+}
 
-		// Add department if present
-		if dID.Valid {
-			dept := models.Department{
-				ID:                int(dID.Int64),
-				Name:              dName.String,
-				Google_map_script: dScript.String,
-				MinistryID:        int(dMinistryID.Int64),
-			}
-			ministryWithDepts.Departments = append(ministryWithDepts.Departments, dept)
-		}
+// bboxCondition matches rows whose coordinates fall inside the box given as
+// $1..$4 (minLon, minLat, maxLon, maxLat), including boxes that cross the
+// antimeridian.
+const bboxCondition = `
+	latitude BETWEEN $2::float8 AND $4::float8
+	AND (
+		($1::float8 <= $3::float8 AND longitude BETWEEN $1::float8 AND $3::float8)
+		OR ($1::float8 > $3::float8 AND (longitude >= $1::float8 OR longitude <= $3::float8))
+	)`
+
+// GetDepartmentsInBBox returns up to limit departments located inside bbox,
+// ordered by id.
+func (r *OrganizationRepository) GetDepartmentsInBBox(bbox models.BBox, limit int) ([]models.Department, error) {
+	rows, err := r.DB.Query(`
+		SELECT `+departmentColumns+`
+		FROM `+r.departmentSource()+` d
+		WHERE `+bboxCondition+`
+		ORDER BY d.id
+		LIMIT $5
+	`, bbox.MinLon, bbox.MinLat, bbox.MaxLon, bbox.MaxLat, limit)
+	if err != nil {
+		return nil, err
 	}
+	return scanDepartments(rows)
+}
 
-	// If no rows found, return error
-	if ministryWithDepts.Ministry.ID == 0 {
-		return ministryWithDepts, sql.ErrNoRows
+// GetMinistriesInBBox returns up to limit ministries located inside bbox,
+// ordered by id.
+func (r *OrganizationRepository) GetMinistriesInBBox(bbox models.BBox, limit int) ([]models.Ministry, error) {
+	rows, err := r.DB.Query(`
+		SELECT `+ministryColumns+`
+		FROM `+r.ministrySource()+` m
+		WHERE `+bboxCondition+`
+		ORDER BY m.id
+		LIMIT $5
+	`, bbox.MinLon, bbox.MinLat, bbox.MaxLon, bbox.MaxLat, limit)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return ministryWithDepts, nil
+	ministries := []models.Ministry{}
+	for rows.Next() {
+		var m ministryRow
+		if err := rows.Scan(m.dest()...); err != nil {
+			return nil, err
+		}
+		ministries = append(ministries, m.toModel())
+	}
+
+	return ministries, rows.Err()
 }
 
-func (r *OrganizationRepository) GetDepartmentByID(id int) (*models.Department, error) {
-	row := r.DB.QueryRow(`SELECT id, name, google_map_script, ministry_id FROM department WHERE id = $1`, id)
+// DeleteMinistry removes a ministry. What happens to its departments is
+// decided by mode; reassignTo is only used with models.DeleteReassign.
+func (r *OrganizationRepository) DeleteMinistry(id int, mode models.MinistryDeleteMode, reassignTo int) error {
+	return withTx(r.DB, func(tx *sql.Tx) error {
+		return deleteMinistry(tx, id, mode, reassignTo, models.Today())
+	})
+}
 
-	var dept models.Department
-	err := row.Scan(&dept.ID, &dept.Name, &dept.Google_map_script, &dept.MinistryID)
+func deleteMinistry(q dbtx, id int, mode models.MinistryDeleteMode, reassignTo int, effective models.Date) error {
+	var lockedID int
+	err := q.QueryRow(`SELECT id FROM ministry WHERE id = $1 FOR UPDATE`, id).Scan(&lockedID)
 	if err == sql.ErrNoRows {
-		return nil, nil
+		return ErrNotFound
 	} else if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &dept, nil
+	departments, err := queryIDs(q, `SELECT id FROM department WHERE ministry_id = $1`, id)
+	if err != nil {
+		return err
+	}
+	subMinistries, err := queryIDs(q, `SELECT id FROM ministry WHERE parent_id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case models.DeleteCascade:
+		for _, deptID := range departments {
+			if err := departmentVersions.close(q, deptID, effective); err != nil {
+				return err
+			}
+		}
+		if _, err := q.Exec(`DELETE FROM department WHERE ministry_id = $1`, id); err != nil {
+			return err
+		}
+	case models.DeleteReassign:
+		if reassignTo == id {
+			return ErrInvalidReassignTarget
+		}
+		err = q.QueryRow(`SELECT id FROM ministry WHERE id = $1 FOR SHARE`, reassignTo).Scan(&lockedID)
+		if err == sql.ErrNoRows {
+			return ErrInvalidReassignTarget
+		} else if err != nil {
+			return err
+		}
+		if _, err := q.Exec(`UPDATE department SET ministry_id = $1 WHERE ministry_id = $2`, reassignTo, id); err != nil {
+			return err
+		}
+		if err := departmentVersions.recordAll(q, departments, effective); err != nil {
+			return err
+		}
+	default:
+		if len(departments) > 0 {
+			return ErrMinistryHasDepartments
+		}
+	}
+
+	if _, err := q.Exec(`DELETE FROM ministry WHERE id = $1`, id); err != nil {
+		return err
+	}
+	if err := ministryVersions.close(q, id, effective); err != nil {
+		return err
+	}
+	// The foreign key has detached any state ministries; record that.
+	return ministryVersions.recordAll(q, subMinistries, effective)
+}
+
+func (r *OrganizationRepository) DeleteDepartment(id int) error {
+	return withTx(r.DB, func(tx *sql.Tx) error {
+		return deleteDepartment(tx, id, models.Today())
+	})
+}
+
+func deleteDepartment(q dbtx, id int, effective models.Date) error {
+	units, err := queryIDs(q, `SELECT id FROM department WHERE parent_id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	res, err := q.Exec(`DELETE FROM department WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if err := expectRowsAffected(res); err != nil {
+		return err
+	}
+	if err := departmentVersions.close(q, id, effective); err != nil {
+		return err
+	}
+	// The foreign key has detached any divisions; record that.
+	return departmentVersions.recordAll(q, units, effective)
 }
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"go-mysql-backend/internal/models"
+
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// ministryColumns and departmentColumns are the columns read into
+// ministryRow and departmentRow. Queries must alias the tables m and d.
+const (
+	ministryColumns   = `m.id, m.name, m.names, m.kind, m.parent_id, m.google_map_script, m.latitude, m.longitude, m.address, m.geometry`
+	departmentColumns = `d.id, d.name, d.names, d.kind, d.parent_id, d.ministry_id, d.google_map_script, d.admin_area_id, d.latitude, d.longitude, d.address, d.geometry`
+)
+
+// ministryRow scans ministryColumns. Every column is nullable so the same
+// type works on the outer side of a join.
+type ministryRow struct {
+	ID        sql.NullInt64
+	Name      sql.NullString
+	Names     []byte
+	Kind      sql.NullString
+	ParentID  sql.NullInt64
+	MapScript sql.NullString
+	Location  nullLocation
+}
+
+func (m *ministryRow) dest() []interface{} {
+	return append([]interface{}{&m.ID, &m.Name, &m.Names, &m.Kind, &m.ParentID, &m.MapScript}, m.Location.dest()...)
+}
+
+func (m ministryRow) toModel() models.Ministry {
+	return models.Ministry{
+		ID:                int(m.ID.Int64),
+		Name:              m.Name.String,
+		Names:             decodeNames(m.Names),
+		Kind:              models.OrgKind(m.Kind.String),
+		ParentID:          nullIntPtr(m.ParentID),
+		Google_map_script: m.MapScript.String,
+		Location:          m.Location.toModel(),
+	}
+}
+
+// departmentRow scans departmentColumns.
+type departmentRow struct {
+	ID          sql.NullInt64
+	Name        sql.NullString
+	Names       []byte
+	Kind        sql.NullString
+	ParentID    sql.NullInt64
+	MinistryID  sql.NullInt64
+	MapScript   sql.NullString
+	AdminAreaID sql.NullInt64
+	Location    nullLocation
+}
+
+func (d *departmentRow) dest() []interface{} {
+	return append([]interface{}{&d.ID, &d.Name, &d.Names, &d.Kind, &d.ParentID, &d.MinistryID, &d.MapScript, &d.AdminAreaID}, d.Location.dest()...)
+}
+
+func (d departmentRow) toModel() models.Department {
+	return models.Department{
+		ID:                int(d.ID.Int64),
+		Name:              d.Name.String,
+		Names:             decodeNames(d.Names),
+		Kind:              models.OrgKind(d.Kind.String),
+		ParentID:          nullIntPtr(d.ParentID),
+		MinistryID:        int(d.MinistryID.Int64),
+		Google_map_script: d.MapScript.String,
+		AdminAreaID:       nullIntPtr(d.AdminAreaID),
+		Location:          d.Location.toModel(),
+	}
+}
+
+// scanDepartments reads every row of a query selecting departmentColumns.
+// scanMinistriesWithDepartments reads joined ministry and department rows
+// ordered by ministry, keeping that order.
+func scanMinistriesWithDepartments(rows *sql.Rows) ([]models.MinistryWithDepartments, error) {
+	defer rows.Close()
+
+	ministries := []models.MinistryWithDepartments{}
+	for rows.Next() {
+		var m ministryRow
+		var d departmentRow
+		if err := rows.Scan(append(m.dest(), d.dest()...)...); err != nil {
+			return nil, err
+		}
+
+		if n := len(ministries); n == 0 || ministries[n-1].ID != int(m.ID.Int64) {
+			ministries = append(ministries, models.MinistryWithDepartments{Ministry: m.toModel()})
+		}
+		if d.ID.Valid {
+			last := &ministries[len(ministries)-1]
+			last.Departments = append(last.Departments, d.toModel())
+		}
+	}
+	return ministries, rows.Err()
+}
+
+func scanDepartments(rows *sql.Rows) ([]models.Department, error) {
+	defer rows.Close()
+
+	departments := []models.Department{}
+	for rows.Next() {
+		var d departmentRow
+		if err := rows.Scan(d.dest()...); err != nil {
+			return nil, err
+		}
+		departments = append(departments, d.toModel())
+	}
+	return departments, rows.Err()
+}
+
+// nullLocation holds the nullable location columns of a ministry or
+// department row while scanning.
+type nullLocation struct {
+	Latitude  sql.NullFloat64
+	Longitude sql.NullFloat64
+	Address   sql.NullString
+	Geometry  []byte
+}
+
+func (n *nullLocation) dest() []interface{} {
+	return []interface{}{&n.Latitude, &n.Longitude, &n.Address, &n.Geometry}
+}
+
+func (n nullLocation) toModel() models.Location {
+	var loc models.Location
+	if n.Latitude.Valid && n.Longitude.Valid {
+		lat, lon := n.Latitude.Float64, n.Longitude.Float64
+		loc.Latitude, loc.Longitude = &lat, &lon
+	}
+	loc.Address = n.Address.String
+	if len(n.Geometry) > 0 {
+		loc.Geometry = json.RawMessage(n.Geometry)
+	}
+	return loc
+}
+
+// decodeNames reads a names column. The column only ever holds objects
+// written by LocalizedNames.Encode, so a value that does not decode is
+// treated as having no localized names.
+func decodeNames(data []byte) models.LocalizedNames {
+	names, err := models.DecodeNames(data)
+	if err != nil {
+		return nil
+	}
+	return names
+}
+
+// encodeNames returns names for a JSONB column.
+func encodeNames(names models.LocalizedNames) string {
+	encoded, err := names.Encode()
+	if err != nil {
+		return "{}"
+	}
+	return encoded
+}
+
+// dbtx is the query interface shared by *sql.DB and *sql.Tx.
+type dbtx interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// withTx runs fn in a transaction, committing when it returns nil.
+func withTx(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func nullIntPtr(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullGeometry passes GeoJSON to a JSONB column as text; lib/pq would send
+// a []byte as bytea.
+func nullGeometry(g json.RawMessage) sql.NullString {
+	if len(g) == 0 || string(g) == "null" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(g), Valid: true}
+}
+
+// expectRowsAffected turns an UPDATE/DELETE that matched nothing into ErrNotFound.
+func expectRowsAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// translateConstraintError maps the constraint violations of Postgres and
+// SQLite to repository errors.
+func translateConstraintError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation
+		return ErrInvalidReference
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+		return ErrInvalidReference
+	}
+	return err
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"database/sql"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/search"
+
+	"github.com/lib/pq"
+)
+
+// searchQuery returns the best $3 of the ministries, departments and
+// offices whose search column matches $1 or the phonetic keys in $4. The
+// text is parsed twice: with the english configuration, which stems
+// English words as the indexed names are, and with simple for Sinhala and
+// Tamil. $4 matches the search_key column, so a romanized query finds
+// names written in Sinhala or Tamil script. $2 lists the entities to
+// search.
+const searchQuery = `
+	WITH query AS (
+		SELECT websearch_to_tsquery('english', $1) || websearch_to_tsquery('simple', $1)
+			|| CASE WHEN $4 = '' THEN ''::tsquery ELSE to_tsquery('simple', $4) END AS q
+	), hits AS (
+		SELECT 'ministry' AS entity, 1 AS entity_order, m.id, m.name, m.names, NULL::int AS parent_id,
+			ts_rank(m.search, query.q) AS score,
+			concat_ws(' · ', m.name, m.names->>'si', m.names->>'ta', m.address) AS text
+		FROM ministry m, query
+		WHERE 'ministry' = ANY($2) AND m.search @@ query.q
+		UNION ALL
+		SELECT 'department', 2, d.id, d.name, d.names, d.ministry_id,
+			ts_rank(d.search, query.q),
+			concat_ws(' · ', d.name, d.names->>'si', d.names->>'ta', d.address)
+		FROM department d, query
+		WHERE 'department' = ANY($2) AND d.search @@ query.q
+		UNION ALL
+		SELECT 'office', 3, o.id, o.name, '{}'::jsonb, o.department_id,
+			ts_rank(o.search, query.q),
+			concat_ws(' · ', o.name, o.address)
+		FROM office o, query
+		WHERE 'office' = ANY($2) AND o.search @@ query.q
+		ORDER BY score DESC, entity_order, id
+		LIMIT $3
+	)
+	SELECT entity, id, name, names, parent_id, score, text
+	FROM hits
+	ORDER BY score DESC, entity_order, id
+`
+
+// Search returns the best matches for q from the generated search
+// columns, which are covered by GIN indexes. It always searches the
+// current structure, also on an as-of view.
+func (r *OrganizationRepository) Search(q models.SearchQuery) ([]models.SearchHit, error) {
+	entities := make([]string, len(q.Entities))
+	for i, entity := range q.Entities {
+		entities[i] = string(entity)
+	}
+
+	rows, err := r.DB.Query(searchQuery, q.Text, pq.Array(entities), q.Limit, search.KeyQuery(q.Text))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	terms := search.Terms(q.Text)
+	hits := []models.SearchHit{}
+	for rows.Next() {
+		var hit models.SearchHit
+		var names []byte
+		var parentID sql.NullInt64
+		var text string
+		if err := rows.Scan(&hit.Entity, &hit.ID, &hit.Name, &names, &parentID, &hit.Score, &text); err != nil {
+			return nil, err
+		}
+		// Highlighted here rather than with ts_headline, which knows
+		// nothing of the phonetic keys.
+		hit.Snippet = search.Highlight(text, terms)
+		hit.Names = decodeNames(names)
+		if parentID.Valid {
+			id := int(parentID.Int64)
+			hit.ParentID = &id
+		}
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
@@ -0,0 +1,167 @@
+package repository_tests
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-mysql-backend/internal/migrations"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+	"go-mysql-backend/internal/repository/repotest"
+
+	_ "github.com/lib/pq"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The conformance suite needs a database it may wipe, so it only runs
+// against the ones named in TEST_POSTGRES_DSN and TEST_NEO4J_URI. Both
+// runners migrate their database before the suite.
+
+func TestPostgresConformance(t *testing.T) {
+	db := openPostgres(t)
+	migrate(t, migrations.NewPostgresTarget(db), migrations.Postgres)
+	reset := func(t *testing.T) {
+		_, err := db.Exec(`TRUNCATE ministry, department, ministry_version, department_version,
+			gazette_change, office, position_term, person, admin_area RESTART IDENTITY CASCADE`)
+		require.NoError(t, err)
+	}
+
+	repotest.TestOrganizationRepo(t, func(t *testing.T) repository.OrganizationRepo {
+		reset(t)
+		return repository.NewOrganizationRepository(db)
+	})
+	repotest.TestPositionRepo(t, func(t *testing.T) (repository.OrganizationRepo, repository.PositionRepo) {
+		reset(t)
+		return repository.NewOrganizationRepository(db), repository.NewPositionRepository(db)
+	})
+}
+
+// TestPostgresUpgrade migrates a database created from the schema the
+// first release documented, with a row in each table, to the latest one.
+func TestPostgresUpgrade(t *testing.T) {
+	db := openPostgres(t)
+	_, err := db.Exec(`DROP TABLE IF EXISTS ministry, department, ministry_version, department_version,
+		gazette_change, office, position_term, person, admin_area, schema_migrations CASCADE`)
+	require.NoError(t, err)
+	_, err = db.Exec(`
+		CREATE TABLE ministry (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			google_map_script TEXT
+		);
+		CREATE TABLE department (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			ministry_id INTEGER REFERENCES ministry(id),
+			google_map_script TEXT
+		);
+		INSERT INTO ministry (name, google_map_script) VALUES ('Ministry of Health', '<iframe></iframe>');
+		INSERT INTO department (name, ministry_id) VALUES ('Department of Health Services', 1);
+	`)
+	require.NoError(t, err)
+
+	target := migrations.NewPostgresTarget(db)
+	migrate(t, target, migrations.Postgres)
+	all, err := migrations.Load(migrations.Postgres)
+	require.NoError(t, err)
+	require.NoError(t, migrations.Check(target, all))
+
+	repo := repository.NewOrganizationRepository(db)
+	ministry, err := repo.GetMinistryByIDWithDepartments(1)
+	require.NoError(t, err)
+	assert.Equal(t, "Ministry of Health", ministry.Name)
+	assert.Equal(t, models.KindMinistry, ministry.Kind)
+	require.Len(t, ministry.Departments, 1)
+	assert.Equal(t, models.KindDepartment, ministry.Departments[0].Kind)
+
+	// The history migration opened versions for the existing rows.
+	current, err := repo.AsOf(models.Today()).GetMinistriesWithDepartments()
+	require.NoError(t, err)
+	require.Len(t, current, 1)
+	assert.Len(t, current[0].Departments, 1)
+
+	id, err := repo.CreateDepartment(models.Department{Name: "Medical Supplies Division", MinistryID: 1,
+		Kind: models.KindDivision, Names: models.LocalizedNames{models.LocaleSinhala: "වෛද්‍ය සැපයුම් අංශය"}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, id)
+}
+
+func openPostgres(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestNeo4jConformance(t *testing.T) {
+	uri := os.Getenv("TEST_NEO4J_URI")
+	if uri == "" {
+		t.Skip("TEST_NEO4J_URI is not set")
+	}
+	driver, err := neo4j.NewDriverWithContext(uri,
+		neo4j.BasicAuth(os.Getenv("TEST_NEO4J_USER"), os.Getenv("TEST_NEO4J_PASSWORD"), ""))
+	require.NoError(t, err)
+	ctx := context.Background()
+	t.Cleanup(func() { driver.Close(ctx) })
+	migrate(t, migrations.NewNeo4jTarget(driver), migrations.Neo4j)
+
+	reset := func(t *testing.T) {
+		_, err := neo4j.ExecuteQuery(ctx, driver, `MATCH (n) WHERE NOT n:SchemaMigration DETACH DELETE n`,
+			nil, neo4j.EagerResultTransformer)
+		require.NoError(t, err)
+	}
+
+	repotest.TestOrganizationRepo(t, func(t *testing.T) repository.OrganizationRepo {
+		reset(t)
+		return repository.NewNeo4jRepository(driver)
+	})
+	repotest.TestPositionRepo(t, func(t *testing.T) (repository.OrganizationRepo, repository.PositionRepo) {
+		reset(t)
+		return repository.NewNeo4jRepository(driver), repository.NewNeo4jPositionRepository(driver)
+	})
+}
+
+func migrate(t *testing.T, target migrations.Target, dialect migrations.Dialect) {
+	t.Helper()
+	all, err := migrations.Load(dialect)
+	require.NoError(t, err)
+	_, err = migrations.Up(target, all)
+	require.NoError(t, err)
+}
+
+func TestMemoryConformance(t *testing.T) {
+	repotest.TestOrganizationRepo(t, func(t *testing.T) repository.OrganizationRepo {
+		return repository.NewMemoryRepository(repository.NewMemoryStore())
+	})
+	repotest.TestPositionRepo(t, func(t *testing.T) (repository.OrganizationRepo, repository.PositionRepo) {
+		store := repository.NewMemoryStore()
+		return repository.NewMemoryRepository(store), repository.NewMemoryPositionRepository(store)
+	})
+}
+
+func TestSQLiteConformance(t *testing.T) {
+	open := func(t *testing.T) *sql.DB {
+		db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "gov_geo.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		return db
+	}
+
+	repotest.TestOrganizationRepo(t, func(t *testing.T) repository.OrganizationRepo {
+		return repository.NewSQLiteRepository(open(t))
+	})
+	repotest.TestPositionRepo(t, func(t *testing.T) (repository.OrganizationRepo, repository.PositionRepo) {
+		db := open(t)
+		return repository.NewSQLiteRepository(db), repository.NewPositionRepository(db)
+	})
+}
@@ -0,0 +1,141 @@
+package repository_tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const memoryFixture = `{
+  "ministries": [
+    {"id": 1, "name": "Ministry of Health", "latitude": 6.9271, "longitude": 79.8612}
+  ],
+  "departments": [
+    {"id": 10, "name": "Department of Health Services", "ministry_id": 1, "admin_area_id": 2,
+     "offices": [{"id": 100, "name": "Head Office", "phone_numbers": ["011 269 4033"]}]}
+  ],
+  "admin_areas": [
+    {"id": 1, "code": "LK-1", "name": "Western", "level": "province"},
+    {"id": 2, "code": "LK-11", "name": "Colombo", "level": "district", "parent_code": "LK-1"}
+  ],
+  "people": [{"id": 1, "name": "A. Perera"}],
+  "positions": [
+    {"id": 1, "person_id": 1, "role": "minister", "entity": "ministry", "organization_id": 1, "start_date": "2024-01-01"}
+  ]
+}`
+
+func writeFixture(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadMemoryStore_Fixture(t *testing.T) {
+	store, err := repository.LoadMemoryStore(writeFixture(t, memoryFixture))
+	require.NoError(t, err)
+	repo := repository.NewMemoryRepository(store)
+
+	ministry, err := repo.GetMinistryByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, "Ministry of Health", ministry.Name)
+	assert.Equal(t, models.KindMinistry, ministry.Kind)
+
+	dept, err := repo.GetDepartmentByID(10)
+	require.NoError(t, err)
+	require.NotNil(t, dept)
+	require.Len(t, dept.Offices, 1)
+	assert.Equal(t, 10, dept.Offices[0].DepartmentID)
+
+	// Loaded records are open from today, and new ids follow the fixture's.
+	_, err = repo.AsOf(models.Today()).GetMinistryByID(1)
+	assert.NoError(t, err)
+	id, err := repo.CreateDepartment(models.Department{Name: "Medical Supplies Division", MinistryID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 11, id)
+
+	areas := repository.NewMemoryAdminAreaRepository(store)
+	depts, err := areas.GetAreaDepartments(1)
+	require.NoError(t, err)
+	require.Len(t, depts, 1)
+	assert.Equal(t, 10, depts[0].ID)
+	district, err := areas.GetAreaByID(2)
+	require.NoError(t, err)
+	assert.Equal(t, "LK-1", district.ParentCode)
+
+	positions := repository.NewMemoryPositionRepository(store)
+	holders, err := positions.GetOfficeHolders(models.OrgRef{Entity: models.EntityMinistry, ID: 1}, "", models.Today())
+	require.NoError(t, err)
+	require.Len(t, holders, 1)
+	assert.Equal(t, "A. Perera", holders[0].PersonName)
+	assert.Equal(t, "Ministry of Health", holders[0].OrganizationName)
+}
+
+func TestLoadMemoryStore_MissingFile(t *testing.T) {
+	store, err := repository.LoadMemoryStore(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	depts, err := repository.NewMemoryRepository(store).GetAllDepartments()
+	require.NoError(t, err)
+	assert.Empty(t, depts)
+}
+
+func TestLoadMemoryStore_InvalidReference(t *testing.T) {
+	_, err := repository.LoadMemoryStore(writeFixture(t, `{
+		"ministries": [{"id": 1, "name": "Ministry of Health"}],
+		"departments": [{"id": 10, "name": "Orphan", "ministry_id": 2}]
+	}`))
+	assert.ErrorIs(t, err, repository.ErrInvalidReference)
+}
+
+func TestMemoryStore_SaveAndLoad(t *testing.T) {
+	store := repository.NewMemoryStore()
+	repo := repository.NewMemoryRepository(store)
+	ministryID, err := repo.CreateMinistry(models.Ministry{Name: "Ministry of Education"})
+	require.NoError(t, err)
+	deletedID, err := repo.CreateDepartment(models.Department{Name: "Examinations", MinistryID: ministryID})
+	require.NoError(t, err)
+	require.NoError(t, repo.DeleteDepartment(deletedID))
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, store.Save(path))
+
+	loaded, err := repository.LoadMemoryStore(path)
+	require.NoError(t, err)
+	reloaded := repository.NewMemoryRepository(loaded)
+	ministry, err := reloaded.GetMinistryByID(ministryID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ministry of Education", ministry.Name)
+
+	// The sequence survives, so the deleted department's id is not reused.
+	id, err := reloaded.CreateDepartment(models.Department{Name: "Educational Publications", MinistryID: ministryID})
+	require.NoError(t, err)
+	assert.Greater(t, id, deletedID)
+}
+
+func TestMemoryRepository_FailedWriteLeavesNothing(t *testing.T) {
+	repo := repository.NewMemoryRepository(repository.NewMemoryStore())
+	ministryID, err := repo.CreateMinistry(models.Ministry{Name: "Ministry of Trade"})
+	require.NoError(t, err)
+	deptID, err := repo.CreateDepartment(models.Department{Name: "Import and Export Control", MinistryID: ministryID})
+	require.NoError(t, err)
+
+	_, err = repo.ApplyChangeSet(models.ChangeSet{
+		Gazette:       "2400/1",
+		EffectiveDate: models.Today(),
+		Operations: []models.ChangeOp{
+			{Type: models.OpRename, Entity: models.EntityDepartment, ID: deptID, Name: "Department of Commerce"},
+			{Type: models.OpTransfer, ID: deptID, ToMinistryID: 999},
+		},
+	})
+	require.Error(t, err)
+
+	dept, err := repo.GetDepartmentByID(deptID)
+	require.NoError(t, err)
+	assert.Equal(t, "Import and Export Control", dept.Name)
+}
@@ -0,0 +1,89 @@
+package repository_tests
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openSQLite(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := repository.OpenSQLite(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestOpenSQLite_KeepsData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gov_geo.db")
+	id, err := repository.NewSQLiteRepository(openSQLite(t, path)).CreateMinistry(models.Ministry{Name: "Ministry of Health"})
+	require.NoError(t, err)
+
+	// Opening again ensures the schema without touching the rows.
+	ministry, err := repository.NewSQLiteRepository(openSQLite(t, path)).GetMinistryByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, "Ministry of Health", ministry.Name)
+}
+
+func TestSQLiteRepository_SearchRomanized(t *testing.T) {
+	repo := repository.NewSQLiteRepository(openSQLite(t, filepath.Join(t.TempDir(), "gov_geo.db")))
+	id, err := repo.CreateMinistry(models.Ministry{
+		Name:  "Ministry of Health",
+		Names: models.LocalizedNames{models.LocaleSinhala: "සෞඛ්‍ය සේවා අමාත්‍යාංශය"},
+	})
+	require.NoError(t, err)
+
+	for _, text := range []string{"සෞඛ්‍ය", "saukhya sewa", "health"} {
+		hits, err := repo.Search(models.SearchQuery{Text: text, Entities: models.SearchEntities, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, hits, 1, text)
+		assert.Equal(t, id, hits[0].ID)
+	}
+}
+
+// The Postgres admin area and position repositories run unchanged on the
+// SQLite schema.
+func TestSQLite_AreasAndPositions(t *testing.T) {
+	db := openSQLite(t, filepath.Join(t.TempDir(), "gov_geo.db"))
+	orgs := repository.NewSQLiteRepository(db)
+	ministryID, err := orgs.CreateMinistry(models.Ministry{Name: "Ministry of Health"})
+	require.NoError(t, err)
+	deptID, err := orgs.CreateDepartment(models.Department{Name: "Department of Health Services", MinistryID: ministryID})
+	require.NoError(t, err)
+
+	areas := repository.NewAdminAreaRepository(db)
+	require.NoError(t, areas.SaveAreas([]models.AdminArea{
+		{Code: "LK-11", Name: "Colombo", Level: models.LevelDistrict, ParentCode: "LK-1"},
+		{Code: "LK-1", Name: "Western", Level: models.LevelProvince},
+	}))
+	districts, err := areas.GetAreas(models.LevelDistrict)
+	require.NoError(t, err)
+	require.Len(t, districts, 1)
+	assert.Equal(t, "LK-1", districts[0].ParentCode)
+	require.NoError(t, areas.SetDepartmentArea(deptID, &districts[0].ID))
+	missing := 999
+	assert.ErrorIs(t, areas.SetDepartmentArea(deptID, &missing), repository.ErrInvalidReference)
+	depts, err := areas.GetAreaDepartments(*districts[0].ParentID)
+	require.NoError(t, err)
+	require.Len(t, depts, 1)
+	assert.Equal(t, deptID, depts[0].ID)
+
+	positions := repository.NewPositionRepository(db)
+	personID, err := positions.CreatePerson(models.Person{Name: "A. Perera"})
+	require.NoError(t, err)
+	start := models.NewDate(2024, 1, 1)
+	_, err = positions.CreatePosition(models.Position{PersonID: personID, Role: models.RoleMinister,
+		Entity: models.EntityMinistry, OrganizationID: ministryID, StartDate: start})
+	require.NoError(t, err)
+	holders, err := positions.GetOfficeHolders(models.OrgRef{Entity: models.EntityMinistry, ID: ministryID}, "", models.Today())
+	require.NoError(t, err)
+	require.Len(t, holders, 1)
+	assert.Equal(t, "Ministry of Health", holders[0].OrganizationName)
+	assert.True(t, holders[0].StartDate.Equal(start))
+}
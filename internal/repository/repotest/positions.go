@@ -0,0 +1,154 @@
+package repotest
+
+import (
+	"testing"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPositionRepo runs the suite for repository.PositionRepo. newRepos is
+// called once per subtest and must return empty repositories over the same
+// database.
+func TestPositionRepo(t *testing.T, newRepos func(t *testing.T) (repository.OrganizationRepo, repository.PositionRepo)) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, orgs repository.OrganizationRepo, positions repository.PositionRepo)
+	}{
+		{"Terms", testTerms},
+		{"TermsOutliveMerge", testTermsOutliveMerge},
+		{"TermsOutliveDelete", testTermsOutliveDelete},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgs, positions := newRepos(t)
+			tt.fn(t, orgs, positions)
+		})
+	}
+}
+
+func createPosition(t *testing.T, positions repository.PositionRepo, position models.Position) int {
+	t.Helper()
+	id, err := positions.CreatePosition(position)
+	require.NoError(t, err)
+	return id
+}
+
+func positionIDs(terms []models.Position) []int {
+	ids := make([]int, len(terms))
+	for i, term := range terms {
+		ids[i] = term.ID
+	}
+	return ids
+}
+
+func testTerms(t *testing.T, orgs repository.OrganizationRepo, positions repository.PositionRepo) {
+	ministryID := createMinistry(t, orgs, models.Ministry{Name: "Ministry of Health"})
+	personID, err := positions.CreatePerson(models.Person{Name: "A. Perera", Title: "Hon."})
+	require.NoError(t, err)
+	otherID, err := positions.CreatePerson(models.Person{Name: "B. Silva"})
+	require.NoError(t, err)
+
+	ministry := models.OrgRef{Entity: models.EntityMinistry, ID: ministryID}
+	first := createPosition(t, positions, models.Position{PersonID: personID, Role: models.RoleMinister,
+		Entity: models.EntityMinistry, OrganizationID: ministryID, StartDate: models.NewDate(2020, 1, 1)})
+	require.NoError(t, positions.EndPosition(first, models.NewDate(2022, 7, 1)))
+	assert.ErrorIs(t, positions.EndPosition(first, models.NewDate(2019, 1, 1)), repository.ErrInvalidTerm)
+	second := createPosition(t, positions, models.Position{PersonID: otherID, Role: models.RoleMinister,
+		Entity: models.EntityMinistry, OrganizationID: ministryID, StartDate: models.NewDate(2022, 7, 1)})
+
+	_, err = positions.CreatePosition(models.Position{PersonID: personID, Role: models.RoleHead,
+		Entity: models.EntityDepartment, OrganizationID: 999, StartDate: models.NewDate(2020, 1, 1)})
+	assert.ErrorIs(t, err, repository.ErrInvalidReference)
+
+	holders, err := positions.GetOfficeHolders(ministry, models.RoleMinister, models.NewDate(2021, 3, 1))
+	require.NoError(t, err)
+	require.Len(t, holders, 1)
+	assert.Equal(t, first, holders[0].ID)
+	assert.Equal(t, "A. Perera", holders[0].PersonName)
+	assert.Equal(t, "Ministry of Health", holders[0].OrganizationName)
+
+	history, err := positions.GetPositionHistory(ministry, "")
+	require.NoError(t, err)
+	assert.Equal(t, []int{first, second}, positionIDs(history))
+
+	terms, err := positions.GetPersonPositions(otherID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{second}, positionIDs(terms))
+	_, err = positions.GetPersonPositions(999)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+// testTermsOutliveMerge checks that the terms of a department merged into
+// another stay with the department, under its last name.
+func testTermsOutliveMerge(t *testing.T, orgs repository.OrganizationRepo, positions repository.PositionRepo) {
+	ministryID := createMinistry(t, orgs, models.Ministry{Name: "Ministry of Health"})
+	intoID := createDepartment(t, orgs, models.Department{Name: "Department of Health Services", MinistryID: ministryID})
+	mergedID := createDepartment(t, orgs, models.Department{Name: "Medical Supplies Division", MinistryID: ministryID})
+	personID, err := positions.CreatePerson(models.Person{Name: "C. Fernando"})
+	require.NoError(t, err)
+	start := models.NewDate(2023, 1, 1)
+	mergedTerm := createPosition(t, positions, models.Position{PersonID: personID, Role: models.RoleHead,
+		Entity: models.EntityDepartment, OrganizationID: mergedID, StartDate: start})
+	intoTerm := createPosition(t, positions, models.Position{PersonID: personID, Role: models.RoleHead,
+		Entity: models.EntityDepartment, OrganizationID: intoID, StartDate: start.AddDays(1)})
+
+	// A later effective date keeps the versions opened today, and with them
+	// the name of the merged department.
+	_, err = orgs.ApplyChangeSet(models.ChangeSet{
+		Gazette:       "2367/12",
+		EffectiveDate: models.Today().AddDays(30),
+		Operations:    []models.ChangeOp{{Type: models.OpMerge, IntoID: intoID, DepartmentIDs: []int{mergedID}}},
+	})
+	require.NoError(t, err)
+
+	history, err := positions.GetPositionHistory(models.OrgRef{Entity: models.EntityDepartment, ID: mergedID}, "")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, mergedTerm, history[0].ID)
+	assert.Equal(t, models.EntityDepartment, history[0].Entity)
+	assert.Equal(t, mergedID, history[0].OrganizationID)
+	assert.Equal(t, "Medical Supplies Division", history[0].OrganizationName)
+
+	holders, err := positions.GetOfficeHolders(models.OrgRef{Entity: models.EntityDepartment, ID: mergedID}, models.RoleHead, start)
+	require.NoError(t, err)
+	assert.Equal(t, []int{mergedTerm}, positionIDs(holders))
+
+	terms, err := positions.GetPersonPositions(personID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{mergedTerm, intoTerm}, positionIDs(terms))
+	history, err = positions.GetPositionHistory(models.OrgRef{Entity: models.EntityDepartment, ID: intoID}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []int{intoTerm}, positionIDs(history))
+}
+
+// testTermsOutliveDelete checks that deleting a ministry, with its
+// departments, keeps their terms.
+func testTermsOutliveDelete(t *testing.T, orgs repository.OrganizationRepo, positions repository.PositionRepo) {
+	ministryID := createMinistry(t, orgs, models.Ministry{Name: "Ministry of Ports"})
+	deptID := createDepartment(t, orgs, models.Department{Name: "Ports Authority", MinistryID: ministryID})
+	personID, err := positions.CreatePerson(models.Person{Name: "D. Jayasuriya"})
+	require.NoError(t, err)
+	start := models.NewDate(2023, 1, 1)
+	ministerTerm := createPosition(t, positions, models.Position{PersonID: personID, Role: models.RoleMinister,
+		Entity: models.EntityMinistry, OrganizationID: ministryID, StartDate: start})
+	headTerm := createPosition(t, positions, models.Position{PersonID: personID, Role: models.RoleHead,
+		Entity: models.EntityDepartment, OrganizationID: deptID, StartDate: start.AddDays(1)})
+
+	require.NoError(t, orgs.DeleteMinistry(ministryID, models.DeleteCascade, 0))
+
+	terms, err := positions.GetPersonPositions(personID)
+	require.NoError(t, err)
+	require.Equal(t, []int{ministerTerm, headTerm}, positionIDs(terms))
+	assert.Equal(t, models.EntityMinistry, terms[0].Entity)
+	assert.Equal(t, ministryID, terms[0].OrganizationID)
+	assert.Equal(t, models.EntityDepartment, terms[1].Entity)
+	assert.Equal(t, deptID, terms[1].OrganizationID)
+
+	history, err := positions.GetPositionHistory(models.OrgRef{Entity: models.EntityMinistry, ID: ministryID}, models.RoleMinister)
+	require.NoError(t, err)
+	assert.Equal(t, []int{ministerTerm}, positionIDs(history))
+}
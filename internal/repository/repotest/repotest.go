@@ -0,0 +1,565 @@
+// Package repotest is a conformance suite for repository.OrganizationRepo
+// and repository.PositionRepo. Every backend runs it, so a behaviour the
+// service relies on is checked the same way against Postgres, Neo4j and any
+// backend added later.
+package repotest
+
+import (
+	"testing"
+
+	"go-mysql-backend/internal/geo"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOrganizationRepo runs the suite. newRepo is called once per subtest
+// and must return an empty repository.
+func TestOrganizationRepo(t *testing.T, newRepo func(t *testing.T) repository.OrganizationRepo) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo repository.OrganizationRepo)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"NotFound", testNotFound},
+		{"InvalidReferences", testInvalidReferences},
+		{"Hierarchy", testHierarchy},
+		{"UpdateDepartmentMovesDivisions", testUpdateDepartmentMovesDivisions},
+		{"DeleteMinistry", testDeleteMinistry},
+		{"DeleteDepartment", testDeleteDepartment},
+		{"ListsAndPages", testListsAndPages},
+		{"BBox", testBBox},
+		{"Nearby", testNearby},
+		{"Offices", testOffices},
+		{"AsOf", testAsOf},
+		{"ChangeSet", testChangeSet},
+		{"Import", testImport},
+		{"Search", testSearch},
+		{"Tree", testTree},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func at(lat, lon float64) models.Location {
+	return models.Location{Latitude: ptr(lat), Longitude: ptr(lon)}
+}
+
+func createMinistry(t *testing.T, repo repository.OrganizationRepo, ministry models.Ministry) int {
+	t.Helper()
+	id, err := repo.CreateMinistry(ministry)
+	require.NoError(t, err)
+	return id
+}
+
+func createDepartment(t *testing.T, repo repository.OrganizationRepo, dept models.Department) int {
+	t.Helper()
+	id, err := repo.CreateDepartment(dept)
+	require.NoError(t, err)
+	return id
+}
+
+func getDepartment(t *testing.T, repo repository.OrganizationRepo, id int) models.Department {
+	t.Helper()
+	dept, err := repo.GetDepartmentByID(id)
+	require.NoError(t, err)
+	require.NotNil(t, dept)
+	return *dept
+}
+
+func departmentIDs(depts []models.Department) []int {
+	ids := make([]int, len(depts))
+	for i, dept := range depts {
+		ids[i] = dept.ID
+	}
+	return ids
+}
+
+func nearbyDepartmentIDs(depts []models.NearbyDepartment) []int {
+	ids := make([]int, len(depts))
+	for i, dept := range depts {
+		ids[i] = dept.ID
+	}
+	return ids
+}
+
+func testCreateAndGet(t *testing.T, repo repository.OrganizationRepo) {
+	loc := at(6.9271, 79.8612)
+	loc.Address = "Colombo 01"
+	ministryID := createMinistry(t, repo, models.Ministry{
+		Name:     "Ministry of Health",
+		Names:    models.LocalizedNames{models.LocaleSinhala: "සෞඛ්‍ය අමාත්‍යාංශය"},
+		Location: loc,
+	})
+	deptID := createDepartment(t, repo, models.Department{
+		Name:       "Department of Health Services",
+		MinistryID: ministryID,
+		Location:   at(6.9, 79.86),
+	})
+
+	ministry, err := repo.GetMinistryByID(ministryID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ministry of Health", ministry.Name)
+	assert.Equal(t, models.KindMinistry, ministry.Kind)
+	assert.Equal(t, "සෞඛ්‍ය අමාත්‍යාංශය", ministry.Names[models.LocaleSinhala])
+	assert.Nil(t, ministry.ParentID)
+	require.True(t, ministry.HasCoordinates())
+	assert.InDelta(t, 6.9271, *ministry.Latitude, 1e-9)
+	assert.Equal(t, "Colombo 01", ministry.Address)
+
+	dept := getDepartment(t, repo, deptID)
+	assert.Equal(t, "Department of Health Services", dept.Name)
+	assert.Equal(t, models.KindDepartment, dept.Kind)
+	assert.Equal(t, ministryID, dept.MinistryID)
+	assert.Nil(t, dept.ParentID)
+
+	withDepts, err := repo.GetMinistryByIDWithDepartments(ministryID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{deptID}, departmentIDs(withDepts.Departments))
+
+	ministry.Name = "Ministry of Health and Indigenous Medicine"
+	require.NoError(t, repo.UpdateMinistry(ministry))
+	ministry, err = repo.GetMinistryByID(ministryID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ministry of Health and Indigenous Medicine", ministry.Name)
+
+	dept.Name = "Department of Health"
+	dept.Location = models.Location{}
+	require.NoError(t, repo.UpdateDepartment(dept))
+	dept = getDepartment(t, repo, deptID)
+	assert.Equal(t, "Department of Health", dept.Name)
+	assert.False(t, dept.HasCoordinates())
+}
+
+func testNotFound(t *testing.T, repo repository.OrganizationRepo) {
+	_, err := repo.GetMinistryByID(999)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = repo.GetMinistryByIDWithDepartments(999)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	dept, err := repo.GetDepartmentByID(999)
+	assert.NoError(t, err)
+	assert.Nil(t, dept)
+
+	assert.ErrorIs(t, repo.UpdateMinistry(models.Ministry{ID: 999, Name: "Missing"}), repository.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteMinistry(999, models.DeleteReject, 0), repository.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteDepartment(999), repository.ErrNotFound)
+
+	_, err = repo.GetOfficeByID(999)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteOffice(999), repository.ErrNotFound)
+
+	_, err = repo.GetOrganizationTree(models.OrgRef{Entity: models.EntityMinistry, ID: 999}, 1)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func testInvalidReferences(t *testing.T, repo repository.OrganizationRepo) {
+	_, err := repo.CreateDepartment(models.Department{Name: "Orphan", MinistryID: 999})
+	assert.ErrorIs(t, err, repository.ErrInvalidReference)
+
+	_, err = repo.CreateMinistry(models.Ministry{Name: "State Ministry", ParentID: ptr(999)})
+	assert.ErrorIs(t, err, repository.ErrInvalidReference)
+
+	ministryID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Education"})
+	_, err = repo.CreateDepartment(models.Department{Name: "Division", MinistryID: ministryID, ParentID: ptr(999)})
+	assert.ErrorIs(t, err, repository.ErrInvalidReference)
+
+	_, err = repo.CreateOffice(models.Office{DepartmentID: 999, Name: "Branch"})
+	assert.ErrorIs(t, err, repository.ErrInvalidReference)
+}
+
+func testHierarchy(t *testing.T, repo repository.OrganizationRepo) {
+	parentID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Finance"})
+	stateID := createMinistry(t, repo, models.Ministry{Name: "State Ministry of Revenue", Kind: models.KindStateMinistry, ParentID: ptr(parentID)})
+
+	state, err := repo.GetMinistryByID(stateID)
+	require.NoError(t, err)
+	require.NotNil(t, state.ParentID)
+	assert.Equal(t, parentID, *state.ParentID)
+	assert.Equal(t, models.KindStateMinistry, state.Kind)
+
+	parent, err := repo.GetMinistryByID(parentID)
+	require.NoError(t, err)
+	parent.ParentID = ptr(stateID)
+	assert.ErrorIs(t, repo.UpdateMinistry(parent), repository.ErrInvalidParent)
+
+	deptID := createDepartment(t, repo, models.Department{Name: "Inland Revenue", MinistryID: parentID})
+	unitID := createDepartment(t, repo, models.Department{Name: "Tax Division", MinistryID: parentID, ParentID: ptr(deptID)})
+	assert.Equal(t, deptID, *getDepartment(t, repo, unitID).ParentID)
+
+	_, err = repo.CreateDepartment(models.Department{Name: "Stray Unit", MinistryID: stateID, ParentID: ptr(deptID)})
+	assert.ErrorIs(t, err, repository.ErrInvalidParent)
+
+	dept := getDepartment(t, repo, deptID)
+	dept.ParentID = ptr(unitID)
+	assert.ErrorIs(t, repo.UpdateDepartment(dept), repository.ErrInvalidParent)
+}
+
+func testUpdateDepartmentMovesDivisions(t *testing.T, repo repository.OrganizationRepo) {
+	fromID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Transport"})
+	toID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Highways"})
+	deptID := createDepartment(t, repo, models.Department{Name: "Road Development", MinistryID: fromID})
+	unitID := createDepartment(t, repo, models.Department{Name: "Bridges Division", MinistryID: fromID, ParentID: ptr(deptID)})
+	subUnitID := createDepartment(t, repo, models.Department{Name: "Bridge Design Unit", MinistryID: fromID, ParentID: ptr(unitID)})
+
+	dept := getDepartment(t, repo, deptID)
+	dept.MinistryID = toID
+	require.NoError(t, repo.UpdateDepartment(dept))
+
+	for _, id := range []int{deptID, unitID, subUnitID} {
+		assert.Equal(t, toID, getDepartment(t, repo, id).MinistryID, "department %d", id)
+	}
+}
+
+func testDeleteMinistry(t *testing.T, repo repository.OrganizationRepo) {
+	ministryID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Fisheries"})
+	targetID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Agriculture"})
+	deptID := createDepartment(t, repo, models.Department{Name: "Fisheries Department", MinistryID: ministryID})
+
+	assert.ErrorIs(t, repo.DeleteMinistry(ministryID, models.DeleteReject, 0), repository.ErrMinistryHasDepartments)
+	assert.ErrorIs(t, repo.DeleteMinistry(ministryID, models.DeleteReassign, ministryID), repository.ErrInvalidReassignTarget)
+	assert.ErrorIs(t, repo.DeleteMinistry(ministryID, models.DeleteReassign, 999), repository.ErrInvalidReassignTarget)
+
+	require.NoError(t, repo.DeleteMinistry(ministryID, models.DeleteReassign, targetID))
+	_, err := repo.GetMinistryByID(ministryID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Equal(t, targetID, getDepartment(t, repo, deptID).MinistryID)
+
+	require.NoError(t, repo.DeleteMinistry(targetID, models.DeleteCascade, 0))
+	dept, err := repo.GetDepartmentByID(deptID)
+	assert.NoError(t, err)
+	assert.Nil(t, dept)
+
+	emptyID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Sports"})
+	assert.NoError(t, repo.DeleteMinistry(emptyID, models.DeleteReject, 0))
+}
+
+func testDeleteDepartment(t *testing.T, repo repository.OrganizationRepo) {
+	ministryID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Justice"})
+	deptID := createDepartment(t, repo, models.Department{Name: "Legal Draftsman", MinistryID: ministryID})
+	unitID := createDepartment(t, repo, models.Department{Name: "Translations Division", MinistryID: ministryID, ParentID: ptr(deptID)})
+
+	require.NoError(t, repo.DeleteDepartment(deptID))
+	dept, err := repo.GetDepartmentByID(deptID)
+	assert.NoError(t, err)
+	assert.Nil(t, dept)
+
+	unit := getDepartment(t, repo, unitID)
+	assert.Nil(t, unit.ParentID)
+	assert.Equal(t, ministryID, unit.MinistryID)
+}
+
+func testListsAndPages(t *testing.T, repo repository.OrganizationRepo) {
+	var ministryIDs, deptIDs []int
+	for _, name := range []string{"Ministry A", "Ministry B", "Ministry C"} {
+		ministryIDs = append(ministryIDs, createMinistry(t, repo, models.Ministry{Name: name}))
+	}
+	for i := 0; i < 5; i++ {
+		deptIDs = append(deptIDs, createDepartment(t, repo, models.Department{
+			Name:       "Department " + string(rune('A'+i)),
+			MinistryID: ministryIDs[i%len(ministryIDs)],
+		}))
+	}
+
+	depts, err := repo.GetAllDepartments()
+	require.NoError(t, err)
+	assert.Equal(t, deptIDs, departmentIDs(depts))
+
+	ministries, err := repo.GetMinistriesWithDepartments()
+	require.NoError(t, err)
+	require.Len(t, ministries, 3)
+	for i, m := range ministries {
+		assert.Equal(t, ministryIDs[i], m.ID)
+	}
+	assert.Equal(t, []int{deptIDs[0], deptIDs[3]}, departmentIDs(ministries[0].Departments))
+
+	page, err := repo.GetDepartmentsPage(models.PageRequest{Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 5, page.Total)
+	assert.Equal(t, 2, page.Offset)
+	assert.Equal(t, deptIDs[2:4], departmentIDs(page.Items))
+
+	page, err = repo.GetDepartmentsPage(models.PageRequest{Limit: 2, Cursor: &models.Cursor{ID: deptIDs[3]}})
+	require.NoError(t, err)
+	assert.Equal(t, 4, page.Offset)
+	assert.Equal(t, deptIDs[4:], departmentIDs(page.Items))
+
+	page, err = repo.GetDepartmentsPage(models.PageRequest{Limit: 2, Cursor: &models.Cursor{ID: deptIDs[3], Before: true}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Offset)
+	assert.Equal(t, deptIDs[1:3], departmentIDs(page.Items))
+
+	ministryPage, err := repo.GetMinistriesPage(models.PageRequest{Limit: 2, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, ministryPage.Total)
+	require.Len(t, ministryPage.Items, 2)
+	assert.Equal(t, ministryIDs[1], ministryPage.Items[0].ID)
+	assert.Equal(t, []int{deptIDs[1], deptIDs[4]}, departmentIDs(ministryPage.Items[0].Departments))
+
+	ministryPage, err = repo.GetMinistriesPage(models.PageRequest{Limit: 2, Offset: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 3, ministryPage.Total)
+	assert.Empty(t, ministryPage.Items)
+}
+
+func testBBox(t *testing.T, repo repository.OrganizationRepo) {
+	ministryID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Foreign Affairs", Location: at(6.93, 79.85)})
+	createMinistry(t, repo, models.Ministry{Name: "Ministry Without Location"})
+	colombo := createDepartment(t, repo, models.Department{Name: "Colombo", MinistryID: ministryID, Location: at(6.93, 79.85)})
+	kandy := createDepartment(t, repo, models.Department{Name: "Kandy", MinistryID: ministryID, Location: at(7.29, 80.63)})
+	fiji := createDepartment(t, repo, models.Department{Name: "Suva Mission", MinistryID: ministryID, Location: at(-18.14, 178.44)})
+	createDepartment(t, repo, models.Department{Name: "Nowhere", MinistryID: ministryID})
+
+	sriLanka := models.BBox{MinLon: 79.5, MinLat: 5.9, MaxLon: 81.9, MaxLat: 9.9}
+	depts, err := repo.GetDepartmentsInBBox(sriLanka, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []int{colombo, kandy}, departmentIDs(depts))
+
+	depts, err = repo.GetDepartmentsInBBox(sriLanka, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{colombo}, departmentIDs(depts))
+
+	pacific := models.BBox{MinLon: 170, MinLat: -30, MaxLon: -170, MaxLat: 0}
+	depts, err = repo.GetDepartmentsInBBox(pacific, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []int{fiji}, departmentIDs(depts))
+
+	ministries, err := repo.GetMinistriesInBBox(sriLanka, 100)
+	require.NoError(t, err)
+	require.Len(t, ministries, 1)
+	assert.Equal(t, ministryID, ministries[0].ID)
+}
+
+func testNearby(t *testing.T, repo repository.OrganizationRepo) {
+	ministryID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Public Administration"})
+	far := createDepartment(t, repo, models.Department{Name: "Galle", MinistryID: ministryID, Location: at(6.05, 80.22)})
+	near := createDepartment(t, repo, models.Department{Name: "Fort", MinistryID: ministryID, Location: at(6.935, 79.845)})
+	createDepartment(t, repo, models.Department{Name: "Jaffna", MinistryID: ministryID, Location: at(9.66, 80.02)})
+
+	nearby, err := repo.FindDepartmentsNearby(models.NearbyQuery{Latitude: 6.93, Longitude: 79.85, RadiusKm: 150, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, nearby, 2)
+	assert.Equal(t, near, nearby[0].ID)
+	assert.Equal(t, far, nearby[1].ID)
+	assert.InDelta(t, geo.HaversineKm(6.93, 79.85, 6.935, 79.845), nearby[0].DistanceKm, 0.001)
+	assert.InDelta(t, geo.HaversineKm(6.93, 79.85, 6.05, 80.22), nearby[1].DistanceKm, 0.001)
+
+	// Galle is 106.05 km away, so a fractional radius either side of it
+	// shows the distance is computed, not rounded.
+	nearby, err = repo.FindDepartmentsNearby(models.NearbyQuery{Latitude: 6.93, Longitude: 79.85, RadiusKm: 106.5, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int{near, far}, nearbyDepartmentIDs(nearby))
+	nearby, err = repo.FindDepartmentsNearby(models.NearbyQuery{Latitude: 6.93, Longitude: 79.85, RadiusKm: 105.5, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int{near}, nearbyDepartmentIDs(nearby))
+
+	nearby, err = repo.FindDepartmentsNearby(models.NearbyQuery{Latitude: 6.93, Longitude: 79.85, RadiusKm: 150, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, nearby, 1)
+	assert.Equal(t, near, nearby[0].ID)
+}
+
+func testOffices(t *testing.T, repo repository.OrganizationRepo) {
+	ministryID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Defence"})
+	deptID := createDepartment(t, repo, models.Department{Name: "Immigration and Emigration", MinistryID: ministryID})
+	otherID := createDepartment(t, repo, models.Department{Name: "Registration of Persons", MinistryID: ministryID})
+
+	loc := at(6.9, 79.87)
+	loc.Address = "Battaramulla"
+	officeID, err := repo.CreateOffice(models.Office{
+		DepartmentID: deptID,
+		Name:         "Head Office",
+		PhoneNumbers: []string{"+94 11 532 9000"},
+		Email:        "info@immigration.gov.lk",
+		Location:     loc,
+	})
+	require.NoError(t, err)
+	branchID, err := repo.CreateOffice(models.Office{DepartmentID: deptID, Name: "Matara Branch", Location: at(5.95, 80.54)})
+	require.NoError(t, err)
+
+	office, err := repo.GetOfficeByID(officeID)
+	require.NoError(t, err)
+	assert.Equal(t, "Head Office", office.Name)
+	assert.Equal(t, deptID, office.DepartmentID)
+	assert.Equal(t, []string{"+94 11 532 9000"}, office.PhoneNumbers)
+	assert.Equal(t, "info@immigration.gov.lk", office.Email)
+	assert.Equal(t, "Battaramulla", office.Address)
+
+	offices, err := repo.GetDepartmentOffices(deptID)
+	require.NoError(t, err)
+	require.Len(t, offices, 2)
+	assert.Equal(t, officeID, offices[0].ID)
+	assert.Equal(t, branchID, offices[1].ID)
+
+	nearby, err := repo.FindOfficesNearby(models.NearbyQuery{Latitude: 6.9, Longitude: 79.87, RadiusKm: 50, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, nearby, 1)
+	assert.Equal(t, officeID, nearby[0].ID)
+
+	// Matara is 128.99 km from Battaramulla.
+	nearby, err = repo.FindOfficesNearby(models.NearbyQuery{Latitude: 6.9, Longitude: 79.87, RadiusKm: 129.5, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, nearby, 2)
+	assert.Equal(t, branchID, nearby[1].ID)
+	assert.InDelta(t, geo.HaversineKm(6.9, 79.87, 5.95, 80.54), nearby[1].DistanceKm, 0.001)
+	nearby, err = repo.FindOfficesNearby(models.NearbyQuery{Latitude: 6.9, Longitude: 79.87, RadiusKm: 128.5, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, nearby, 1)
+
+	office.DepartmentID = otherID
+	office.Name = "Main Office"
+	require.NoError(t, repo.UpdateOffice(*office))
+	office, err = repo.GetOfficeByID(officeID)
+	require.NoError(t, err)
+	assert.Equal(t, otherID, office.DepartmentID)
+	assert.Equal(t, "Main Office", office.Name)
+
+	office.DepartmentID = 999
+	assert.ErrorIs(t, repo.UpdateOffice(*office), repository.ErrInvalidReference)
+	assert.ErrorIs(t, repo.UpdateOffice(models.Office{ID: 999, DepartmentID: deptID, Name: "Missing"}), repository.ErrNotFound)
+
+	require.NoError(t, repo.DeleteOffice(branchID))
+	_, err = repo.GetOfficeByID(branchID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	offices, err = repo.GetDepartmentOffices(deptID)
+	require.NoError(t, err)
+	assert.Empty(t, offices)
+}
+
+func testAsOf(t *testing.T, repo repository.OrganizationRepo) {
+	ministryID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Tourism"})
+	createDepartment(t, repo, models.Department{Name: "Tourist Board", MinistryID: ministryID})
+
+	yesterday := repo.AsOf(models.Today().AddDays(-1))
+	_, err := yesterday.GetMinistryByID(ministryID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	depts, err := yesterday.GetAllDepartments()
+	require.NoError(t, err)
+	assert.Empty(t, depts)
+
+	today := repo.AsOf(models.Today())
+	ministry, err := today.GetMinistryByID(ministryID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ministry of Tourism", ministry.Name)
+	depts, err = today.GetAllDepartments()
+	require.NoError(t, err)
+	assert.Len(t, depts, 1)
+}
+
+func testChangeSet(t *testing.T, repo repository.OrganizationRepo) {
+	fromID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Mass Media"})
+	toID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Technology"})
+	deptID := createDepartment(t, repo, models.Department{Name: "Government Information", MinistryID: fromID})
+
+	result, err := repo.ApplyChangeSet(models.ChangeSet{
+		Gazette:       "2345/12",
+		EffectiveDate: models.Today(),
+		Operations: []models.ChangeOp{
+			{Type: models.OpRename, Entity: models.EntityDepartment, ID: deptID, Name: "Department of Government Information"},
+			{Type: models.OpTransfer, ID: deptID, ToMinistryID: toID},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Applied)
+
+	dept := getDepartment(t, repo, deptID)
+	assert.Equal(t, "Department of Government Information", dept.Name)
+	assert.Equal(t, toID, dept.MinistryID)
+
+	_, err = repo.ApplyChangeSet(models.ChangeSet{
+		Gazette:       "2345/13",
+		EffectiveDate: models.Today(),
+		Operations:    []models.ChangeOp{{Type: models.OpTransfer, ID: 999, ToMinistryID: toID}},
+	})
+	var opErr *models.ChangeOpError
+	require.ErrorAs(t, err, &opErr)
+	assert.Equal(t, 0, opErr.Index)
+}
+
+func testImport(t *testing.T, repo repository.OrganizationRepo) {
+	existingID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Health"})
+
+	result, err := repo.Import(models.ImportPlan{
+		Ministries: []models.ImportMinistry{{Ministry: models.Ministry{Name: "Ministry of Ports"}, Row: 2}},
+		Departments: []models.ImportDepartment{
+			{Department: models.Department{Name: "Ports Authority"}, Row: 3, MinistryIndex: ptr(0)},
+			{Department: models.Department{Name: "Medical Supplies", MinistryID: existingID}, Row: 4},
+		},
+		Offices: []models.ImportOffice{
+			{Office: models.Office{Name: "Galle Office", Location: at(6.03, 80.22)}, Row: 5, DepartmentIndex: ptr(0)},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.MinistryIDs, 1)
+	require.Len(t, result.DepartmentIDs, 2)
+	require.Len(t, result.OfficeIDs, 1)
+	assert.Equal(t, result.MinistryIDs[0], getDepartment(t, repo, result.DepartmentIDs[0]).MinistryID)
+	assert.Equal(t, existingID, getDepartment(t, repo, result.DepartmentIDs[1]).MinistryID)
+	office, err := repo.GetOfficeByID(result.OfficeIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, result.DepartmentIDs[0], office.DepartmentID)
+
+	// A row that fails takes the rows before it with it.
+	_, err = repo.Import(models.ImportPlan{
+		Ministries:  []models.ImportMinistry{{Ministry: models.Ministry{Name: "Ministry of Fisheries"}, Row: 2}},
+		Departments: []models.ImportDepartment{{Department: models.Department{Name: "Lost", MinistryID: 999}, Row: 3}},
+	})
+	var rowErr *models.ImportRowError
+	require.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, 3, rowErr.Row)
+	assert.ErrorIs(t, err, repository.ErrInvalidReference)
+	ministries, err := repo.GetMinistriesWithDepartments()
+	require.NoError(t, err)
+	assert.Len(t, ministries, 2)
+}
+
+func testSearch(t *testing.T, repo repository.OrganizationRepo) {
+	ministryID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Irrigation"})
+	deptID := createDepartment(t, repo, models.Department{Name: "Department of Meteorology", MinistryID: ministryID})
+
+	hits, err := repo.Search(models.SearchQuery{Text: "meteorology", Entities: models.SearchEntities, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, models.SearchDepartment, hits[0].Entity)
+	assert.Equal(t, deptID, hits[0].ID)
+	require.NotNil(t, hits[0].ParentID)
+	assert.Equal(t, ministryID, *hits[0].ParentID)
+	assert.Contains(t, hits[0].Snippet, "<mark>")
+
+	hits, err = repo.Search(models.SearchQuery{Text: "meteorology", Entities: []models.SearchEntity{models.SearchMinistry}, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
+
+func testTree(t *testing.T, repo repository.OrganizationRepo) {
+	ministryID := createMinistry(t, repo, models.Ministry{Name: "Ministry of Energy"})
+	stateID := createMinistry(t, repo, models.Ministry{Name: "State Ministry of Power", ParentID: ptr(ministryID)})
+	deptID := createDepartment(t, repo, models.Department{Name: "Atomic Energy Board", MinistryID: ministryID})
+	unitID := createDepartment(t, repo, models.Department{Name: "Radiation Protection", MinistryID: ministryID, ParentID: ptr(deptID)})
+
+	tree, err := repo.GetOrganizationTree(models.OrgRef{Entity: models.EntityMinistry, ID: ministryID}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Ministry of Energy", tree.Name)
+	require.Len(t, tree.Children, 2)
+	assert.Equal(t, models.EntityMinistry, tree.Children[0].Entity)
+	assert.Equal(t, stateID, tree.Children[0].ID)
+	assert.Equal(t, models.EntityDepartment, tree.Children[1].Entity)
+	assert.Equal(t, deptID, tree.Children[1].ID)
+	require.Len(t, tree.Children[1].Children, 1)
+	assert.Equal(t, unitID, tree.Children[1].Children[0].ID)
+	assert.Equal(t, 2, tree.Children[1].Children[0].Depth)
+
+	tree, err = repo.GetOrganizationTree(models.OrgRef{Entity: models.EntityMinistry, ID: ministryID}, 1)
+	require.NoError(t, err)
+	require.Len(t, tree.Children, 2)
+	assert.Empty(t, tree.Children[1].Children)
+}
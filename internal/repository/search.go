@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"strings"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/translit"
+)
+
+// searchKey returns the phonetic key stored with a ministry or department
+// so that romanized queries match its Sinhala and Tamil names. Both
+// backends write it whenever they write the names.
+func searchKey(name string, names models.LocalizedNames) string {
+	return translit.Key(strings.Join([]string{name, names[models.LocaleSinhala], names[models.LocaleTamil]}, " "))
+}
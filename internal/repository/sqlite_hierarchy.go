@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+)
+
+// sqliteOrgTreeQuery is orgTreeQuery for SQLite, which has no arrays: the
+// path is a comma-separated list of entity:id, delimited on both ends so
+// a node can be looked up with instr.
+const sqliteOrgTreeQuery = `
+	WITH RECURSIVE edges AS (
+		SELECT 'ministry' AS parent_entity, parent_id, 'ministry' AS entity, id, name, names, kind
+		FROM %[1]s ministry WHERE parent_id IS NOT NULL
+		UNION ALL
+		SELECT 'ministry', ministry_id, 'department', id, name, names, kind
+		FROM %[2]s department WHERE parent_id IS NULL AND ministry_id IS NOT NULL
+		UNION ALL
+		SELECT 'department', parent_id, 'department', id, name, names, kind
+		FROM %[2]s department WHERE parent_id IS NOT NULL
+	),
+	tree AS (
+		SELECT e.entity, e.id, e.parent_entity, e.parent_id, e.name, e.names, e.kind, 1 AS depth,
+			',' || $1 || ':' || $2 || ',' || e.entity || ':' || e.id || ',' AS path
+		FROM edges e
+		WHERE e.parent_entity = $1 AND e.parent_id = $2 AND $3 >= 1
+		UNION ALL
+		SELECT e.entity, e.id, e.parent_entity, e.parent_id, e.name, e.names, e.kind, t.depth + 1,
+			t.path || e.entity || ':' || e.id || ','
+		FROM tree t
+		JOIN edges e ON e.parent_entity = t.entity AND e.parent_id = t.id
+		WHERE t.depth < $3 AND instr(t.path, ',' || e.entity || ':' || e.id || ',') = 0
+	)
+	SELECT entity, id, parent_entity, parent_id, name, names, kind, depth
+	FROM tree
+	ORDER BY depth, entity DESC, id
+`
+
+// GetOrganizationTree returns root and its descendants down to depth
+// levels below it.
+func (r *SQLiteRepository) GetOrganizationTree(root models.OrgRef, depth int) (models.OrgNode, error) {
+	if !root.Entity.Valid() {
+		return models.OrgNode{}, fmt.Errorf("unknown organisation entity %q", root.Entity)
+	}
+
+	source := r.ministrySource()
+	if root.Entity == models.EntityDepartment {
+		source = r.departmentSource()
+	}
+
+	node := models.OrgNode{Entity: root.Entity, ID: root.ID}
+	var names []byte
+	err := r.DB.QueryRow(`SELECT name, names, kind FROM `+source+` root WHERE id = $1`, root.ID).
+		Scan(&node.Name, &names, &node.Kind)
+	if err == sql.ErrNoRows {
+		return models.OrgNode{}, ErrNotFound
+	} else if err != nil {
+		return models.OrgNode{}, err
+	}
+	node.Names = decodeNames(names)
+
+	query := fmt.Sprintf(sqliteOrgTreeQuery, r.ministrySource(), r.departmentSource())
+	rows, err := r.DB.Query(query, root.Entity, root.ID, depth)
+	if err != nil {
+		return models.OrgNode{}, err
+	}
+	defer rows.Close()
+
+	var tree []orgTreeRow
+	for rows.Next() {
+		var row orgTreeRow
+		if err := rows.Scan(&row.node.Entity, &row.node.ID, &row.parent.Entity, &row.parent.ID,
+			&row.node.Name, &names, &row.node.Kind, &row.node.Depth); err != nil {
+			return models.OrgNode{}, err
+		}
+		row.node.Names = decodeNames(names)
+		tree = append(tree, row)
+	}
+	if err := rows.Err(); err != nil {
+		return models.OrgNode{}, err
+	}
+
+	return assembleOrgTree(node, tree), nil
+}
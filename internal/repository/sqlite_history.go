@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"fmt"
+
+	"go-mysql-backend/internal/models"
+)
+
+// AsOf returns a read-only view of the repository that answers read
+// queries from ministry_version and department_version as the structure
+// stood on date, as OrganizationRepository.AsOf does.
+func (r *SQLiteRepository) AsOf(date models.Date) OrganizationRepo {
+	return &SQLiteRepository{DB: r.DB, asOf: &date}
+}
+
+func (r *SQLiteRepository) ministrySource() string {
+	return sqliteMinistrySource(r.asOf)
+}
+
+func (r *SQLiteRepository) departmentSource() string {
+	return sqliteDepartmentSource(r.asOf)
+}
+
+// sqliteMinistrySource and sqliteDepartmentSource are ministrySource and
+// departmentSource for SQLite, where dates are YYYY-MM-DD text and compare
+// as strings.
+func sqliteMinistrySource(asOf *models.Date) string {
+	if asOf == nil {
+		return "ministry"
+	}
+	return fmt.Sprintf(`(
+		SELECT v.ministry_id AS id, v.name, v.names, v.kind, v.parent_id,
+			l.google_map_script, l.latitude, l.longitude, l.address, l.geometry
+		FROM ministry_version v
+		LEFT JOIN ministry l ON l.id = v.ministry_id
+		WHERE v.valid_from <= '%[1]s' AND (v.valid_to IS NULL OR v.valid_to > '%[1]s')
+	)`, asOf)
+}
+
+func sqliteDepartmentSource(asOf *models.Date) string {
+	if asOf == nil {
+		return "department"
+	}
+	return fmt.Sprintf(`(
+		SELECT v.department_id AS id, v.name, v.names, v.kind, v.parent_id, v.ministry_id,
+			l.google_map_script, l.admin_area_id, l.latitude, l.longitude, l.address, l.geometry
+		FROM department_version v
+		LEFT JOIN department l ON l.id = v.department_id
+		WHERE v.valid_from <= '%[1]s' AND (v.valid_to IS NULL OR v.valid_to > '%[1]s')
+	)`, asOf)
+}
+
+// ApplyChangeSet applies every operation of cs in one transaction, in
+// order, each on its own effective date. If an operation fails nothing is
+// applied and the error is a *models.ChangeOpError naming it.
+func (r *SQLiteRepository) ApplyChangeSet(cs models.ChangeSet) (models.ChangeSetResult, error) {
+	var result models.ChangeSetResult
+	err := withSQLiteTx(r.DB, func(q dbtx) (err error) {
+		result, err = applyChangeSet(q, cs)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "go-mysql-backend/internal/models"
+
+// Import creates the records of plan in one transaction, effective
+// today. If one fails nothing is created and the error is a
+// *models.ImportRowError naming it.
+func (r *SQLiteRepository) Import(plan models.ImportPlan) (models.ImportResult, error) {
+	var result models.ImportResult
+	err := withSQLiteTx(r.DB, func(q dbtx) (err error) {
+		result, err = importRows(q, plan, createSQLiteOffice)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+
+	"go-mysql-backend/internal/geo"
+	"go-mysql-backend/internal/models"
+)
+
+// sqliteOfficeRow scans officeColumns from SQLite, where the phone numbers
+// are a JSON list rather than a text array.
+type sqliteOfficeRow struct {
+	officeRow
+	Phones []byte
+}
+
+func (o *sqliteOfficeRow) dest() []interface{} {
+	return append([]interface{}{&o.ID, &o.DepartmentID, &o.Name, &o.Phones, &o.Email, &o.Hours, &o.Closures}, o.Location.dest()...)
+}
+
+func (o sqliteOfficeRow) toModel() (models.Office, error) {
+	if len(o.Phones) > 0 {
+		if err := json.Unmarshal(o.Phones, &o.PhoneNumbers); err != nil {
+			return models.Office{}, err
+		}
+	}
+	return o.officeRow.toModel()
+}
+
+// encodePhoneNumbers returns the phone numbers of office as a JSON list.
+func encodePhoneNumbers(office models.Office) (string, error) {
+	phones, err := json.Marshal(nonNil(office.PhoneNumbers))
+	return string(phones), err
+}
+
+// GetDepartmentOffices lists the offices of a department, head office
+// first by id.
+func (r *SQLiteRepository) GetDepartmentOffices(departmentID int) ([]models.Office, error) {
+	rows, err := r.DB.Query(`SELECT `+officeColumns+` FROM office o WHERE o.department_id = $1 ORDER BY o.id`, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	offices := []models.Office{}
+	for rows.Next() {
+		var o sqliteOfficeRow
+		if err := rows.Scan(o.dest()...); err != nil {
+			return nil, err
+		}
+		office, err := o.toModel()
+		if err != nil {
+			return nil, err
+		}
+		offices = append(offices, office)
+	}
+	return offices, rows.Err()
+}
+
+func (r *SQLiteRepository) GetOfficeByID(id int) (*models.Office, error) {
+	var o sqliteOfficeRow
+	err := r.DB.QueryRow(`SELECT `+officeColumns+` FROM office o WHERE o.id = $1`, id).Scan(o.dest()...)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	office, err := o.toModel()
+	if err != nil {
+		return nil, err
+	}
+	return &office, nil
+}
+
+// FindOfficesNearby returns the offices within q.RadiusKm of the query
+// point, nearest first.
+func (r *SQLiteRepository) FindOfficesNearby(q models.NearbyQuery) ([]models.NearbyOffice, error) {
+	// Degrees of latitude spanned by the radius, used to prefilter rows.
+	latSpan := q.RadiusKm / (geo.EarthRadiusKm * math.Pi / 180)
+
+	rows, err := r.DB.Query(`
+		SELECT `+officeColumns+`, o.distance_km
+		FROM (
+			SELECT office.*, `+sqliteDistance+` AS distance_km
+			FROM office
+			WHERE latitude IS NOT NULL AND longitude IS NOT NULL
+			  AND latitude BETWEEN $1 - $6 AND $1 + $6
+		) o
+		WHERE o.distance_km <= $3
+		ORDER BY o.distance_km, o.id
+		LIMIT $4
+	`, q.Latitude, q.Longitude, q.RadiusKm, q.Limit, geo.EarthRadiusKm, latSpan)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	offices := []models.NearbyOffice{}
+	for rows.Next() {
+		var o sqliteOfficeRow
+		var distance float64
+		if err := rows.Scan(append(o.dest(), &distance)...); err != nil {
+			return nil, err
+		}
+		office, err := o.toModel()
+		if err != nil {
+			return nil, err
+		}
+		offices = append(offices, models.NearbyOffice{Office: office, DistanceKm: distance})
+	}
+	return offices, rows.Err()
+}
+
+// CreateOffice adds an office to an existing department.
+func (r *SQLiteRepository) CreateOffice(office models.Office) (int, error) {
+	return createSQLiteOffice(r.DB, office)
+}
+
+func createSQLiteOffice(q dbtx, office models.Office) (int, error) {
+	hours, closures, err := encodeSchedule(office)
+	if err != nil {
+		return 0, err
+	}
+	phones, err := encodePhoneNumbers(office)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = q.QueryRow(`INSERT INTO office (department_id, name, phone_numbers, email, hours, closures, latitude, longitude, address, geometry)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
+		office.DepartmentID, office.Name, phones, nullString(office.Email), hours, closures,
+		office.Latitude, office.Longitude, nullString(office.Address), nullGeometry(office.Geometry)).Scan(&id)
+	if err != nil {
+		return 0, translateConstraintError(err)
+	}
+	return id, nil
+}
+
+// UpdateOffice replaces the details of an office. DepartmentID moves the
+// office to another department.
+func (r *SQLiteRepository) UpdateOffice(office models.Office) error {
+	hours, closures, err := encodeSchedule(office)
+	if err != nil {
+		return err
+	}
+	phones, err := encodePhoneNumbers(office)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.DB.Exec(`UPDATE office SET department_id = $1, name = $2, phone_numbers = $3, email = $4,
+		hours = $5, closures = $6, latitude = $7, longitude = $8, address = $9, geometry = $10 WHERE id = $11`,
+		office.DepartmentID, office.Name, phones, nullString(office.Email), hours, closures,
+		office.Latitude, office.Longitude, nullString(office.Address), nullGeometry(office.Geometry), office.ID)
+	if err != nil {
+		return translateConstraintError(err)
+	}
+	return expectRowsAffected(res)
+}
+
+func (r *SQLiteRepository) DeleteOffice(id int) error {
+	res, err := r.DB.Exec(`DELETE FROM office WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"encoding/json"
+
+	"go-mysql-backend/internal/models"
+)
+
+// GetMinistriesPage returns a page of ministries, each with all of its
+// departments. Pages are cut over ministries, never within one.
+func (r *SQLiteRepository) GetMinistriesPage(p models.PageRequest) (models.MinistryPage, error) {
+	page := models.MinistryPage{Limit: p.Limit, Items: []models.MinistryWithDepartments{}}
+	ids, err := pageIDs(r.DB, r.ministrySource(), p, &page.Offset, &page.Total)
+	if err != nil || len(ids) == 0 {
+		return page, err
+	}
+
+	rows, err := r.DB.Query(`
+		SELECT `+ministryColumns+`, `+departmentColumns+`
+		FROM `+r.ministrySource()+` m
+		LEFT JOIN `+r.departmentSource()+` d ON m.id = d.ministry_id
+		WHERE m.id IN (SELECT value FROM json_each($1))
+		ORDER BY m.id, d.id
+	`, jsonIDs(ids))
+	if err != nil {
+		return page, err
+	}
+	page.Items, err = scanMinistriesWithDepartments(rows)
+	return page, err
+}
+
+// GetDepartmentsPage returns a page of departments.
+func (r *SQLiteRepository) GetDepartmentsPage(p models.PageRequest) (models.DepartmentPage, error) {
+	page := models.DepartmentPage{Limit: p.Limit, Items: []models.Department{}}
+	ids, err := pageIDs(r.DB, r.departmentSource(), p, &page.Offset, &page.Total)
+	if err != nil || len(ids) == 0 {
+		return page, err
+	}
+
+	rows, err := r.DB.Query(`
+		SELECT `+departmentColumns+` FROM `+r.departmentSource()+` d
+		WHERE d.id IN (SELECT value FROM json_each($1))
+		ORDER BY d.id
+	`, jsonIDs(ids))
+	if err != nil {
+		return page, err
+	}
+	page.Items, err = scanDepartments(rows)
+	return page, err
+}
+
+// jsonIDs passes a list of ids to SQLite, which has no array parameters,
+// as a JSON array for json_each.
+func jsonIDs(ids []int) string {
+	encoded, _ := json.Marshal(ids)
+	return string(encoded)
+}
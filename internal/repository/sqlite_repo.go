@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"database/sql"
+	"math"
+	"strings"
+
+	"go-mysql-backend/internal/geo"
+	"go-mysql-backend/internal/models"
+)
+
+// SQLiteRepository keeps the organisation structure in an embedded SQLite
+// database with the tables of the Postgres schema, for single machines
+// without a database server. Writes run the statements of
+// OrganizationRepository through sqliteQueries; reads have their own SQL
+// where the dialects differ.
+type SQLiteRepository struct {
+	DB *sql.DB
+
+	// asOf is set on the dated views returned by AsOf.
+	asOf *models.Date
+}
+
+// NewSQLiteRepository returns a repository over db, which must have been
+// opened with OpenSQLite.
+func NewSQLiteRepository(db *sql.DB) *SQLiteRepository {
+	return &SQLiteRepository{DB: db}
+}
+
+// sqliteQueries runs the statements shared with Postgres on SQLite. They
+// only differ in the row locks Postgres takes, which SQLite does without:
+// its transactions hold the write lock from the start.
+type sqliteQueries struct {
+	q dbtx
+}
+
+var sqliteLockClauses = strings.NewReplacer(" FOR UPDATE", "", " FOR SHARE", "")
+
+func (s sqliteQueries) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return s.q.Exec(sqliteLockClauses.Replace(query), args...)
+}
+
+func (s sqliteQueries) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return s.q.Query(sqliteLockClauses.Replace(query), args...)
+}
+
+func (s sqliteQueries) QueryRow(query string, args ...interface{}) *sql.Row {
+	return s.q.QueryRow(sqliteLockClauses.Replace(query), args...)
+}
+
+// withSQLiteTx runs fn in a transaction, committing when it returns nil.
+func withSQLiteTx(db *sql.DB, fn func(q dbtx) error) error {
+	return withTx(db, func(tx *sql.Tx) error {
+		return fn(sqliteQueries{tx})
+	})
+}
+
+func (r *SQLiteRepository) GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error) {
+	rows, err := r.DB.Query(`
+		SELECT ` + ministryColumns + `, ` + departmentColumns + `
+		FROM ` + r.ministrySource() + ` m
+		LEFT JOIN ` + r.departmentSource() + ` d ON m.id = d.ministry_id
+		ORDER BY m.id, d.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return scanMinistriesWithDepartments(rows)
+}
+
+func (r *SQLiteRepository) GetAllDepartments() ([]models.Department, error) {
+	rows, err := r.DB.Query(`SELECT ` + departmentColumns + ` FROM ` + r.departmentSource() + ` d ORDER BY d.id`)
+	if err != nil {
+		return nil, err
+	}
+	return scanDepartments(rows)
+}
+
+func (r *SQLiteRepository) CreateMinistry(ministry models.Ministry) (int, error) {
+	var id int
+	err := withSQLiteTx(r.DB, func(q dbtx) (err error) {
+		id, err = createMinistry(q, ministry, models.Today())
+		return err
+	})
+	return id, err
+}
+
+func (r *SQLiteRepository) CreateDepartment(dept models.Department) (int, error) {
+	var id int
+	err := withSQLiteTx(r.DB, func(q dbtx) (err error) {
+		id, err = createDepartment(q, dept, models.Today())
+		return err
+	})
+	return id, err
+}
+
+func (r *SQLiteRepository) GetMinistryByID(id int) (models.Ministry, error) {
+	var m ministryRow
+	err := r.DB.QueryRow(`SELECT `+ministryColumns+` FROM `+r.ministrySource()+` m WHERE m.id = $1`, id).Scan(m.dest()...)
+	if err == sql.ErrNoRows {
+		return models.Ministry{}, ErrNotFound
+	} else if err != nil {
+		return models.Ministry{}, err
+	}
+	return m.toModel(), nil
+}
+
+func (r *SQLiteRepository) GetMinistryByIDWithDepartments(id int) (models.MinistryWithDepartments, error) {
+	rows, err := r.DB.Query(`
+		SELECT `+ministryColumns+`, `+departmentColumns+`
+		FROM `+r.ministrySource()+` m
+		LEFT JOIN `+r.departmentSource()+` d ON m.id = d.ministry_id
+		WHERE m.id = $1
+		ORDER BY d.id
+	`, id)
+	if err != nil {
+		return models.MinistryWithDepartments{}, err
+	}
+	ministries, err := scanMinistriesWithDepartments(rows)
+	if err != nil {
+		return models.MinistryWithDepartments{}, err
+	}
+	if len(ministries) == 0 {
+		return models.MinistryWithDepartments{}, ErrNotFound
+	}
+	return ministries[0], nil
+}
+
+func (r *SQLiteRepository) GetDepartmentByID(id int) (*models.Department, error) {
+	var d departmentRow
+	err := r.DB.QueryRow(`SELECT `+departmentColumns+` FROM `+r.departmentSource()+` d WHERE d.id = $1`, id).Scan(d.dest()...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	dept := d.toModel()
+	// Offices are not versioned, so dated reads leave them out.
+	if r.asOf == nil {
+		if dept.Offices, err = r.GetDepartmentOffices(id); err != nil {
+			return nil, err
+		}
+	}
+	return &dept, nil
+}
+
+func (r *SQLiteRepository) UpdateMinistry(ministry models.Ministry) error {
+	return withSQLiteTx(r.DB, func(q dbtx) error {
+		return updateMinistry(q, ministry, models.Today())
+	})
+}
+
+// UpdateDepartment saves dept. When its ministry changes, the divisions and
+// units below it move to the new ministry as well.
+func (r *SQLiteRepository) UpdateDepartment(dept models.Department) error {
+	return withSQLiteTx(r.DB, func(q dbtx) error {
+		return updateDepartment(q, dept, models.Today())
+	})
+}
+
+func (r *SQLiteRepository) UpdateMinistryLocation(id int, loc models.Location) error {
+	res, err := r.DB.Exec(`UPDATE ministry SET latitude = $1, longitude = $2, address = $3, geometry = $4 WHERE id = $5`,
+		loc.Latitude, loc.Longitude, nullString(loc.Address), nullGeometry(loc.Geometry), id)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+func (r *SQLiteRepository) UpdateDepartmentLocation(id int, loc models.Location) error {
+	res, err := r.DB.Exec(`UPDATE department SET latitude = $1, longitude = $2, address = $3, geometry = $4 WHERE id = $5`,
+		loc.Latitude, loc.Longitude, nullString(loc.Address), nullGeometry(loc.Geometry), id)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(res)
+}
+
+// sqliteDistance is the haversine great-circle distance in km from the
+// point ($1, $2) to the row's coordinates, with the earth radius as $5.
+// SQLite spells LEAST as the scalar MIN.
+const sqliteDistance = `2 * $5 * ASIN(MIN(1, SQRT(
+	POWER(SIN(RADIANS(latitude - $1) / 2), 2) +
+	COS(RADIANS($1)) * COS(RADIANS(latitude)) *
+	POWER(SIN(RADIANS(longitude - $2) / 2), 2)
+)))`
+
+// FindDepartmentsNearby returns the departments within q.RadiusKm of the
+// query point, nearest first.
+func (r *SQLiteRepository) FindDepartmentsNearby(q models.NearbyQuery) ([]models.NearbyDepartment, error) {
+	// Degrees of latitude spanned by the radius, used to prefilter rows.
+	latSpan := q.RadiusKm / (geo.EarthRadiusKm * math.Pi / 180)
+
+	rows, err := r.DB.Query(`
+		SELECT `+departmentColumns+`, d.distance_km
+		FROM (
+			SELECT src.*, `+sqliteDistance+` AS distance_km
+			FROM `+r.departmentSource()+` src
+			WHERE latitude IS NOT NULL AND longitude IS NOT NULL
+			  AND latitude BETWEEN $1 - $6 AND $1 + $6
+		) d
+		WHERE d.distance_km <= $3
+		ORDER BY d.distance_km, d.id
+		LIMIT $4
+	`, q.Latitude, q.Longitude, q.RadiusKm, q.Limit, geo.EarthRadiusKm, latSpan)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	departments := []models.NearbyDepartment{}
+	for rows.Next() {
+		var d departmentRow
+		var distance float64
+		if err := rows.Scan(append(d.dest(), &distance)...); err != nil {
+			return nil, err
+		}
+		departments = append(departments, models.NearbyDepartment{Department: d.toModel(), DistanceKm: distance})
+	}
+	return departments, rows.Err()
+}
+
+// sqliteBBoxCondition is bboxCondition without the casts, which SQLite
+// does not need to compare REAL columns with float parameters.
+const sqliteBBoxCondition = `
+	latitude BETWEEN $2 AND $4
+	AND (
+		($1 <= $3 AND longitude BETWEEN $1 AND $3)
+		OR ($1 > $3 AND (longitude >= $1 OR longitude <= $3))
+	)`
+
+// GetDepartmentsInBBox returns up to limit departments located inside bbox,
+// ordered by id.
+func (r *SQLiteRepository) GetDepartmentsInBBox(bbox models.BBox, limit int) ([]models.Department, error) {
+	rows, err := r.DB.Query(`
+		SELECT `+departmentColumns+`
+		FROM `+r.departmentSource()+` d
+		WHERE `+sqliteBBoxCondition+`
+		ORDER BY d.id
+		LIMIT $5
+	`, bbox.MinLon, bbox.MinLat, bbox.MaxLon, bbox.MaxLat, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanDepartments(rows)
+}
+
+// GetMinistriesInBBox returns up to limit ministries located inside bbox,
+// ordered by id.
+func (r *SQLiteRepository) GetMinistriesInBBox(bbox models.BBox, limit int) ([]models.Ministry, error) {
+	rows, err := r.DB.Query(`
+		SELECT `+ministryColumns+`
+		FROM `+r.ministrySource()+` m
+		WHERE `+sqliteBBoxCondition+`
+		ORDER BY m.id
+		LIMIT $5
+	`, bbox.MinLon, bbox.MinLat, bbox.MaxLon, bbox.MaxLat, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ministries := []models.Ministry{}
+	for rows.Next() {
+		var m ministryRow
+		if err := rows.Scan(m.dest()...); err != nil {
+			return nil, err
+		}
+		ministries = append(ministries, m.toModel())
+	}
+	return ministries, rows.Err()
+}
+
+// DeleteMinistry removes a ministry. What happens to its departments is
+// decided by mode; reassignTo is only used with models.DeleteReassign.
+func (r *SQLiteRepository) DeleteMinistry(id int, mode models.MinistryDeleteMode, reassignTo int) error {
+	return withSQLiteTx(r.DB, func(q dbtx) error {
+		return deleteMinistry(q, id, mode, reassignTo, models.Today())
+	})
+}
+
+func (r *SQLiteRepository) DeleteDepartment(id int) error {
+	return withSQLiteTx(r.DB, func(q dbtx) error {
+		return deleteDepartment(q, id, models.Today())
+	})
+}
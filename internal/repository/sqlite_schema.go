@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"database/sql"
+	"net/url"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema creates the tables of the Postgres schema in SQLite. JSONB
+// columns are JSON text, dates are YYYY-MM-DD text and phone numbers are a
+// JSON list. The generated tsvector columns become FTS5 tables kept in
+// step by triggers; their rowid is the id of the row they index.
+// Statements are idempotent so the schema can be ensured on every start.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS ministry (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	names TEXT NOT NULL DEFAULT '{}',
+	search_key TEXT NOT NULL DEFAULT '',
+	kind TEXT NOT NULL DEFAULT 'ministry'
+		CHECK (kind IN ('ministry', 'state_ministry', 'statutory_board', 'agency')),
+	parent_id INTEGER REFERENCES ministry(id) ON DELETE SET NULL,
+	google_map_script TEXT,
+	latitude REAL CHECK (latitude BETWEEN -90 AND 90),
+	longitude REAL CHECK (longitude BETWEEN -180 AND 180),
+	address TEXT,
+	geometry TEXT
+);
+
+CREATE TABLE IF NOT EXISTS admin_area (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	code TEXT NOT NULL,
+	name TEXT NOT NULL,
+	level TEXT NOT NULL CHECK (level IN ('province', 'district', 'ds_division', 'gn_division')),
+	parent_id INTEGER REFERENCES admin_area(id),
+	geometry TEXT,
+	UNIQUE (level, code)
+);
+
+CREATE TABLE IF NOT EXISTS department (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	names TEXT NOT NULL DEFAULT '{}',
+	search_key TEXT NOT NULL DEFAULT '',
+	kind TEXT NOT NULL DEFAULT 'department'
+		CHECK (kind IN ('department', 'agency', 'division', 'unit')),
+	parent_id INTEGER REFERENCES department(id) ON DELETE SET NULL,
+	ministry_id INTEGER REFERENCES ministry(id),
+	google_map_script TEXT,
+	latitude REAL CHECK (latitude BETWEEN -90 AND 90),
+	longitude REAL CHECK (longitude BETWEEN -180 AND 180),
+	address TEXT,
+	geometry TEXT,
+	admin_area_id INTEGER REFERENCES admin_area(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS ministry_parent_idx ON ministry (parent_id);
+CREATE INDEX IF NOT EXISTS department_parent_idx ON department (parent_id);
+CREATE INDEX IF NOT EXISTS department_ministry_idx ON department (ministry_id);
+CREATE INDEX IF NOT EXISTS admin_area_parent_idx ON admin_area (parent_id);
+CREATE INDEX IF NOT EXISTS department_admin_area_idx ON department (admin_area_id);
+CREATE INDEX IF NOT EXISTS ministry_lat_lon_idx ON ministry (latitude, longitude);
+CREATE INDEX IF NOT EXISTS department_lat_lon_idx ON department (latitude, longitude);
+
+CREATE TABLE IF NOT EXISTS ministry_version (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	ministry_id INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	names TEXT NOT NULL DEFAULT '{}',
+	kind TEXT NOT NULL,
+	parent_id INTEGER,
+	valid_from TEXT NOT NULL,
+	valid_to TEXT CHECK (valid_to > valid_from)
+);
+
+CREATE TABLE IF NOT EXISTS department_version (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	department_id INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	names TEXT NOT NULL DEFAULT '{}',
+	kind TEXT NOT NULL,
+	parent_id INTEGER,
+	ministry_id INTEGER,
+	valid_from TEXT NOT NULL,
+	valid_to TEXT CHECK (valid_to > valid_from)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ministry_version_open_idx ON ministry_version (ministry_id) WHERE valid_to IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS department_version_open_idx ON department_version (department_id) WHERE valid_to IS NULL;
+CREATE INDEX IF NOT EXISTS ministry_version_range_idx ON ministry_version (valid_from, valid_to);
+CREATE INDEX IF NOT EXISTS department_version_range_idx ON department_version (valid_from, valid_to);
+
+CREATE TABLE IF NOT EXISTS gazette_change (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	gazette TEXT NOT NULL,
+	effective_date TEXT NOT NULL,
+	type TEXT NOT NULL,
+	operation TEXT NOT NULL,
+	applied_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS gazette_change_gazette_idx ON gazette_change (gazette);
+
+CREATE TABLE IF NOT EXISTS person (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	title TEXT
+);
+
+CREATE TABLE IF NOT EXISTS position_term (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	person_id INTEGER NOT NULL REFERENCES person(id) ON DELETE CASCADE,
+	role TEXT NOT NULL
+		CHECK (role IN ('minister', 'state_minister', 'deputy_minister', 'secretary', 'head')),
+	entity TEXT NOT NULL CHECK (entity IN ('ministry', 'department')),
+	organization_id INTEGER NOT NULL,
+	start_date TEXT NOT NULL,
+	end_date TEXT CHECK (end_date > start_date)
+);
+
+CREATE INDEX IF NOT EXISTS position_term_org_idx ON position_term (entity, organization_id);
+CREATE INDEX IF NOT EXISTS position_term_person_idx ON position_term (person_id);
+
+CREATE TABLE IF NOT EXISTS office (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	department_id INTEGER NOT NULL REFERENCES department(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	phone_numbers TEXT NOT NULL DEFAULT '[]',
+	email TEXT,
+	hours TEXT NOT NULL DEFAULT '[]',
+	closures TEXT NOT NULL DEFAULT '[]',
+	latitude REAL CHECK (latitude BETWEEN -90 AND 90),
+	longitude REAL CHECK (longitude BETWEEN -180 AND 180),
+	address TEXT,
+	geometry TEXT
+);
+
+CREATE INDEX IF NOT EXISTS office_department_idx ON office (department_id);
+
+-- Porter stems English as the english configuration does; marks are kept
+-- in words so Sinhala and Tamil vowel signs do not split them.
+CREATE VIRTUAL TABLE IF NOT EXISTS ministry_search USING fts5 (name, names, search_key, address,
+	tokenize = "porter unicode61 remove_diacritics 0 categories 'L* N* M* Co'");
+CREATE VIRTUAL TABLE IF NOT EXISTS department_search USING fts5 (name, names, search_key, address,
+	tokenize = "porter unicode61 remove_diacritics 0 categories 'L* N* M* Co'");
+CREATE VIRTUAL TABLE IF NOT EXISTS office_search USING fts5 (name, names, search_key, address,
+	tokenize = "porter unicode61 remove_diacritics 0 categories 'L* N* M* Co'");
+
+CREATE TRIGGER IF NOT EXISTS ministry_search_insert AFTER INSERT ON ministry BEGIN
+	INSERT INTO ministry_search (rowid, name, names, search_key, address)
+	VALUES (new.id, new.name, (SELECT group_concat(value, ' ') FROM json_each(new.names)), new.search_key, new.address);
+END;
+CREATE TRIGGER IF NOT EXISTS ministry_search_update AFTER UPDATE ON ministry BEGIN
+	DELETE FROM ministry_search WHERE rowid = old.id;
+	INSERT INTO ministry_search (rowid, name, names, search_key, address)
+	VALUES (new.id, new.name, (SELECT group_concat(value, ' ') FROM json_each(new.names)), new.search_key, new.address);
+END;
+CREATE TRIGGER IF NOT EXISTS ministry_search_delete AFTER DELETE ON ministry BEGIN
+	DELETE FROM ministry_search WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS department_search_insert AFTER INSERT ON department BEGIN
+	INSERT INTO department_search (rowid, name, names, search_key, address)
+	VALUES (new.id, new.name, (SELECT group_concat(value, ' ') FROM json_each(new.names)), new.search_key, new.address);
+END;
+CREATE TRIGGER IF NOT EXISTS department_search_update AFTER UPDATE ON department BEGIN
+	DELETE FROM department_search WHERE rowid = old.id;
+	INSERT INTO department_search (rowid, name, names, search_key, address)
+	VALUES (new.id, new.name, (SELECT group_concat(value, ' ') FROM json_each(new.names)), new.search_key, new.address);
+END;
+CREATE TRIGGER IF NOT EXISTS department_search_delete AFTER DELETE ON department BEGIN
+	DELETE FROM department_search WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS office_search_insert AFTER INSERT ON office BEGIN
+	INSERT INTO office_search (rowid, name, address) VALUES (new.id, new.name, new.address);
+END;
+CREATE TRIGGER IF NOT EXISTS office_search_update AFTER UPDATE ON office BEGIN
+	DELETE FROM office_search WHERE rowid = old.id;
+	INSERT INTO office_search (rowid, name, address) VALUES (new.id, new.name, new.address);
+END;
+CREATE TRIGGER IF NOT EXISTS office_search_delete AFTER DELETE ON office BEGIN
+	DELETE FROM office_search WHERE rowid = old.id;
+END;
+`
+
+// OpenSQLite opens, creating it if needed, the SQLite database at path and
+// ensures its schema. Foreign keys are enforced, and transactions take the
+// write lock when they begin, which stands in for the row locks Postgres
+// takes.
+func OpenSQLite(path string) (*sql.DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_txlock", "immediate")
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	if err := EnsureSQLiteSchema(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// EnsureSQLiteSchema creates the tables, indexes and search tables that do
+// not exist yet.
+func EnsureSQLiteSchema(db *sql.DB) error {
+	_, err := db.Exec(sqliteSchema)
+	return err
+}
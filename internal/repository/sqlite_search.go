@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/search"
+	"go-mysql-backend/internal/translit"
+)
+
+// sqliteSearchQuery returns the best $3 of the ministries, departments and
+// offices whose FTS5 table matches $1, for the entities in the JSON list
+// $2. bm25 is weighted after the setweight classes of the Postgres search
+// columns and negated so that higher scores are better.
+const sqliteSearchQuery = `
+	WITH hits AS (
+		SELECT 'ministry' AS entity, 1 AS entity_order, m.id, m.name, m.names, NULL AS parent_id,
+			-bm25(ministry_search, 1.0, 1.0, 0.4, 0.2) AS score,
+			concat_ws(' · ', m.name, m.names->>'si', m.names->>'ta', m.address) AS text
+		FROM ministry_search JOIN ministry m ON m.id = ministry_search.rowid
+		WHERE 'ministry' IN (SELECT value FROM json_each($2)) AND ministry_search MATCH $1
+		UNION ALL
+		SELECT 'department', 2, d.id, d.name, d.names, d.ministry_id,
+			-bm25(department_search, 1.0, 1.0, 0.4, 0.2),
+			concat_ws(' · ', d.name, d.names->>'si', d.names->>'ta', d.address)
+		FROM department_search JOIN department d ON d.id = department_search.rowid
+		WHERE 'department' IN (SELECT value FROM json_each($2)) AND department_search MATCH $1
+		UNION ALL
+		SELECT 'office', 3, o.id, o.name, '{}', o.department_id,
+			-bm25(office_search, 1.0, 1.0, 0.4, 0.2),
+			concat_ws(' · ', o.name, o.address)
+		FROM office_search JOIN office o ON o.id = office_search.rowid
+		WHERE 'office' IN (SELECT value FROM json_each($2)) AND office_search MATCH $1
+	)
+	SELECT entity, id, name, names, parent_id, score, text
+	FROM hits
+	ORDER BY score DESC, entity_order, id
+	LIMIT $3
+`
+
+// Search returns the best matches for q from the FTS5 tables. It always
+// searches the current structure, also on an as-of view.
+func (r *SQLiteRepository) Search(q models.SearchQuery) ([]models.SearchHit, error) {
+	terms := search.Terms(q.Text)
+	if len(terms) == 0 {
+		return []models.SearchHit{}, nil
+	}
+	entities, err := json.Marshal(q.Entities)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.DB.Query(sqliteSearchQuery, sqliteMatch(terms, translit.Keys(q.Text)), string(entities), q.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := []models.SearchHit{}
+	for rows.Next() {
+		var hit models.SearchHit
+		var names []byte
+		var parentID sql.NullInt64
+		var text string
+		if err := rows.Scan(&hit.Entity, &hit.ID, &hit.Name, &names, &parentID, &hit.Score, &text); err != nil {
+			return nil, err
+		}
+		hit.Snippet = search.Highlight(text, terms)
+		hit.Names = decodeNames(names)
+		hit.ParentID = nullIntPtr(parentID)
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
+
+// sqliteMatch returns an FTS5 query matching records whose names or
+// address hold every term, or whose phonetic keys hold every key, each as
+// a prefix. Terms only hold letters, digits and marks and keys only ASCII
+// letters and digits, so quoting them is enough.
+func sqliteMatch(terms, keys []string) string {
+	match := "{name names address} : (" + sqlitePrefixes(terms) + ")"
+	if len(keys) > 0 {
+		match += " OR search_key : (" + sqlitePrefixes(keys) + ")"
+	}
+	return match
+}
+
+func sqlitePrefixes(words []string) string {
+	clauses := make([]string, len(words))
+	for i, word := range words {
+		clauses[i] = `"` + word + `"*`
+	}
+	return strings.Join(clauses, " AND ")
+}
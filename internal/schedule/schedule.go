@@ -0,0 +1,61 @@
+// Package schedule decides whether an office is open at a given instant
+// from its weekly hours and closures. Hours are wall-clock times in Sri
+// Lanka, which has kept a fixed UTC+05:30 offset since 2006, so there are no
+// daylight-saving gaps or repeated hours to handle.
+package schedule
+
+import (
+	"time"
+
+	"go-mysql-backend/internal/models"
+)
+
+// OpenAt reports whether an office with the given hours and closures is
+// open at t. A closure cancels the openings that start on its date; an
+// overnight opening that started the evening before still runs until it
+// closes.
+func OpenAt(hours []models.OpeningHours, closures []models.Closure, t time.Time) bool {
+	local := t.In(models.CivilZone)
+	minute := models.ClockTime(local.Hour()*60 + local.Minute())
+	today := models.DateOf(t)
+	yesterday := today.AddDays(-1)
+
+	for _, h := range hours {
+		day := time.Weekday(h.Day)
+		if day == today.Weekday() && !closed(closures, today) &&
+			minute >= h.Opens && (h.Overnight() || minute < h.Closes) {
+			return true
+		}
+		if day == yesterday.Weekday() && h.Overnight() && !closed(closures, yesterday) &&
+			minute < h.Closes {
+			return true
+		}
+	}
+	return false
+}
+
+// Annotate sets OpenNow on every office that has hours, as of now.
+func Annotate(offices []models.Office, now time.Time) {
+	for i := range offices {
+		AnnotateOne(&offices[i], now)
+	}
+}
+
+// AnnotateOne sets OpenNow on an office that has hours, as of now.
+func AnnotateOne(office *models.Office, now time.Time) {
+	if len(office.Hours) == 0 {
+		office.OpenNow = nil
+		return
+	}
+	open := OpenAt(office.Hours, office.Closures, now)
+	office.OpenNow = &open
+}
+
+func closed(closures []models.Closure, date models.Date) bool {
+	for _, c := range closures {
+		if c.Date.Equal(date) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package schedule_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/schedule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// colombo builds an instant from Sri Lanka wall-clock time.
+func colombo(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, models.CivilZone)
+}
+
+func hours(t *testing.T, spec string) []models.OpeningHours {
+	var h []models.OpeningHours
+	assert.NoError(t, json.Unmarshal([]byte(spec), &h))
+	return h
+}
+
+// weekdays is a typical government office: 08:30-16:15 Monday to Friday.
+const weekdays = `[
+	{"day": "monday", "opens": "08:30", "closes": "16:15"},
+	{"day": "tuesday", "opens": "08:30", "closes": "16:15"},
+	{"day": "wednesday", "opens": "08:30", "closes": "16:15"},
+	{"day": "thursday", "opens": "08:30", "closes": "16:15"},
+	{"day": "friday", "opens": "08:30", "closes": "16:15"}
+]`
+
+func TestOpenAt_WeekdayHours(t *testing.T) {
+	h := hours(t, weekdays)
+	// 2024-03-04 is a Monday.
+	cases := []struct {
+		name string
+		at   time.Time
+		open bool
+	}{
+		{"before opening", colombo(2024, time.March, 4, 8, 29), false},
+		{"at opening", colombo(2024, time.March, 4, 8, 30), true},
+		{"midday", colombo(2024, time.March, 4, 12, 0), true},
+		{"last minute", colombo(2024, time.March, 4, 16, 14), true},
+		{"at closing", colombo(2024, time.March, 4, 16, 15), false},
+		{"saturday", colombo(2024, time.March, 9, 10, 0), false},
+		{"sunday", colombo(2024, time.March, 10, 10, 0), false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.open, schedule.OpenAt(h, nil, c.at), c.name)
+	}
+
+	// Seconds within the last open minute still count as open.
+	lastSecond := colombo(2024, time.March, 4, 16, 14).Add(59*time.Second + 999*time.Millisecond)
+	assert.True(t, schedule.OpenAt(h, nil, lastSecond))
+}
+
+func TestOpenAt_EvaluatesInColomboTime(t *testing.T) {
+	h := hours(t, weekdays)
+
+	// 03:00 UTC is 08:30 in Colombo.
+	assert.True(t, schedule.OpenAt(h, nil, time.Date(2024, time.March, 4, 3, 0, 0, 0, time.UTC)))
+	assert.False(t, schedule.OpenAt(h, nil, time.Date(2024, time.March, 4, 2, 59, 0, 0, time.UTC)))
+
+	// 19:00 UTC on Sunday is already 00:30 on Monday in Colombo, and
+	// 04:00 on Monday in Colombo is still Sunday evening in New York.
+	night := hours(t, `[{"day": "monday", "opens": "00:00", "closes": "06:00"}]`)
+	assert.True(t, schedule.OpenAt(night, nil, time.Date(2024, time.March, 3, 19, 0, 0, 0, time.UTC)))
+	newYork := time.FixedZone("EST", -5*60*60)
+	assert.True(t, schedule.OpenAt(night, nil, time.Date(2024, time.March, 3, 17, 30, 0, 0, newYork)))
+
+	// Sri Lanka does not observe daylight saving: the same wall-clock time
+	// is the same UTC instant in January and July.
+	assert.True(t, schedule.OpenAt(h, nil, time.Date(2024, time.January, 8, 3, 0, 0, 0, time.UTC)))
+	assert.True(t, schedule.OpenAt(h, nil, time.Date(2024, time.July, 8, 3, 0, 0, 0, time.UTC)))
+}
+
+func TestOpenAt_Overnight(t *testing.T) {
+	// Open Friday evening until 02:00 on Saturday.
+	h := hours(t, `[{"day": "friday", "opens": "20:00", "closes": "02:00"}]`)
+
+	assert.False(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 8, 19, 59)))
+	assert.True(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 8, 20, 0)))
+	assert.True(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 8, 23, 59)))
+	assert.True(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 9, 0, 0)))
+	assert.True(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 9, 1, 59)))
+	assert.False(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 9, 2, 0)))
+	// Friday morning is not the tail of Thursday night.
+	assert.False(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 8, 1, 0)))
+}
+
+func TestOpenAt_OvernightAcrossWeek(t *testing.T) {
+	// Sunday night into Monday morning.
+	h := hours(t, `[{"day": "sunday", "opens": "22:00", "closes": "06:00"}]`)
+
+	assert.True(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 10, 22, 30)))
+	assert.True(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 11, 5, 59)))
+	assert.False(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 11, 6, 0)))
+
+	// And across a year end: 2023-12-31 is a Sunday.
+	assert.True(t, schedule.OpenAt(h, nil, colombo(2024, time.January, 1, 3, 0)))
+}
+
+func TestOpenAt_UntilMidnightAndAllDay(t *testing.T) {
+	untilMidnight := hours(t, `[{"day": "monday", "opens": "18:00", "closes": "00:00"}]`)
+	assert.True(t, schedule.OpenAt(untilMidnight, nil, colombo(2024, time.March, 4, 23, 59)))
+	assert.False(t, schedule.OpenAt(untilMidnight, nil, colombo(2024, time.March, 5, 0, 0)))
+
+	allDay := hours(t, `[{"day": "monday", "opens": "00:00", "closes": "00:00"}]`)
+	assert.True(t, schedule.OpenAt(allDay, nil, colombo(2024, time.March, 4, 0, 0)))
+	assert.True(t, schedule.OpenAt(allDay, nil, colombo(2024, time.March, 4, 23, 59)))
+	assert.False(t, schedule.OpenAt(allDay, nil, colombo(2024, time.March, 5, 0, 0)))
+	assert.False(t, schedule.OpenAt(allDay, nil, colombo(2024, time.March, 3, 23, 59)))
+}
+
+func TestOpenAt_Closures(t *testing.T) {
+	h := hours(t, weekdays)
+	// Poya day on Monday 2024-03-25.
+	poya := []models.Closure{{Date: models.NewDate(2024, time.March, 25), Reason: "Medin Full Moon Poya Day"}}
+
+	assert.False(t, schedule.OpenAt(h, poya, colombo(2024, time.March, 25, 10, 0)))
+	assert.True(t, schedule.OpenAt(h, poya, colombo(2024, time.March, 26, 10, 0)))
+
+	// The closure date is the Colombo date: 20:00 UTC on the 24th is
+	// already the 25th in Sri Lanka.
+	late := hours(t, `[{"day": "monday", "opens": "00:00", "closes": "06:00"}]`)
+	assert.False(t, schedule.OpenAt(late, poya, time.Date(2024, time.March, 24, 20, 0, 0, 0, time.UTC)))
+}
+
+func TestOpenAt_ClosureKeepsOvernightTail(t *testing.T) {
+	h := hours(t, `[
+		{"day": "sunday", "opens": "22:00", "closes": "06:00"},
+		{"day": "monday", "opens": "22:00", "closes": "06:00"}
+	]`)
+	monday := []models.Closure{{Date: models.NewDate(2024, time.March, 25)}}
+
+	// Sunday night's opening started before the closure and runs on.
+	assert.True(t, schedule.OpenAt(h, monday, colombo(2024, time.March, 25, 5, 0)))
+	// Monday night's opening starts on the closed day and is cancelled,
+	// including its tail on Tuesday morning.
+	assert.False(t, schedule.OpenAt(h, monday, colombo(2024, time.March, 25, 23, 0)))
+	assert.False(t, schedule.OpenAt(h, monday, colombo(2024, time.March, 26, 5, 0)))
+}
+
+func TestOpenAt_SplitHours(t *testing.T) {
+	// A lunch break is two openings on the same day.
+	h := hours(t, `[
+		{"day": "saturday", "opens": "08:30", "closes": "12:30"},
+		{"day": "saturday", "opens": "13:30", "closes": "15:00"}
+	]`)
+	assert.True(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 9, 12, 29)))
+	assert.False(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 9, 13, 0)))
+	assert.True(t, schedule.OpenAt(h, nil, colombo(2024, time.March, 9, 13, 30)))
+}
+
+func TestAnnotate(t *testing.T) {
+	offices := []models.Office{
+		{ID: 1, Name: "Head Office", Hours: hours(t, weekdays)},
+		{ID: 2, Name: "Unknown hours"},
+	}
+	schedule.Annotate(offices, colombo(2024, time.March, 4, 9, 0))
+
+	if assert.NotNil(t, offices[0].OpenNow) {
+		assert.True(t, *offices[0].OpenNow)
+	}
+	assert.Nil(t, offices[1].OpenNow)
+}
@@ -0,0 +1,103 @@
+// Package search holds the query handling shared by the full-text search
+// backends: splitting a query into terms, turning it into a Lucene query
+// for the Neo4j full-text index and highlighting matches in a snippet.
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"go-mysql-backend/internal/translit"
+)
+
+// MarkStart and MarkEnd wrap the matching words of a snippet.
+const (
+	MarkStart = "<mark>"
+	MarkEnd   = "</mark>"
+)
+
+// Terms splits a query into lower-case words, dropping punctuation and
+// repeated words.
+func Terms(query string) []string {
+	var terms []string
+	seen := map[string]bool{}
+	for _, word := range strings.FieldsFunc(query, isSeparator) {
+		word = strings.ToLower(word)
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// isSeparator splits words on anything that is not a letter, a digit or a
+// combining mark; Sinhala and Tamil vowel signs are marks, and zero-width
+// joiners hold Sinhala conjuncts together.
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r) && r != '\u200d'
+}
+
+// LuceneQuery returns a Lucene query matching records that contain every
+// term, each also matched as a prefix so "licence" finds "licences".
+// Terms only hold letters, digits and marks, so nothing needs escaping.
+func LuceneQuery(terms []string) string {
+	clauses := make([]string, len(terms))
+	for i, term := range terms {
+		clauses[i] = "(" + term + " OR " + term + "*)"
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// KeyQuery returns a Postgres tsquery matching every phonetic key of
+// text as a prefix, or "" when text has no keys. Keys only hold ASCII
+// letters and digits, so nothing needs escaping.
+func KeyQuery(text string) string {
+	keys := translit.Keys(text)
+	for i, key := range keys {
+		keys[i] = key + ":*"
+	}
+	return strings.Join(keys, " & ")
+}
+
+// Highlight wraps the words of text that start with one of terms, or
+// whose phonetic key starts with the key of one, in MarkStart and MarkEnd.
+func Highlight(text string, terms []string) string {
+	var keys []string
+	for _, term := range terms {
+		if key := translit.Key(term); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	var b strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if isSeparator(runes[i]) {
+			b.WriteRune(runes[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && !isSeparator(runes[j]) {
+			j++
+		}
+		word := string(runes[i:j])
+		if matchesAny(strings.ToLower(word), terms) || matchesAny(translit.Key(word), keys) {
+			b.WriteString(MarkStart + word + MarkEnd)
+		} else {
+			b.WriteString(word)
+		}
+		i = j
+	}
+	return b.String()
+}
+
+func matchesAny(word string, terms []string) bool {
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
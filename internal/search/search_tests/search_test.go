@@ -0,0 +1,36 @@
+package search_test
+
+import (
+	"testing"
+
+	"go-mysql-backend/internal/search"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"driving", "licences"}, search.Terms("  Driving, licences! driving"))
+	assert.Equal(t, []string{"motor", "traffic", "අමාත්‍යාංශය"}, search.Terms("motor-traffic අමාත්‍යාංශය"))
+	assert.Empty(t, search.Terms(" ?!* "))
+}
+
+func TestLuceneQuery(t *testing.T) {
+	assert.Equal(t, "(motor OR motor*) AND (traffic OR traffic*)", search.LuceneQuery([]string{"motor", "traffic"}))
+	assert.Equal(t, "", search.LuceneQuery(nil))
+}
+
+func TestKeyQuery(t *testing.T) {
+	assert.Equal(t, "atyapana:* & amatyansaya:*", search.KeyQuery("Adhyapana amaathyanshaya"))
+	assert.Equal(t, "", search.KeyQuery(" ?! "))
+}
+
+func TestHighlight(t *testing.T) {
+	assert.Equal(t,
+		"Department of <mark>Motor</mark> <mark>Traffic</mark> · Colombo 05",
+		search.Highlight("Department of Motor Traffic · Colombo 05", []string{"motor", "traf"}))
+	assert.Equal(t, "Department of Ports", search.Highlight("Department of Ports", []string{"motor"}))
+	assert.Equal(t, "<mark>சுகாதார</mark> அமைச்சு", search.Highlight("சுகாதார அமைச்சு", []string{"சுகாதார"}))
+
+	// Romanized terms mark the words they match by phonetic key.
+	assert.Equal(t, "<mark>අධ්‍යාපන</mark> අමාත්‍යාංශය", search.Highlight("අධ්‍යාපන අමාත්‍යාංශය", []string{"adhyaapana"}))
+}
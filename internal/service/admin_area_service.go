@@ -0,0 +1,41 @@
+package service
+
+import (
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+)
+
+// AdminAreaService serves the administrative area endpoints. Jurisdiction,
+// when set, is invalidated when a department's area changes.
+type AdminAreaService struct {
+	Repo         repository.AdminAreaRepo
+	Jurisdiction *JurisdictionService
+}
+
+func NewAdminAreaService(repo repository.AdminAreaRepo) *AdminAreaService {
+	return &AdminAreaService{Repo: repo}
+}
+
+func (s *AdminAreaService) GetAreas(level models.AdminLevel) ([]models.AdminArea, error) {
+	return s.Repo.GetAreas(level)
+}
+
+func (s *AdminAreaService) GetAreaByID(id int) (*models.AdminArea, error) {
+	return s.Repo.GetAreaByID(id)
+}
+
+func (s *AdminAreaService) GetChildAreas(id int) ([]models.AdminArea, error) {
+	return s.Repo.GetChildAreas(id)
+}
+
+func (s *AdminAreaService) GetAreaDepartments(id int) ([]models.Department, error) {
+	return s.Repo.GetAreaDepartments(id)
+}
+
+func (s *AdminAreaService) SetDepartmentArea(departmentID int, areaID *int) error {
+	err := s.Repo.SetDepartmentArea(departmentID, areaID)
+	if err == nil {
+		s.Jurisdiction.Invalidate()
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"log"
+
+	"go-mysql-backend/internal/autocomplete"
+	"go-mysql-backend/internal/models"
+)
+
+// autocompleteSuggestions lists the ministries and departments of
+// ministries as autocomplete index entries.
+func autocompleteSuggestions(ministries []models.MinistryWithDepartments) []models.Suggestion {
+	var suggestions []models.Suggestion
+	for _, m := range ministries {
+		suggestions = append(suggestions, ministrySuggestion(m.Ministry))
+		for _, d := range m.Departments {
+			suggestions = append(suggestions, departmentSuggestion(d))
+		}
+	}
+	return suggestions
+}
+
+func ministrySuggestion(m models.Ministry) models.Suggestion {
+	return models.Suggestion{Entity: models.SearchMinistry, ID: m.ID, Name: m.Name, Names: m.Names}
+}
+
+func departmentSuggestion(d models.Department) models.Suggestion {
+	ministryID := d.MinistryID
+	return models.Suggestion{Entity: models.SearchDepartment, ID: d.ID, Name: d.Name, Names: d.Names, ParentID: &ministryID}
+}
+
+// loadAutocomplete fills index from load, which reads every ministry with
+// its departments.
+func loadAutocomplete(index *autocomplete.Index, load func() ([]models.MinistryWithDepartments, error)) error {
+	ministries, err := load()
+	if err != nil {
+		return err
+	}
+	index.Load(autocompleteSuggestions(ministries))
+	return nil
+}
+
+// reloadAutocomplete refreshes index after a write that may have changed
+// many names or parents at once. The write has already succeeded, so a
+// failure is only logged; the index catches up on the next reload.
+func reloadAutocomplete(index *autocomplete.Index, load func() ([]models.MinistryWithDepartments, error)) {
+	if index == nil {
+		return
+	}
+	if err := loadAutocomplete(index, load); err != nil {
+		log.Printf("autocomplete: reload failed: %v", err)
+	}
+}
+
+// lookupAutocomplete answers q from index, which may be nil when it has
+// not been loaded.
+func lookupAutocomplete(index *autocomplete.Index, q models.AutocompleteQuery) models.AutocompleteResult {
+	result := models.AutocompleteResult{Query: q.Text, Suggestions: []models.Suggestion{}}
+	if index != nil {
+		result.Suggestions = index.Lookup(q)
+	}
+	return result
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-mysql-backend/internal/geo"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+)
+
+// DefaultJurisdictionMaxAge is how long a built spatial index is used
+// before it is rebuilt from the repositories.
+const DefaultJurisdictionMaxAge = 5 * time.Minute
+
+// OrganizationSource is the part of an organisation repository the
+// jurisdiction lookup needs; every repository.OrganizationRepo satisfies
+// it.
+type OrganizationSource interface {
+	GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error)
+}
+
+// JurisdictionService answers point-in-polygon queries against the stored
+// administrative boundaries and department geometries. Boundaries change
+// rarely, so they are loaded into an in-process grid index that is rebuilt
+// once it is older than MaxAge or after Invalidate.
+//
+// Lookups read the index without locking. One lookup at a time rebuilds
+// it; while it does, the others keep answering from the index it replaces
+// if that has only aged, and wait for the new one if it was invalidated or
+// there is none yet.
+type JurisdictionService struct {
+	Areas         repository.AdminAreaRepo
+	Organizations OrganizationSource
+	MaxAge        time.Duration
+
+	index      atomic.Pointer[jurisdictionIndex]
+	generation atomic.Uint64 // bumped by Invalidate
+	building   sync.Mutex    // held while the index is rebuilt
+	now        func() time.Time
+}
+
+func NewJurisdictionService(areas repository.AdminAreaRepo, organizations OrganizationSource) *JurisdictionService {
+	return &JurisdictionService{
+		Areas:         areas,
+		Organizations: organizations,
+		MaxAge:        DefaultJurisdictionMaxAge,
+		now:           time.Now,
+	}
+}
+
+// jurisdictionIndex is an immutable snapshot of the boundaries, so lookups
+// can run while a newer snapshot is being built. generation is the value
+// of JurisdictionService.generation when the build started.
+type jurisdictionIndex struct {
+	areas           *geo.Index
+	areaByID        map[int]models.AdminArea
+	departments     *geo.Index
+	departmentByID  map[int]models.Department
+	areaDepartments map[int][]models.Department
+
+	builtAt    time.Time
+	generation uint64
+}
+
+// Lookup returns the areas containing the point, from the province down,
+// with the departments responsible for each.
+func (s *JurisdictionService) Lookup(lat, lon float64) (models.Jurisdiction, error) {
+	index, err := s.currentIndex()
+	if err != nil {
+		return models.Jurisdiction{}, err
+	}
+
+	result := models.Jurisdiction{
+		Latitude:    lat,
+		Longitude:   lon,
+		Areas:       []models.AreaJurisdiction{},
+		Departments: []models.Department{},
+	}
+	for _, id := range index.areas.Query(lon, lat) {
+		area := index.areaByID[id]
+		area.Geometry = nil
+		departments := index.areaDepartments[id]
+		if departments == nil {
+			departments = []models.Department{}
+		}
+		result.Areas = append(result.Areas, models.AreaJurisdiction{AdminArea: area, Departments: departments})
+	}
+	for _, id := range index.departments.Query(lon, lat) {
+		result.Departments = append(result.Departments, index.departmentByID[id])
+	}
+	return result, nil
+}
+
+// Invalidate makes the next lookup rebuild the index, after a write that
+// changed areas or departments. It may be called on a nil service.
+func (s *JurisdictionService) Invalidate() {
+	if s == nil {
+		return
+	}
+	s.generation.Add(1)
+}
+
+func (s *JurisdictionService) currentIndex() (*jurisdictionIndex, error) {
+	index := s.index.Load()
+	if s.fresh(index) {
+		return index, nil
+	}
+	if index != nil && index.generation == s.generation.Load() {
+		// Only aged: answer from it unless this lookup gets to rebuild.
+		if !s.building.TryLock() {
+			return index, nil
+		}
+	} else {
+		s.building.Lock()
+	}
+	defer s.building.Unlock()
+
+	// Another lookup may have rebuilt it while this one waited.
+	if index := s.index.Load(); s.fresh(index) {
+		return index, nil
+	}
+	generation := s.generation.Load()
+	index, err := s.buildIndex()
+	if err != nil {
+		return nil, err
+	}
+	index.builtAt, index.generation = s.now(), generation
+	s.index.Store(index)
+	return index, nil
+}
+
+func (s *JurisdictionService) fresh(index *jurisdictionIndex) bool {
+	return index != nil && index.generation == s.generation.Load() && s.now().Sub(index.builtAt) < s.MaxAge
+}
+
+// buildIndex loads every boundary. Areas are inserted from provinces down,
+// which is the order Lookup reports them in. Geometries that are not
+// polygons, such as department points, are left out of the index.
+func (s *JurisdictionService) buildIndex() (*jurisdictionIndex, error) {
+	areas, err := s.Areas.GetAreaBoundaries()
+	if err != nil {
+		return nil, err
+	}
+	ministries, err := s.Organizations.GetMinistriesWithDepartments()
+	if err != nil {
+		return nil, err
+	}
+	var departments []models.Department
+	for _, m := range ministries {
+		departments = append(departments, m.Departments...)
+	}
+
+	index := &jurisdictionIndex{
+		areas:           geo.NewIndex(geo.DefaultCellSizeDeg),
+		areaByID:        make(map[int]models.AdminArea, len(areas)),
+		departments:     geo.NewIndex(geo.DefaultCellSizeDeg),
+		departmentByID:  make(map[int]models.Department),
+		areaDepartments: make(map[int][]models.Department),
+	}
+
+	for _, area := range areas {
+		shape, err := geo.ParseMultiPolygon(area.Geometry)
+		if err != nil {
+			continue
+		}
+		index.areas.Insert(area.ID, shape)
+		index.areaByID[area.ID] = area
+	}
+
+	for _, dept := range departments {
+		if dept.AdminAreaID != nil {
+			index.areaDepartments[*dept.AdminAreaID] = append(index.areaDepartments[*dept.AdminAreaID], dept)
+		}
+		if len(dept.Geometry) == 0 {
+			continue
+		}
+		shape, err := geo.ParseMultiPolygon(dept.Geometry)
+		if err != nil {
+			continue
+		}
+		index.departments.Insert(dept.ID, shape)
+		index.departmentByID[dept.ID] = dept
+	}
+
+	return index, nil
+}
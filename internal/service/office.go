@@ -0,0 +1,56 @@
+package service
+
+import (
+	"time"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/schedule"
+)
+
+// officeScanLimit bounds how many nearby offices are read when they are
+// filtered by opening hours, since the filter runs after the query.
+const officeScanLimit = 1000
+
+// openOffices sets OpenNow on offices and, when at is set, keeps only the
+// offices open at that instant. Offices without hours are never known to
+// be open.
+func openOffices(offices []models.Office, at *time.Time) []models.Office {
+	schedule.Annotate(offices, time.Now())
+	if at == nil {
+		return offices
+	}
+	open := []models.Office{}
+	for _, office := range offices {
+		if schedule.OpenAt(office.Hours, office.Closures, *at) {
+			open = append(open, office)
+		}
+	}
+	return open
+}
+
+// openNearbyOffices is openOffices for a nearby search, keeping at most
+// limit offices.
+func openNearbyOffices(offices []models.NearbyOffice, at *time.Time, limit int) []models.NearbyOffice {
+	now := time.Now()
+	open := []models.NearbyOffice{}
+	for _, office := range offices {
+		if at != nil && !schedule.OpenAt(office.Hours, office.Closures, *at) {
+			continue
+		}
+		schedule.AnnotateOne(&office.Office, now)
+		open = append(open, office)
+		if len(open) == limit {
+			break
+		}
+	}
+	return open
+}
+
+// nearbyOfficeQuery widens q so that enough offices are read to fill
+// q.Limit after filtering by opening hours.
+func nearbyOfficeQuery(q models.NearbyQuery, at *time.Time) models.NearbyQuery {
+	if at != nil {
+		q.Limit = officeScanLimit
+	}
+	return q
+}
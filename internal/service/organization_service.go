@@ -0,0 +1,300 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"go-mysql-backend/internal/autocomplete"
+	"go-mysql-backend/internal/importer"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+	"go-mysql-backend/internal/schedule"
+)
+
+// ErrSeedUnsupported is returned by SeedDummyData when the backend cannot
+// generate demonstration data.
+var ErrSeedUnsupported = errors.New("this backend cannot seed demonstration data")
+
+// OrganizationService serves the organisation endpoints from any backend.
+// AutocompleteIndex is nil until LoadAutocomplete is called, and is then
+// kept current by the writes below. Jurisdiction, when set, is invalidated
+// by the writes that change departments.
+type OrganizationService struct {
+	Repo              repository.OrganizationRepo
+	AutocompleteIndex *autocomplete.Index
+	Jurisdiction      *JurisdictionService
+}
+
+func NewOrganizationService(repo repository.OrganizationRepo) *OrganizationService {
+	return &OrganizationService{Repo: repo}
+}
+
+func (s *OrganizationService) GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error) {
+	return s.Repo.GetMinistriesWithDepartments()
+}
+
+// GetMinistriesPage returns a page of ministries with their departments.
+func (s *OrganizationService) GetMinistriesPage(p models.PageRequest) (models.MinistryPage, error) {
+	page, err := s.Repo.GetMinistriesPage(p)
+	if err != nil {
+		return models.MinistryPage{}, err
+	}
+	page.SetCursors(ministryID)
+	return page, nil
+}
+
+func (s *OrganizationService) CreateMinistry(ministry models.Ministry) (int, error) {
+	id, err := s.Repo.CreateMinistry(ministry)
+	if err == nil && s.AutocompleteIndex != nil {
+		ministry.ID = id
+		s.AutocompleteIndex.Put(ministrySuggestion(ministry))
+	}
+	return id, err
+}
+
+func (s *OrganizationService) CreateDepartment(department models.Department) (int, error) {
+	id, err := s.Repo.CreateDepartment(department)
+	if err != nil {
+		return 0, err
+	}
+	s.Jurisdiction.Invalidate()
+	if s.AutocompleteIndex != nil {
+		department.ID = id
+		s.AutocompleteIndex.Put(departmentSuggestion(department))
+	}
+	return id, nil
+}
+func (s *OrganizationService) GetAllDepartments() ([]models.Department, error) {
+	return s.Repo.GetAllDepartments()
+}
+
+// GetDepartmentsPage returns a page of departments.
+func (s *OrganizationService) GetDepartmentsPage(p models.PageRequest) (models.DepartmentPage, error) {
+	page, err := s.Repo.GetDepartmentsPage(p)
+	if err != nil {
+		return models.DepartmentPage{}, err
+	}
+	page.SetCursors(departmentID)
+	return page, nil
+}
+func (s *OrganizationService) GetMinistryByID(id int) (models.Ministry, error) {
+	ministry, err := s.Repo.GetMinistryByID(id)
+	if err != nil {
+		return models.Ministry{}, err
+	}
+	return ministry, nil
+}
+
+func (s *OrganizationService) GetMinistryByIDWithDepartments(id int) (models.MinistryWithDepartments, error) {
+	ministry, err := s.Repo.GetMinistryByIDWithDepartments(id)
+	if err != nil {
+		return models.MinistryWithDepartments{}, err
+	}
+	return ministry, nil
+}
+
+// GetDepartmentByID returns a department with its offices, each flagged
+// open or closed now.
+func (s *OrganizationService) GetDepartmentByID(id int) (*models.Department, error) {
+	dept, err := s.Repo.GetDepartmentByID(id)
+	if err != nil || dept == nil {
+		return dept, err
+	}
+	dept.Offices = openOffices(dept.Offices, nil)
+	return dept, nil
+}
+
+func (s *OrganizationService) UpdateMinistry(ministry models.Ministry) error {
+	err := s.Repo.UpdateMinistry(ministry)
+	if err == nil && s.AutocompleteIndex != nil {
+		s.AutocompleteIndex.Put(ministrySuggestion(ministry))
+	}
+	return err
+}
+
+// UpdateDepartment saves department. A department moving ministry takes
+// its divisions along, so the autocomplete index is reloaded.
+func (s *OrganizationService) UpdateDepartment(department models.Department) error {
+	err := s.Repo.UpdateDepartment(department)
+	if err == nil {
+		s.Jurisdiction.Invalidate()
+		reloadAutocomplete(s.AutocompleteIndex, s.Repo.GetMinistriesWithDepartments)
+	}
+	return err
+}
+
+func (s *OrganizationService) DeleteMinistry(id int, mode models.MinistryDeleteMode, reassignTo int) error {
+	err := s.Repo.DeleteMinistry(id, mode, reassignTo)
+	if err == nil {
+		s.Jurisdiction.Invalidate()
+		reloadAutocomplete(s.AutocompleteIndex, s.Repo.GetMinistriesWithDepartments)
+	}
+	return err
+}
+
+func (s *OrganizationService) DeleteDepartment(id int) error {
+	err := s.Repo.DeleteDepartment(id)
+	if err != nil {
+		return err
+	}
+	s.Jurisdiction.Invalidate()
+	if s.AutocompleteIndex != nil {
+		s.AutocompleteIndex.Remove(models.SearchDepartment, id)
+	}
+	return nil
+}
+
+// SeedDummyData fills the backend with random ministries and departments,
+// or returns ErrSeedUnsupported when it is not a repository.Seeder.
+func (s *OrganizationService) SeedDummyData() error {
+	seeder, ok := s.Repo.(repository.Seeder)
+	if !ok {
+		return ErrSeedUnsupported
+	}
+	err := seeder.SeedDummyData()
+	if err == nil {
+		s.Jurisdiction.Invalidate()
+		reloadAutocomplete(s.AutocompleteIndex, s.Repo.GetMinistriesWithDepartments)
+	}
+	return err
+}
+
+func (s *OrganizationService) FindDepartmentsNearby(q models.NearbyQuery) ([]models.NearbyDepartment, error) {
+	return s.Repo.FindDepartmentsNearby(q)
+}
+
+// GetDepartmentsInViewport returns at most limit departments inside bbox and
+// flags the result as truncated when there are more.
+func (s *OrganizationService) GetDepartmentsInViewport(bbox models.BBox, limit int) (models.DepartmentViewport, error) {
+	departments, err := s.Repo.GetDepartmentsInBBox(bbox, limit+1)
+	if err != nil {
+		return models.DepartmentViewport{}, err
+	}
+
+	viewport := models.DepartmentViewport{Limit: limit}
+	if len(departments) > limit {
+		departments = departments[:limit]
+		viewport.Truncated = true
+	}
+	viewport.Departments = departments
+	viewport.Count = len(departments)
+	return viewport, nil
+}
+
+// GetMinistriesInViewport returns at most limit ministries inside bbox and
+// flags the result as truncated when there are more.
+func (s *OrganizationService) GetMinistriesInViewport(bbox models.BBox, limit int) (models.MinistryViewport, error) {
+	ministries, err := s.Repo.GetMinistriesInBBox(bbox, limit+1)
+	if err != nil {
+		return models.MinistryViewport{}, err
+	}
+
+	viewport := models.MinistryViewport{Limit: limit}
+	if len(ministries) > limit {
+		ministries = ministries[:limit]
+		viewport.Truncated = true
+	}
+	viewport.Ministries = ministries
+	viewport.Count = len(ministries)
+	return viewport, nil
+}
+
+func (s *OrganizationService) GetOrganizationTree(root models.OrgRef, depth int) (models.OrgNode, error) {
+	return s.Repo.GetOrganizationTree(root, depth)
+}
+
+func (s *OrganizationService) ApplyChangeSet(cs models.ChangeSet) (models.ChangeSetResult, error) {
+	result, err := s.Repo.ApplyChangeSet(cs)
+	if err == nil {
+		s.Jurisdiction.Invalidate()
+		reloadAutocomplete(s.AutocompleteIndex, s.Repo.GetMinistriesWithDepartments)
+	}
+	return result, err
+}
+
+// Import checks batch row by row and, unless opts.DryRun is set, creates
+// it in one transaction when every row is valid.
+func (s *OrganizationService) Import(batch importer.Batch, opts importer.Options) (importer.Report, error) {
+	report, err := importer.Run(s.Repo, batch, opts)
+	if err == nil && report.Created != nil {
+		s.Jurisdiction.Invalidate()
+		reloadAutocomplete(s.AutocompleteIndex, s.Repo.GetMinistriesWithDepartments)
+	}
+	return report, err
+}
+
+// GetDepartmentOffices lists the offices of a department, only those open
+// at openAt when it is set.
+func (s *OrganizationService) GetDepartmentOffices(departmentID int, openAt *time.Time) ([]models.Office, error) {
+	offices, err := s.Repo.GetDepartmentOffices(departmentID)
+	if err != nil {
+		return nil, err
+	}
+	return openOffices(offices, openAt), nil
+}
+
+func (s *OrganizationService) GetOfficeByID(id int) (*models.Office, error) {
+	office, err := s.Repo.GetOfficeByID(id)
+	if err != nil {
+		return nil, err
+	}
+	schedule.AnnotateOne(office, time.Now())
+	return office, nil
+}
+
+// FindOfficesNearby lists the offices closest to a point, only those open
+// at openAt when it is set.
+func (s *OrganizationService) FindOfficesNearby(q models.NearbyQuery, openAt *time.Time) ([]models.NearbyOffice, error) {
+	offices, err := s.Repo.FindOfficesNearby(nearbyOfficeQuery(q, openAt))
+	if err != nil {
+		return nil, err
+	}
+	return openNearbyOffices(offices, openAt, q.Limit), nil
+}
+
+func (s *OrganizationService) CreateOffice(office models.Office) (int, error) {
+	return s.Repo.CreateOffice(office)
+}
+
+func (s *OrganizationService) UpdateOffice(office models.Office) error {
+	return s.Repo.UpdateOffice(office)
+}
+
+func (s *OrganizationService) DeleteOffice(id int) error {
+	return s.Repo.DeleteOffice(id)
+}
+
+// AsOf returns a service that reads the structure as it stood on date, or
+// s itself when date is nil.
+func (s *OrganizationService) AsOf(date *models.Date) *OrganizationService {
+	if date == nil {
+		return s
+	}
+	return NewOrganizationService(s.Repo.AsOf(*date))
+}
+
+// Search runs a full-text search over the current structure.
+func (s *OrganizationService) Search(q models.SearchQuery) (models.SearchResult, error) {
+	hits, err := s.Repo.Search(q)
+	if err != nil {
+		return models.SearchResult{}, err
+	}
+	return models.NewSearchResult(q.Text, hits), nil
+}
+
+// LoadAutocomplete builds the autocomplete index from the repository.
+// The server calls it once at startup.
+func (s *OrganizationService) LoadAutocomplete() error {
+	index := autocomplete.New()
+	if err := loadAutocomplete(index, s.Repo.GetMinistriesWithDepartments); err != nil {
+		return err
+	}
+	s.AutocompleteIndex = index
+	return nil
+}
+
+// Autocomplete suggests the ministry and department names starting with
+// q from the in-memory index.
+func (s *OrganizationService) Autocomplete(q models.AutocompleteQuery) models.AutocompleteResult {
+	return lookupAutocomplete(s.AutocompleteIndex, q)
+}
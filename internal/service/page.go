@@ -0,0 +1,7 @@
+package service
+
+import "go-mysql-backend/internal/models"
+
+func ministryID(m models.MinistryWithDepartments) int { return m.ID }
+
+func departmentID(d models.Department) int { return d.ID }
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+)
+
+type PositionService struct {
+	Repo repository.PositionRepo
+}
+
+func NewPositionService(repo repository.PositionRepo) *PositionService {
+	return &PositionService{Repo: repo}
+}
+
+func (s *PositionService) CreatePerson(person models.Person) (int, error) {
+	return s.Repo.CreatePerson(person)
+}
+
+// GetPerson returns a person with their full portfolio history.
+func (s *PositionService) GetPerson(id int) (models.PersonWithPositions, error) {
+	person, err := s.Repo.GetPersonByID(id)
+	if err != nil {
+		return models.PersonWithPositions{}, err
+	}
+	positions, err := s.Repo.GetPersonPositions(id)
+	if err != nil {
+		return models.PersonWithPositions{}, err
+	}
+	return models.PersonWithPositions{Person: *person, Positions: positions}, nil
+}
+
+func (s *PositionService) CreatePosition(position models.Position) (int, error) {
+	return s.Repo.CreatePosition(position)
+}
+
+func (s *PositionService) EndPosition(id int, endDate models.Date) error {
+	return s.Repo.EndPosition(id, endDate)
+}
+
+// GetOfficeHolders returns who held office in org on date.
+func (s *PositionService) GetOfficeHolders(org models.OrgRef, role models.PositionRole, date models.Date) ([]models.Position, error) {
+	return s.Repo.GetOfficeHolders(org, role, date)
+}
+
+func (s *PositionService) GetPositionHistory(org models.OrgRef, role models.PositionRole) ([]models.Position, error) {
+	return s.Repo.GetPositionHistory(org, role)
+}
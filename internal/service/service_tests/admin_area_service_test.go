@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"testing"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+	"go-mysql-backend/internal/repository/mocks"
+	"go-mysql-backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAreas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAdminAreaRepo(ctrl)
+	parentID := 1
+	expected := []models.AdminArea{
+		{ID: 2, Code: "LK-11", Name: "Colombo", Level: models.LevelDistrict, ParentID: &parentID, ParentCode: "LK-1"},
+	}
+	mockRepo.EXPECT().GetAreas(models.LevelDistrict).Return(expected, nil)
+
+	svc := service.NewAdminAreaService(mockRepo)
+	areas, err := svc.GetAreas(models.LevelDistrict)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, areas)
+}
+
+func TestGetAreaByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAdminAreaRepo(ctrl)
+	mockRepo.EXPECT().GetAreaByID(99).Return(nil, repository.ErrNotFound)
+
+	svc := service.NewAdminAreaService(mockRepo)
+	area, err := svc.GetAreaByID(99)
+
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Nil(t, area)
+}
+
+func TestGetAreaDepartments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAdminAreaRepo(ctrl)
+	areaID := 7
+	expected := []models.Department{{ID: 12, Name: "Divisional Secretariat", MinistryID: 3, AdminAreaID: &areaID}}
+	mockRepo.EXPECT().GetAreaDepartments(1).Return(expected, nil)
+
+	svc := service.NewAdminAreaService(mockRepo)
+	departments, err := svc.GetAreaDepartments(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, departments)
+}
+
+func TestSetDepartmentArea_Unlink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAdminAreaRepo(ctrl)
+	mockRepo.EXPECT().SetDepartmentArea(12, nil).Return(nil)
+
+	svc := service.NewAdminAreaService(mockRepo)
+	assert.NoError(t, svc.SetDepartmentArea(12, nil))
+}
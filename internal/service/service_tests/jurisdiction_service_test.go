@@ -0,0 +1,174 @@
+package service_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository/mocks"
+	"go-mysql-backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func jurisdictionFixtures() ([]models.AdminArea, []models.MinistryWithDepartments) {
+	provinceID := 1
+	districtID := 2
+	areas := []models.AdminArea{
+		{ID: provinceID, Code: "1", Name: "Western", Level: models.LevelProvince,
+			Geometry: json.RawMessage(`{"type":"Polygon","coordinates":[[[79.5,6.0],[80.5,6.0],[80.5,7.5],[79.5,7.5],[79.5,6.0]]]}`)},
+		{ID: districtID, Code: "11", Name: "Colombo", Level: models.LevelDistrict, ParentID: &provinceID,
+			Geometry: json.RawMessage(`{"type":"Polygon","coordinates":[[[79.8,6.8],[80.2,6.8],[80.2,7.0],[79.8,7.0],[79.8,6.8]]]}`)},
+	}
+	ministries := []models.MinistryWithDepartments{
+		{
+			Ministry: models.Ministry{ID: 1, Name: "Ministry of Home Affairs"},
+			Departments: []models.Department{
+				{ID: 10, Name: "Colombo District Secretariat", MinistryID: 1, AdminAreaID: &districtID},
+				{ID: 11, Name: "Western Provincial Council", MinistryID: 1, AdminAreaID: &provinceID},
+				{ID: 12, Name: "Port Authority", MinistryID: 1, Location: models.Location{
+					Geometry: json.RawMessage(`{"type":"Polygon","coordinates":[[[79.84,6.93],[79.86,6.93],[79.86,6.96],[79.84,6.96],[79.84,6.93]]]}`)}},
+			},
+		},
+	}
+	return areas, ministries
+}
+
+func TestJurisdictionLookup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	areas, ministries := jurisdictionFixtures()
+	areaRepo := mocks.NewMockAdminAreaRepo(ctrl)
+	orgRepo := mocks.NewMockOrganizationRepo(ctrl)
+	areaRepo.EXPECT().GetAreaBoundaries().Return(areas, nil).Times(1)
+	orgRepo.EXPECT().GetMinistriesWithDepartments().Return(ministries, nil).Times(1)
+
+	svc := service.NewJurisdictionService(areaRepo, orgRepo)
+
+	result, err := svc.Lookup(6.95, 79.85)
+	require.NoError(t, err)
+	require.Len(t, result.Areas, 2)
+	assert.Equal(t, "Western", result.Areas[0].Name)
+	assert.Equal(t, "Colombo", result.Areas[1].Name)
+	assert.Nil(t, result.Areas[1].Geometry)
+	assert.Equal(t, 11, result.Areas[0].Departments[0].ID)
+	assert.Equal(t, 10, result.Areas[1].Departments[0].ID)
+	require.Len(t, result.Departments, 1)
+	assert.Equal(t, 12, result.Departments[0].ID)
+
+	// The second lookup is answered from the cached index.
+	result, err = svc.Lookup(7.3, 80.4)
+	require.NoError(t, err)
+	require.Len(t, result.Areas, 1)
+	assert.Equal(t, "Western", result.Areas[0].Name)
+	assert.Empty(t, result.Departments)
+}
+
+func TestJurisdictionLookup_Outside(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	areas, ministries := jurisdictionFixtures()
+	areaRepo := mocks.NewMockAdminAreaRepo(ctrl)
+	orgRepo := mocks.NewMockOrganizationRepo(ctrl)
+	areaRepo.EXPECT().GetAreaBoundaries().Return(areas, nil)
+	orgRepo.EXPECT().GetMinistriesWithDepartments().Return(ministries, nil)
+
+	svc := service.NewJurisdictionService(areaRepo, orgRepo)
+	result, err := svc.Lookup(9.66, 80.01)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Areas)
+	assert.Empty(t, result.Departments)
+}
+
+func TestJurisdictionInvalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	areas, ministries := jurisdictionFixtures()
+	areaRepo := mocks.NewMockAdminAreaRepo(ctrl)
+	orgRepo := mocks.NewMockOrganizationRepo(ctrl)
+	areaRepo.EXPECT().GetAreaBoundaries().Return(areas, nil).Times(2)
+	orgRepo.EXPECT().GetMinistriesWithDepartments().Return(ministries, nil).Times(2)
+
+	svc := service.NewJurisdictionService(areaRepo, orgRepo)
+	_, err := svc.Lookup(6.95, 79.85)
+	require.NoError(t, err)
+
+	svc.Invalidate()
+	_, err = svc.Lookup(6.95, 79.85)
+	require.NoError(t, err)
+}
+
+func TestJurisdictionLookup_AgedIndexServedDuringRebuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	areas, ministries := jurisdictionFixtures()
+	areaRepo := mocks.NewMockAdminAreaRepo(ctrl)
+	orgRepo := mocks.NewMockOrganizationRepo(ctrl)
+	rebuilding := make(chan struct{})
+	release := make(chan struct{})
+	gomock.InOrder(
+		areaRepo.EXPECT().GetAreaBoundaries().Return(areas, nil),
+		areaRepo.EXPECT().GetAreaBoundaries().DoAndReturn(func() ([]models.AdminArea, error) {
+			close(rebuilding)
+			<-release
+			return areas, nil
+		}),
+	)
+	orgRepo.EXPECT().GetMinistriesWithDepartments().Return(ministries, nil).Times(2)
+
+	svc := service.NewJurisdictionService(areaRepo, orgRepo)
+	_, err := svc.Lookup(6.95, 79.85)
+	require.NoError(t, err)
+
+	// Every index is now too old, so the next lookup rebuilds it.
+	svc.MaxAge = 0
+	done := make(chan error)
+	go func() {
+		_, err := svc.Lookup(6.95, 79.85)
+		done <- err
+	}()
+	<-rebuilding
+
+	// Lookups during the rebuild answer from the aged index.
+	result, err := svc.Lookup(6.95, 79.85)
+	require.NoError(t, err)
+	assert.Len(t, result.Areas, 2)
+
+	close(release)
+	require.NoError(t, <-done)
+}
+
+func TestJurisdictionInvalidatedByWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	areas, ministries := jurisdictionFixtures()
+	areaRepo := mocks.NewMockAdminAreaRepo(ctrl)
+	orgRepo := mocks.NewMockOrganizationRepo(ctrl)
+	areaRepo.EXPECT().GetAreaBoundaries().Return(areas, nil).Times(3)
+	orgRepo.EXPECT().GetMinistriesWithDepartments().Return(ministries, nil).Times(3)
+	orgRepo.EXPECT().DeleteDepartment(12).Return(nil)
+	areaRepo.EXPECT().SetDepartmentArea(10, nil).Return(nil)
+
+	svc := service.NewJurisdictionService(areaRepo, orgRepo)
+	orgService := &service.OrganizationService{Repo: orgRepo, Jurisdiction: svc}
+	areaService := &service.AdminAreaService{Repo: areaRepo, Jurisdiction: svc}
+
+	_, err := svc.Lookup(6.95, 79.85)
+	require.NoError(t, err)
+
+	require.NoError(t, orgService.DeleteDepartment(12))
+	_, err = svc.Lookup(6.95, 79.85)
+	require.NoError(t, err)
+
+	require.NoError(t, areaService.SetDepartmentArea(10, nil))
+	_, err = svc.Lookup(6.95, 79.85)
+	require.NoError(t, err)
+}
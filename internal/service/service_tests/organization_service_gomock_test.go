@@ -0,0 +1,346 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository/mocks"
+	"go-mysql-backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetMinistriesWithDepartments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+
+	// Define expected output
+	expected_list := []models.MinistryWithDepartments{
+		{
+			Ministry: models.Ministry{
+				ID:   1,
+				Name: "Ministry of Testing",
+			},
+			Departments: []models.Department{
+				{
+					ID:         101,
+					Name:       "QA Dept",
+					MinistryID: 1,
+				},
+			},
+		},
+	}
+
+	// Setup expectations
+	mockRepo.EXPECT().GetMinistriesWithDepartments().Return(expected_list, nil)
+
+	// Inject mock into service
+	s := service.NewOrganizationService(mockRepo)
+
+	// Run the test
+	result, err := s.GetMinistriesWithDepartments()
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected_list, result)
+}
+
+func TestGetMinistryByIdWithDepartments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+
+	// Define expected output
+	expected_ministry := models.MinistryWithDepartments{
+
+		Ministry: models.Ministry{
+			ID:   1,
+			Name: "Ministry of Testing",
+		},
+		Departments: []models.Department{
+			{
+				ID:         101,
+				Name:       "QA Dept",
+				MinistryID: 1,
+			},
+		},
+	}
+
+	// Setup expectations
+	mockRepo.EXPECT().GetMinistryByIDWithDepartments(1).Return(expected_ministry, nil)
+
+	// Inject mock into service
+	s := service.NewOrganizationService(mockRepo)
+
+	// Run the test
+	result, err := s.GetMinistryByIDWithDepartments(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected_ministry, result)
+}
+
+func TestFindDepartmentsNearby(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+
+	query := models.NearbyQuery{Latitude: 7.2906, Longitude: 80.6337, RadiusKm: 5, Limit: 5}
+	lat, lon := 7.2936, 80.6413
+	expected := []models.NearbyDepartment{
+		{
+			Department: models.Department{
+				ID:         7,
+				Name:       "Kandy District Secretariat",
+				MinistryID: 2,
+				Location:   models.Location{Latitude: &lat, Longitude: &lon},
+			},
+			DistanceKm: 0.9,
+		},
+	}
+
+	mockRepo.EXPECT().FindDepartmentsNearby(query).Return(expected, nil)
+
+	s := service.NewOrganizationService(mockRepo)
+
+	result, err := s.FindDepartmentsNearby(query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestGetOrganizationTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	root := models.OrgRef{Entity: models.EntityDepartment, ID: 1}
+	expected := models.OrgNode{
+		Entity: models.EntityDepartment, ID: 1, Name: "Planning Department", Kind: models.KindDepartment,
+		Children: []models.OrgNode{
+			{Entity: models.EntityDepartment, ID: 11, Name: "Finance Division", Kind: models.KindDivision, Depth: 1, Children: []models.OrgNode{}},
+		},
+	}
+	mockRepo.EXPECT().GetOrganizationTree(root, 1).Return(expected, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	tree, err := svc.GetOrganizationTree(root, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, tree)
+}
+
+func TestAsOf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	datedRepo := mocks.NewMockOrganizationRepo(ctrl)
+	date := models.NewDate(2023, time.July, 20)
+	root := models.OrgRef{Entity: models.EntityMinistry, ID: 4}
+	expected := models.OrgNode{Entity: models.EntityMinistry, ID: 4, Name: "Ministry of Ports", Kind: models.KindMinistry, Children: []models.OrgNode{}}
+
+	mockRepo.EXPECT().AsOf(date).Return(datedRepo)
+	datedRepo.EXPECT().GetOrganizationTree(root, 2).Return(expected, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	tree, err := svc.AsOf(&date).GetOrganizationTree(root, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, tree)
+}
+
+func TestApplyChangeSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	cs := models.ChangeSet{
+		Gazette:       "2367/12",
+		EffectiveDate: models.NewDate(2024, time.January, 15),
+		Operations: []models.ChangeOp{{Type: models.OpSplit, ID: 8, Parts: []models.SplitPart{
+			{Name: "Department of Wildlife"}, {Name: "Department of Forests"},
+		}}},
+	}
+	expected := models.ChangeSetResult{Applied: 1, Operations: []models.ChangeOpResult{
+		{Index: 0, Type: models.OpSplit, Gazette: "2367/12", CreatedIDs: []int{2101, 2102}},
+	}}
+	mockRepo.EXPECT().ApplyChangeSet(cs).Return(expected, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	result, err := svc.ApplyChangeSet(cs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestGetDepartmentOffices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	expected := []models.Office{
+		{ID: 1, DepartmentID: 4, Name: "Head Office"},
+		{ID: 2, DepartmentID: 4, Name: "Kandy Regional Office", PhoneNumbers: []string{"081 222 3344"}},
+	}
+	mockRepo.EXPECT().GetDepartmentOffices(4).Return(expected, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	offices, err := svc.GetDepartmentOffices(4, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, offices)
+}
+
+func TestFindOfficesNearby_OpenAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	weekdays := []models.OpeningHours{
+		{Day: models.Weekday(time.Monday), Opens: 8*60 + 30, Closes: 16*60 + 15},
+	}
+	nightShift := []models.OpeningHours{
+		{Day: models.Weekday(time.Monday), Opens: 20 * 60, Closes: 6 * 60},
+	}
+	q := models.NearbyQuery{Latitude: 6.93, Longitude: 79.85, RadiusKm: 10, Limit: 1}
+	// Filtering reads more than the limit so the page can still be filled.
+	widened := q
+	widened.Limit = 1000
+	mockRepo.EXPECT().FindOfficesNearby(widened).Return([]models.NearbyOffice{
+		{Office: models.Office{ID: 1, Name: "Colombo Office", Hours: weekdays}, DistanceKm: 0.5},
+		{Office: models.Office{ID: 2, Name: "No hours"}, DistanceKm: 0.8},
+		{Office: models.Office{ID: 3, Name: "Night Office", Hours: nightShift}, DistanceKm: 1.2},
+		{Office: models.Office{ID: 4, Name: "Second Night Office", Hours: nightShift}, DistanceKm: 2.0},
+	}, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	// 2024-03-04 22:00 in Colombo, a Monday night.
+	openAt := time.Date(2024, time.March, 4, 22, 0, 0, 0, models.CivilZone)
+	offices, err := svc.FindOfficesNearby(q, &openAt)
+
+	assert.NoError(t, err)
+	if assert.Len(t, offices, 1) {
+		assert.Equal(t, 3, offices[0].ID)
+		assert.NotNil(t, offices[0].OpenNow)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	q := models.SearchQuery{Text: "kandy", Entities: []models.SearchEntity{models.SearchOffice}, Limit: 5}
+	mockRepo.EXPECT().Search(q).Return([]models.SearchHit{
+		{Entity: models.SearchOffice, ID: 2, Name: "Kandy Regional Office", Snippet: "<mark>Kandy</mark> Regional Office"},
+	}, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	result, err := svc.Search(q)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "kandy", result.Query)
+	assert.Len(t, result.Hits, 1)
+}
+
+func TestGetMinistriesPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	p := models.PageRequest{Limit: 1, Cursor: &models.Cursor{ID: 4}}
+	mockRepo.EXPECT().GetMinistriesPage(p).Return(models.MinistryPage{
+		Items: []models.MinistryWithDepartments{{Ministry: models.Ministry{ID: 7, Name: "Ministry of Health"}}},
+		Total: 3, Limit: 1, Offset: 2,
+	}, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	page, err := svc.GetMinistriesPage(p)
+
+	assert.NoError(t, err)
+	assert.Empty(t, page.NextCursor)
+	assert.Equal(t, models.Cursor{ID: 7, Before: true}.Encode(), page.PrevCursor)
+}
+
+func TestGetDepartmentsPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	p := models.PageRequest{Limit: 2}
+	mockRepo.EXPECT().GetDepartmentsPage(p).Return(models.DepartmentPage{
+		Items: []models.Department{{ID: 1, Name: "Department of Census", MinistryID: 1}, {ID: 3, Name: "Department of Fisheries", MinistryID: 2}},
+		Total: 5, Limit: 2,
+	}, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	page, err := svc.GetDepartmentsPage(p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.Cursor{ID: 3}.Encode(), page.NextCursor)
+	assert.Empty(t, page.PrevCursor)
+}
+
+func TestGetDepartmentsInViewport_Truncated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	bbox := models.BBox{MinLon: 79.8, MinLat: 6.8, MaxLon: 80.0, MaxLat: 7.0}
+	departments := []models.Department{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}, {ID: 3, Name: "C"}}
+	mockRepo.EXPECT().GetDepartmentsInBBox(bbox, 3).Return(departments, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	viewport, err := svc.GetDepartmentsInViewport(bbox, 2)
+
+	assert.NoError(t, err)
+	assert.True(t, viewport.Truncated)
+	assert.Equal(t, departments[:2], viewport.Departments)
+}
+
+func TestCreateMinistry_UpdatesAutocomplete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	mockRepo.EXPECT().GetMinistriesWithDepartments().Return([]models.MinistryWithDepartments{}, nil)
+	ministry := models.Ministry{Name: "Ministry of Health"}
+	mockRepo.EXPECT().CreateMinistry(ministry).Return(12, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	assert.NoError(t, svc.LoadAutocomplete())
+	id, err := svc.CreateMinistry(ministry)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12, id)
+	result := svc.Autocomplete(models.AutocompleteQuery{Text: "heal", Entities: models.AutocompleteEntities, Limit: 10})
+	if assert.Len(t, result.Suggestions, 1) {
+		assert.Equal(t, 12, result.Suggestions[0].ID)
+	}
+}
+
+func TestDeleteMinistry_Reassign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	mockRepo.EXPECT().DeleteMinistry(4, models.DeleteReassign, 2).Return(nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	assert.NoError(t, svc.DeleteMinistry(4, models.DeleteReassign, 2))
+}
+
+func TestSeedDummyData_Unsupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrganizationRepo(ctrl)
+	svc := service.NewOrganizationService(mockRepo)
+
+	assert.ErrorIs(t, svc.SeedDummyData(), service.ErrSeedUnsupported)
+}
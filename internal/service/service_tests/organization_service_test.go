@@ -0,0 +1,749 @@
+package service_test
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"go-mysql-backend/internal/importer"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+	"go-mysql-backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrganizationRepo is a mock implementation of the OrganizationRepo interface
+type MockOrganizationRepo struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRepo) GetMinistriesWithDepartments() ([]models.MinistryWithDepartments, error) {
+	args := m.Called()
+	return args.Get(0).([]models.MinistryWithDepartments), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) GetMinistriesPage(p models.PageRequest) (models.MinistryPage, error) {
+	args := m.Called(p)
+	return args.Get(0).(models.MinistryPage), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) GetAllDepartments() ([]models.Department, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Department), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) GetDepartmentsPage(p models.PageRequest) (models.DepartmentPage, error) {
+	args := m.Called(p)
+	return args.Get(0).(models.DepartmentPage), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) CreateMinistry(ministry models.Ministry) (int, error) {
+	args := m.Called(ministry)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) CreateDepartment(dept models.Department) (int, error) {
+	args := m.Called(dept)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) GetMinistryByID(id int) (models.Ministry, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Ministry), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) GetMinistryByIDWithDepartments(id int) (models.MinistryWithDepartments, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.MinistryWithDepartments), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) GetDepartmentByID(id int) (*models.Department, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Department), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) UpdateMinistry(ministry models.Ministry) error {
+	args := m.Called(ministry)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepo) UpdateDepartment(dept models.Department) error {
+	args := m.Called(dept)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepo) DeleteMinistry(id int, mode models.MinistryDeleteMode, reassignTo int) error {
+	args := m.Called(id, mode, reassignTo)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepo) DeleteDepartment(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepo) FindDepartmentsNearby(q models.NearbyQuery) ([]models.NearbyDepartment, error) {
+	args := m.Called(q)
+	return args.Get(0).([]models.NearbyDepartment), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) GetDepartmentsInBBox(bbox models.BBox, limit int) ([]models.Department, error) {
+	args := m.Called(bbox, limit)
+	return args.Get(0).([]models.Department), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) GetMinistriesInBBox(bbox models.BBox, limit int) ([]models.Ministry, error) {
+	args := m.Called(bbox, limit)
+	return args.Get(0).([]models.Ministry), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) GetOrganizationTree(root models.OrgRef, depth int) (models.OrgNode, error) {
+	args := m.Called(root, depth)
+	return args.Get(0).(models.OrgNode), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) ApplyChangeSet(cs models.ChangeSet) (models.ChangeSetResult, error) {
+	args := m.Called(cs)
+	return args.Get(0).(models.ChangeSetResult), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) Import(plan models.ImportPlan) (models.ImportResult, error) {
+	args := m.Called(plan)
+	return args.Get(0).(models.ImportResult), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) GetDepartmentOffices(departmentID int) ([]models.Office, error) {
+	args := m.Called(departmentID)
+	return args.Get(0).([]models.Office), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) GetOfficeByID(id int) (*models.Office, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Office), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) CreateOffice(office models.Office) (int, error) {
+	args := m.Called(office)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) UpdateOffice(office models.Office) error {
+	args := m.Called(office)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepo) DeleteOffice(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepo) FindOfficesNearby(q models.NearbyQuery) ([]models.NearbyOffice, error) {
+	args := m.Called(q)
+	return args.Get(0).([]models.NearbyOffice), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) Search(q models.SearchQuery) ([]models.SearchHit, error) {
+	args := m.Called(q)
+	return args.Get(0).([]models.SearchHit), args.Error(1)
+}
+
+func (m *MockOrganizationRepo) AsOf(date models.Date) repository.OrganizationRepo {
+	args := m.Called(date)
+	return args.Get(0).(repository.OrganizationRepo)
+}
+
+func TestPostgresGetMinistriesWithDepartments(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	expectedMinistries := []models.MinistryWithDepartments{
+		{
+			Ministry: models.Ministry{
+				ID:                1,
+				Name:              "Ministry of Education",
+				Google_map_script: "<script>map1</script>",
+			},
+			Departments: []models.Department{
+				{
+					ID:                1,
+					Name:              "Primary Education",
+					MinistryID:        1,
+					Google_map_script: "<script>map2</script>",
+				},
+			},
+		},
+	}
+
+	mockRepo.On("GetMinistriesWithDepartments").Return(expectedMinistries, nil)
+
+	result, err := service.GetMinistriesWithDepartments()
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMinistries, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetMinistriesPage(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	p := models.PageRequest{Limit: 2, Offset: 2}
+	items := []models.MinistryWithDepartments{
+		{Ministry: models.Ministry{ID: 3, Name: "Ministry of Education"},
+			Departments: []models.Department{{ID: 1, Name: "Primary Education", MinistryID: 3}}},
+		{Ministry: models.Ministry{ID: 5, Name: "Ministry of Health"}},
+	}
+	mockRepo.On("GetMinistriesPage", p).Return(models.MinistryPage{Items: items, Total: 6, Limit: 2, Offset: 2}, nil)
+
+	page, err := service.GetMinistriesPage(p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, items, page.Items)
+	assert.Equal(t, models.Cursor{ID: 5}.Encode(), page.NextCursor)
+	assert.Equal(t, models.Cursor{ID: 3, Before: true}.Encode(), page.PrevCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetDepartmentsPageAtEnds(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	p := models.PageRequest{Limit: 10}
+	items := []models.Department{{ID: 1, Name: "Primary Education", MinistryID: 3}}
+	mockRepo.On("GetDepartmentsPage", p).Return(models.DepartmentPage{Items: items, Total: 1, Limit: 10}, nil)
+
+	page, err := service.GetDepartmentsPage(p)
+
+	assert.NoError(t, err)
+	assert.Empty(t, page.NextCursor)
+	assert.Empty(t, page.PrevCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresCreateMinistry(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	ministry := models.Ministry{
+		Name:              "New Ministry",
+		Google_map_script: "<script>map</script>",
+	}
+	expectedID := 1
+
+	mockRepo.On("CreateMinistry", ministry).Return(expectedID, nil)
+
+	id, err := service.CreateMinistry(ministry)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedID, id)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresCreateDepartment(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	department := models.Department{
+		Name:              "New Department",
+		MinistryID:        1,
+		Google_map_script: "<script>map</script>",
+	}
+	expectedID := 1
+
+	mockRepo.On("CreateDepartment", department).Return(expectedID, nil)
+
+	id, err := service.CreateDepartment(department)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedID, id)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetMinistryByID(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	expectedMinistry := models.Ministry{
+		ID:                1,
+		Name:              "Ministry of Education",
+		Google_map_script: "<script>map</script>",
+	}
+
+	mockRepo.On("GetMinistryByID", 1).Return(expectedMinistry, nil)
+
+	result, err := service.GetMinistryByID(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMinistry, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetMinistryByID_NotFound(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	mockRepo.On("GetMinistryByID", 999).Return(models.Ministry{}, sql.ErrNoRows)
+
+	result, err := service.GetMinistryByID(999)
+
+	assert.Error(t, err)
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Equal(t, models.Ministry{}, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetMinistryByIDWithDepartments(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	expectedMinistry := models.MinistryWithDepartments{
+		Ministry: models.Ministry{
+			ID:                1,
+			Name:              "Ministry of Education",
+			Google_map_script: "<script>map1</script>",
+		},
+		Departments: []models.Department{
+			{
+				ID:                1,
+				Name:              "Primary Education",
+				MinistryID:        1,
+				Google_map_script: "<script>map2</script>",
+			},
+		},
+	}
+
+	mockRepo.On("GetMinistryByIDWithDepartments", 1).Return(expectedMinistry, nil)
+
+	result, err := service.GetMinistryByIDWithDepartments(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMinistry, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetDepartmentByID(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	expectedDepartment := &models.Department{
+		ID:                1,
+		Name:              "Primary Education",
+		MinistryID:        1,
+		Google_map_script: "<script>map</script>",
+	}
+
+	mockRepo.On("GetDepartmentByID", 1).Return(expectedDepartment, nil)
+
+	result, err := service.GetDepartmentByID(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDepartment, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetDepartmentByID_NotFound(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	mockRepo.On("GetDepartmentByID", 999).Return(nil, nil)
+
+	result, err := service.GetDepartmentByID(999)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetAllDepartments(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	expectedDepartments := []models.Department{
+		{
+			ID:                1,
+			Name:              "Primary Education",
+			MinistryID:        1,
+			Google_map_script: "<script>map1</script>",
+		},
+		{
+			ID:                2,
+			Name:              "Secondary Education",
+			MinistryID:        1,
+			Google_map_script: "<script>map2</script>",
+		},
+	}
+
+	mockRepo.On("GetAllDepartments").Return(expectedDepartments, nil)
+
+	result, err := service.GetAllDepartments()
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDepartments, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresUpdateMinistry(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	ministry := models.Ministry{
+		ID:   1,
+		Name: "Ministry of Health",
+	}
+
+	mockRepo.On("UpdateMinistry", ministry).Return(nil)
+
+	err := service.UpdateMinistry(ministry)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresUpdateDepartment_NotFound(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	department := models.Department{
+		ID:         999,
+		Name:       "Missing Department",
+		MinistryID: 1,
+	}
+
+	mockRepo.On("UpdateDepartment", department).Return(repository.ErrNotFound)
+
+	err := service.UpdateDepartment(department)
+
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresDeleteMinistry_RejectsWhenDepartmentsRemain(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	mockRepo.On("DeleteMinistry", 1, models.DeleteReject, 0).Return(repository.ErrMinistryHasDepartments)
+
+	err := service.DeleteMinistry(1, models.DeleteReject, 0)
+
+	assert.ErrorIs(t, err, repository.ErrMinistryHasDepartments)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresDeleteMinistry_Reassign(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	mockRepo.On("DeleteMinistry", 1, models.DeleteReassign, 2).Return(nil)
+
+	err := service.DeleteMinistry(1, models.DeleteReassign, 2)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresDeleteDepartment(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	mockRepo.On("DeleteDepartment", 5).Return(nil)
+
+	err := service.DeleteDepartment(5)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresFindDepartmentsNearby(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	// Searching from Colombo Fort: Colombo 01 is ~2.2 km away, Kandy ~94 km.
+	query := models.NearbyQuery{Latitude: 6.9344, Longitude: 79.8428, RadiusKm: 100, Limit: 10}
+	colomboLat, colomboLon := 6.9271, 79.8612
+	kandyLat, kandyLon := 7.2906, 80.6337
+	expected := []models.NearbyDepartment{
+		{
+			Department: models.Department{ID: 1, Name: "Colombo Office", MinistryID: 1,
+				Location: models.Location{Latitude: &colomboLat, Longitude: &colomboLon}},
+			DistanceKm: 2.19,
+		},
+		{
+			Department: models.Department{ID: 2, Name: "Kandy Office", MinistryID: 1,
+				Location: models.Location{Latitude: &kandyLat, Longitude: &kandyLon}},
+			DistanceKm: 94.0,
+		},
+	}
+
+	mockRepo.On("FindDepartmentsNearby", query).Return(expected, nil)
+
+	result, err := service.FindDepartmentsNearby(query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetDepartmentsInViewport_Truncated(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	bbox := models.BBox{MinLon: 79.8, MinLat: 6.8, MaxLon: 80.0, MaxLat: 7.0}
+	departments := []models.Department{
+		{ID: 1, Name: "A", MinistryID: 1},
+		{ID: 2, Name: "B", MinistryID: 1},
+		{ID: 3, Name: "C", MinistryID: 1},
+	}
+
+	// The service asks for one extra row to detect truncation.
+	mockRepo.On("GetDepartmentsInBBox", bbox, 3).Return(departments, nil)
+
+	result, err := service.GetDepartmentsInViewport(bbox, 2)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Truncated)
+	assert.Equal(t, 2, result.Count)
+	assert.Equal(t, 2, result.Limit)
+	assert.Equal(t, departments[:2], result.Departments)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetMinistriesInViewport(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	service := service.NewOrganizationService(mockRepo)
+
+	bbox := models.BBox{MinLon: 79.8, MinLat: 6.8, MaxLon: 80.0, MaxLat: 7.0}
+	ministries := []models.Ministry{{ID: 1, Name: "Ministry of Health"}}
+
+	mockRepo.On("GetMinistriesInBBox", bbox, 11).Return(ministries, nil)
+
+	result, err := service.GetMinistriesInViewport(bbox, 10)
+
+	assert.NoError(t, err)
+	assert.False(t, result.Truncated)
+	assert.Equal(t, 1, result.Count)
+	assert.Equal(t, ministries, result.Ministries)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetOrganizationTree(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	root := models.OrgRef{Entity: models.EntityMinistry, ID: 1}
+	expected := models.OrgNode{
+		Entity: models.EntityMinistry, ID: 1, Name: "Ministry of Finance", Kind: models.KindMinistry,
+		Children: []models.OrgNode{
+			{Entity: models.EntityMinistry, ID: 7, Name: "State Ministry of Finance", Kind: models.KindStateMinistry, Depth: 1, Children: []models.OrgNode{}},
+			{Entity: models.EntityDepartment, ID: 3, Name: "Department of Treasury", Kind: models.KindDepartment, Depth: 1, Children: []models.OrgNode{
+				{Entity: models.EntityDepartment, ID: 9, Name: "Budget Division", Kind: models.KindDivision, Depth: 2, Children: []models.OrgNode{}},
+			}},
+		},
+	}
+	mockRepo.On("GetOrganizationTree", root, 2).Return(expected, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	tree, err := svc.GetOrganizationTree(root, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, tree)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresUpdateDepartment_InvalidParent(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	parentID := 4
+	dept := models.Department{ID: 4, Name: "Budget Division", MinistryID: 1, ParentID: &parentID}
+	mockRepo.On("UpdateDepartment", dept).Return(repository.ErrInvalidParent)
+
+	svc := service.NewOrganizationService(mockRepo)
+	err := svc.UpdateDepartment(dept)
+
+	assert.ErrorIs(t, err, repository.ErrInvalidParent)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresAsOf(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	datedRepo := new(MockOrganizationRepo)
+	date := models.NewDate(2024, time.March, 1)
+	expected := []models.Department{{ID: 1, Name: "Department of Fisheries", MinistryID: 3}}
+
+	mockRepo.On("AsOf", date).Return(datedRepo)
+	datedRepo.On("GetAllDepartments").Return(expected, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	departments, err := svc.AsOf(&date).GetAllDepartments()
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, departments)
+	mockRepo.AssertExpectations(t)
+	datedRepo.AssertExpectations(t)
+}
+
+func TestPostgresAsOfNilIsCurrent(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	svc := service.NewOrganizationService(mockRepo)
+
+	assert.Same(t, svc, svc.AsOf(nil))
+	mockRepo.AssertNotCalled(t, "AsOf", mock.Anything)
+}
+
+func TestPostgresApplyChangeSet(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	cs := models.ChangeSet{
+		Gazette:       "2367/12",
+		EffectiveDate: models.NewDate(2024, time.January, 15),
+		Operations:    []models.ChangeOp{{Type: models.OpTransfer, ID: 9, ToMinistryID: 2}},
+	}
+	opErr := &models.ChangeOpError{Index: 0, Type: models.OpTransfer, Err: repository.ErrInvalidReference}
+	mockRepo.On("ApplyChangeSet", cs).Return(models.ChangeSetResult{}, opErr)
+
+	svc := service.NewOrganizationService(mockRepo)
+	_, err := svc.ApplyChangeSet(cs)
+
+	assert.ErrorIs(t, err, repository.ErrInvalidReference)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresImport(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	mockRepo.On("GetMinistriesWithDepartments").Return([]models.MinistryWithDepartments{}, nil)
+	mockRepo.On("Import", mock.Anything).Return(models.ImportResult{MinistryIDs: []int{3}}, nil).Once()
+
+	batch, err := importer.Parse(strings.NewReader(`{"ministries": [{"name": "Ministry of Ports"}]}`), importer.JSON)
+	assert.NoError(t, err)
+
+	svc := service.NewOrganizationService(mockRepo)
+	report, err := svc.Import(batch, importer.Options{DryRun: true})
+	assert.NoError(t, err)
+	assert.Nil(t, report.Created)
+	mockRepo.AssertNotCalled(t, "Import", mock.Anything)
+
+	report, err = svc.Import(batch, importer.Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3}, report.Created.MinistryIDs)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetDepartmentByID_WithOffices(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	svc := service.NewOrganizationService(mockRepo)
+
+	expected := &models.Department{
+		ID:         4,
+		Name:       "Department of Immigration and Emigration",
+		MinistryID: 2,
+		Offices: []models.Office{
+			{ID: 1, DepartmentID: 4, Name: "Head Office", PhoneNumbers: []string{"+94 11 532 9000"}},
+			{ID: 2, DepartmentID: 4, Name: "Kandy Regional Office", Email: "kandy@immigration.gov.lk"},
+		},
+	}
+	mockRepo.On("GetDepartmentByID", 4).Return(expected, nil)
+
+	result, err := svc.GetDepartmentByID(4)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Offices, 2)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresCreateOffice_UnknownDepartment(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	office := models.Office{DepartmentID: 999, Name: "Galle Office"}
+	mockRepo.On("CreateOffice", office).Return(0, repository.ErrInvalidReference)
+
+	svc := service.NewOrganizationService(mockRepo)
+	_, err := svc.CreateOffice(office)
+
+	assert.ErrorIs(t, err, repository.ErrInvalidReference)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresDeleteOffice_NotFound(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	mockRepo.On("DeleteOffice", 42).Return(repository.ErrNotFound)
+
+	svc := service.NewOrganizationService(mockRepo)
+
+	assert.ErrorIs(t, svc.DeleteOffice(42), repository.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresGetDepartmentOffices_OpenAt(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	hours := []models.OpeningHours{{Day: models.Weekday(time.Monday), Opens: 8*60 + 30, Closes: 16*60 + 15}}
+	mockRepo.On("GetDepartmentOffices", 4).Return([]models.Office{
+		{ID: 1, DepartmentID: 4, Name: "Head Office", Hours: hours},
+		{ID: 2, DepartmentID: 4, Name: "Kandy Regional Office", Hours: hours,
+			Closures: []models.Closure{{Date: models.NewDate(2024, time.March, 25), Reason: "Poya"}}},
+	}, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	// Monday 2024-03-25 10:00 in Colombo, a Poya day for the Kandy office.
+	openAt := time.Date(2024, time.March, 25, 4, 30, 0, 0, time.UTC)
+	offices, err := svc.GetDepartmentOffices(4, &openAt)
+
+	assert.NoError(t, err)
+	if assert.Len(t, offices, 1) {
+		assert.Equal(t, "Head Office", offices[0].Name)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresSearch(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	q := models.SearchQuery{Text: "motor traffic", Entities: models.SearchEntities, Limit: 20}
+	hits := []models.SearchHit{
+		{Entity: models.SearchDepartment, ID: 7, Name: "Department of Motor Traffic", Score: 0.6,
+			Snippet: "Department of <mark>Motor</mark> <mark>Traffic</mark>"},
+	}
+	mockRepo.On("Search", q).Return(hits, nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	result, err := svc.Search(q)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "motor traffic", result.Query)
+	assert.Contains(t, result.Transliterations, models.LocaleSinhala)
+	assert.Equal(t, hits, result.Hits)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPostgresAutocomplete(t *testing.T) {
+	mockRepo := new(MockOrganizationRepo)
+	mockRepo.On("GetMinistriesWithDepartments").Return([]models.MinistryWithDepartments{
+		{
+			Ministry:    models.Ministry{ID: 1, Name: "Ministry of Transport"},
+			Departments: []models.Department{{ID: 7, Name: "Department of Motor Traffic", MinistryID: 1}},
+		},
+	}, nil).Once()
+	mockRepo.On("CreateMinistry", mock.Anything).Return(2, nil)
+	mockRepo.On("DeleteDepartment", 7).Return(nil)
+
+	svc := service.NewOrganizationService(mockRepo)
+	q := models.AutocompleteQuery{Text: "mo", Entities: models.AutocompleteEntities, Limit: 10}
+	assert.Empty(t, svc.Autocomplete(q).Suggestions)
+
+	assert.NoError(t, svc.LoadAutocomplete())
+	result := svc.Autocomplete(q)
+	assert.Equal(t, "mo", result.Query)
+	assert.Len(t, result.Suggestions, 1)
+	assert.Equal(t, 7, result.Suggestions[0].ID)
+	assert.Equal(t, 1, *result.Suggestions[0].ParentID)
+
+	// Writes keep the index current without reading it back.
+	_, err := svc.CreateMinistry(models.Ministry{Name: "Ministry of Mobility"})
+	assert.NoError(t, err)
+	assert.NoError(t, svc.DeleteDepartment(7))
+	result = svc.Autocomplete(q)
+	assert.Len(t, result.Suggestions, 1)
+	assert.Equal(t, 2, result.Suggestions[0].ID)
+	mockRepo.AssertExpectations(t)
+}
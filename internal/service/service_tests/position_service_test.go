@@ -0,0 +1,76 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+	"go-mysql-backend/internal/repository/mocks"
+	"go-mysql-backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPerson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPositionRepo(ctrl)
+	person := &models.Person{ID: 7, Name: "Anura Perera", Title: "Hon."}
+	positions := []models.Position{
+		{
+			ID: 1, PersonID: 7, PersonName: "Anura Perera", Role: models.RoleStateMinister,
+			Entity: models.EntityMinistry, OrganizationID: 201, OrganizationName: "State Ministry of Ports",
+			StartDate: models.NewDate(2019, time.November, 22), EndDate: models.NewDate(2020, time.August, 12),
+		},
+		{
+			ID: 4, PersonID: 7, PersonName: "Anura Perera", Role: models.RoleMinister,
+			Entity: models.EntityMinistry, OrganizationID: 3, OrganizationName: "Ministry of Ports and Shipping",
+			StartDate: models.NewDate(2020, time.August, 12),
+		},
+	}
+	mockRepo.EXPECT().GetPersonByID(7).Return(person, nil)
+	mockRepo.EXPECT().GetPersonPositions(7).Return(positions, nil)
+
+	svc := service.NewPositionService(mockRepo)
+	result, err := svc.GetPerson(7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, *person, result.Person)
+	assert.Equal(t, positions, result.Positions)
+}
+
+func TestGetPerson_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPositionRepo(ctrl)
+	mockRepo.EXPECT().GetPersonByID(99).Return(nil, repository.ErrNotFound)
+
+	svc := service.NewPositionService(mockRepo)
+	_, err := svc.GetPerson(99)
+
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestGetOfficeHolders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPositionRepo(ctrl)
+	org := models.OrgRef{Entity: models.EntityDepartment, ID: 12}
+	date := models.NewDate(2023, time.May, 1)
+	expected := []models.Position{{
+		ID: 9, PersonID: 3, PersonName: "K. Silva", Role: models.RoleHead,
+		Entity: models.EntityDepartment, OrganizationID: 12, StartDate: models.NewDate(2021, time.January, 4),
+	}}
+	mockRepo.EXPECT().GetOfficeHolders(org, models.RoleHead, date).Return(expected, nil)
+
+	svc := service.NewPositionService(mockRepo)
+	holders, err := svc.GetOfficeHolders(org, models.RoleHead, date)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, holders)
+}
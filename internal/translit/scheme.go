@@ -0,0 +1,168 @@
+package translit
+
+import (
+	"strings"
+	"unicode"
+)
+
+// scheme is a romanization scheme for one script. Consonants and vowels
+// are tried longest first.
+type scheme struct {
+	consonants []consonant
+	vowels     []vowel
+	virama     string
+	// joinBefore lists the consonants written as a joined form after a
+	// virama, such as the Sinhala yansaya in ්‍ය.
+	joinBefore map[string]bool
+	// initial replaces a consonant at the start of a word.
+	initial map[string]string
+}
+
+type consonant struct {
+	latin  string
+	native string
+}
+
+type vowel struct {
+	latin       string
+	independent string
+	sign        string
+}
+
+// sinhalaScheme follows common Singlish: th and dh are the dental ත and
+// ද, t and d the retroflex ට and ඩ, and ee and oo the long ඊ and ඌ.
+var sinhalaScheme = scheme{
+	consonants: []consonant{
+		{"chh", "ඡ"},
+		{"kh", "ඛ"}, {"gh", "ඝ"}, {"ch", "ච"}, {"jh", "ඣ"}, {"ny", "ඤ"},
+		{"th", "ත"}, {"dh", "ද"}, {"ph", "ඵ"}, {"bh", "භ"}, {"sh", "ශ"},
+		{"k", "ක"}, {"g", "ග"}, {"c", "ච"}, {"j", "ජ"}, {"t", "ට"}, {"d", "ඩ"},
+		{"n", "න"}, {"p", "ප"}, {"b", "බ"}, {"m", "ම"}, {"y", "ය"}, {"r", "ර"},
+		{"l", "ල"}, {"v", "ව"}, {"w", "ව"}, {"s", "ස"}, {"h", "හ"}, {"f", "ෆ"},
+		{"q", "ක"}, {"z", "ස"},
+	},
+	vowels: []vowel{
+		{"aee", "ඈ", "ෑ"},
+		{"ae", "ඇ", "ැ"}, {"aa", "ආ", "ා"}, {"ai", "ඓ", "ෛ"}, {"au", "ඖ", "ෞ"},
+		{"ee", "ඊ", "ී"}, {"ii", "ඊ", "ී"}, {"oo", "ඌ", "ූ"}, {"uu", "ඌ", "ූ"},
+		{"a", "අ", ""}, {"i", "ඉ", "ි"}, {"u", "උ", "ු"}, {"e", "එ", "ෙ"}, {"o", "ඔ", "ො"},
+	},
+	virama:     "්",
+	joinBefore: map[string]bool{"y": true, "r": true},
+}
+
+// tamilScheme follows common Tanglish. Tamil does not tell voiced from
+// voiceless stops, so g, d and b share a letter with k, t and p; s is
+// the native ச and n is ந at the start of a word and ன elsewhere.
+var tamilScheme = scheme{
+	consonants: []consonant{
+		{"ng", "ங"}, {"nj", "ஞ"}, {"ch", "ச"}, {"sh", "ஷ"}, {"zh", "ழ"},
+		{"th", "த"}, {"dh", "த"},
+		{"k", "க"}, {"g", "க"}, {"c", "ச"}, {"s", "ச"}, {"j", "ஜ"}, {"t", "ட"}, {"d", "ட"},
+		{"n", "ன"}, {"p", "ப"}, {"b", "ப"}, {"m", "ம"}, {"y", "ய"}, {"r", "ர"},
+		{"l", "ல"}, {"v", "வ"}, {"w", "வ"}, {"h", "ஹ"}, {"f", "ப"}, {"q", "க"}, {"z", "ஸ"},
+	},
+	vowels: []vowel{
+		{"aa", "ஆ", "ா"}, {"ai", "ஐ", "ை"}, {"au", "ஔ", "ௌ"},
+		{"ee", "ஈ", "ீ"}, {"ii", "ஈ", "ீ"}, {"oo", "ஊ", "ூ"}, {"uu", "ஊ", "ூ"},
+		{"a", "அ", ""}, {"i", "இ", "ி"}, {"u", "உ", "ு"}, {"e", "எ", "ெ"}, {"o", "ஒ", "ொ"},
+	},
+	virama:  "்",
+	initial: map[string]string{"n": "ந"},
+}
+
+// transliterate writes every romanized word of s in the script of sc and
+// returns the result in NFC.
+func transliterate(s string, sc scheme) string {
+	var b strings.Builder
+	for _, field := range splitWords(s) {
+		if !IsRomanized(field) {
+			b.WriteString(field)
+			continue
+		}
+		b.WriteString(sc.word(strings.ToLower(field)))
+	}
+	return Normalize(b.String())
+}
+
+// word transliterates one lower-case romanized word.
+func (sc scheme) word(w string) string {
+	var b strings.Builder
+	afterConsonant := false
+	for i := 0; i < len(w); {
+		if v, ok := sc.matchVowel(w[i:]); ok {
+			if afterConsonant {
+				b.WriteString(v.sign)
+			} else {
+				b.WriteString(v.independent)
+			}
+			afterConsonant = false
+			i += len(v.latin)
+			continue
+		}
+		if c, ok := sc.matchConsonant(w[i:]); ok {
+			native := c.native
+			if i == 0 && sc.initial[c.latin] != "" {
+				native = sc.initial[c.latin]
+			}
+			if afterConsonant {
+				b.WriteString(sc.virama)
+				if sc.joinBefore[c.latin] {
+					b.WriteRune(zwj)
+				}
+			}
+			b.WriteString(native)
+			afterConsonant = true
+			i += len(c.latin)
+			continue
+		}
+		if afterConsonant {
+			b.WriteString(sc.virama)
+			afterConsonant = false
+		}
+		b.WriteByte(w[i])
+		i++
+	}
+	if afterConsonant {
+		b.WriteString(sc.virama)
+	}
+	return b.String()
+}
+
+func (sc scheme) matchVowel(s string) (vowel, bool) {
+	for _, v := range sc.vowels {
+		if strings.HasPrefix(s, v.latin) {
+			return v, true
+		}
+	}
+	return vowel{}, false
+}
+
+func (sc scheme) matchConsonant(s string) (consonant, bool) {
+	for _, c := range sc.consonants {
+		if strings.HasPrefix(s, c.latin) {
+			return c, true
+		}
+	}
+	return consonant{}, false
+}
+
+// splitWords splits s into runs of letters and digits and the runs of
+// other characters between them, so joining the parts gives s back.
+func splitWords(s string) []string {
+	var parts []string
+	start := 0
+	inWord := false
+	for i, r := range s {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == zwj
+		if i > 0 && isWord != inWord {
+			parts = append(parts, s[start:i])
+			start = i
+		}
+		inWord = isWord
+	}
+	if start < len(s) {
+		parts = append(parts, s[start:])
+	}
+	return parts
+}
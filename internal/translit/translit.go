@@ -0,0 +1,186 @@
+// Package translit maps romanized Sinhala and Tamil ("Singlish" and
+// "Tanglish") to the native scripts and derives phonetic keys that let a
+// romanized query match a name written in Sinhala or Tamil script.
+//
+// Romanization is loose in practice: the same Sinhala word is typed as
+// "amathyanshaya", "amatyansaya" or "amaathyaanshaya". Rather than guess
+// one spelling, Key reduces both the query and the stored names to a
+// common Latin skeleton that ignores aspiration, voicing, vowel length and
+// doubled letters, and matching is done on keys.
+package translit
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	zwj  = '\u200d'
+	zwnj = '\u200c'
+)
+
+// Normalize returns s in Unicode NFC with zero-width non-joiners removed,
+// so that vowel signs typed as two code points compare equal to their
+// precomposed form. Zero-width joiners are kept: Sinhala needs them for
+// conjuncts such as ්‍ය.
+func Normalize(s string) string {
+	return norm.NFC.String(strings.ReplaceAll(s, string(zwnj), ""))
+}
+
+// IsRomanized reports whether s has Latin letters and no Sinhala or Tamil.
+func IsRomanized(s string) bool {
+	latin := false
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Sinhala, r), unicode.Is(unicode.Tamil, r):
+			return false
+		case unicode.Is(unicode.Latin, r):
+			latin = true
+		}
+	}
+	return latin
+}
+
+// Key returns the phonetic key of s: each word of s, in any of the three
+// scripts, romanized and folded to the skeleton described in the package
+// comment, separated by spaces. Text in other scripts is dropped.
+func Key(s string) string {
+	return strings.Join(Keys(s), " ")
+}
+
+// Keys returns the words of Key(s).
+func Keys(s string) []string {
+	var keys []string
+	for _, word := range strings.Fields(romanize(Normalize(s))) {
+		if key := fold(word); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ToSinhala transliterates romanized text to Sinhala script. Words that
+// are not romanized are kept as they are.
+func ToSinhala(s string) string {
+	return transliterate(s, sinhalaScheme)
+}
+
+// ToTamil transliterates romanized text to Tamil script. Words that are
+// not romanized are kept as they are.
+func ToTamil(s string) string {
+	return transliterate(s, tamilScheme)
+}
+
+// fold reduces a romanized word to its key, keeping only ASCII letters and
+// digits.
+func fold(word string) string {
+	word = strings.ToLower(word)
+	for _, r := range foldReplacements {
+		word = strings.ReplaceAll(word, r[0], r[1])
+	}
+
+	var b strings.Builder
+	var last rune
+	for _, r := range word {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			continue
+		}
+		if r == last {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+	return b.String()
+}
+
+// foldReplacements are applied in order. Aspirates lose their h, voiced
+// stops become voiceless, the sibilants and affricates merge into s, and
+// long vowels become short.
+var foldReplacements = [][2]string{
+	{"kh", "k"}, {"gh", "g"}, {"chh", "c"}, {"ch", "c"}, {"jh", "j"},
+	{"th", "t"}, {"dh", "d"}, {"ph", "p"}, {"bh", "b"}, {"sh", "s"}, {"zh", "l"},
+	{"w", "v"}, {"q", "k"}, {"x", "ks"}, {"z", "s"},
+	{"g", "k"}, {"d", "t"}, {"b", "p"}, {"j", "c"}, {"c", "s"},
+	{"aee", "e"}, {"ae", "e"}, {"aa", "a"}, {"ee", "i"}, {"ii", "i"}, {"oo", "u"}, {"uu", "u"},
+}
+
+// romanize writes the Sinhala and Tamil letters of s in Latin, lower-cases
+// the rest and keeps word boundaries.
+func romanize(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if consonant, ok := indicConsonants[r]; ok {
+			b.WriteString(consonant)
+			// The inherent vowel is a unless a vowel sign or virama follows.
+			vowel := "a"
+			if i+1 < len(runes) {
+				if sign, ok := indicVowelSigns[runes[i+1]]; ok {
+					vowel = sign
+					i++
+				}
+			}
+			b.WriteString(vowel)
+			continue
+		}
+		if latin, ok := indicLetters[r]; ok {
+			b.WriteString(latin)
+			continue
+		}
+		switch {
+		case r == zwj || r == zwnj:
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			// Decompose so accented Latin letters fold to their base letter.
+			b.WriteString(norm.NFD.String(string(unicode.ToLower(r))))
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return b.String()
+}
+
+// indicConsonants romanizes the consonants of both scripts without their
+// inherent vowel.
+var indicConsonants = map[rune]string{
+	// Sinhala
+	'ක': "k", 'ඛ': "kh", 'ග': "g", 'ඝ': "gh", 'ඞ': "n", 'ඟ': "ng",
+	'ච': "ch", 'ඡ': "chh", 'ජ': "j", 'ඣ': "jh", 'ඤ': "ny", 'ඥ': "gny", 'ඦ': "nj",
+	'ට': "t", 'ඨ': "th", 'ඩ': "d", 'ඪ': "dh", 'ණ': "n", 'ඬ': "nd",
+	'ත': "th", 'ථ': "th", 'ද': "dh", 'ධ': "dh", 'න': "n", 'ඳ': "nd",
+	'ප': "p", 'ඵ': "ph", 'බ': "b", 'භ': "bh", 'ම': "m", 'ඹ': "mb",
+	'ය': "y", 'ර': "r", 'ල': "l", 'ව': "v", 'ශ': "sh", 'ෂ': "sh", 'ස': "s",
+	'හ': "h", 'ළ': "l", 'ෆ': "f",
+	// Tamil
+	'க': "k", 'ங': "ng", 'ச': "s", 'ஞ': "ny", 'ட': "t", 'ண': "n",
+	'த': "th", 'ந': "n", 'ப': "p", 'ம': "m", 'ய': "y", 'ர': "r",
+	'ல': "l", 'வ': "v", 'ழ': "zh", 'ள': "l", 'ற': "r", 'ன': "n",
+	'ஜ': "j", 'ஷ': "sh", 'ஸ': "s", 'ஹ': "h",
+}
+
+// indicVowelSigns romanizes the dependent vowel signs of both scripts and
+// their viramas, which cancel the inherent vowel. Long e and o are written
+// short since fold would otherwise read "ee" and "oo" as long i and u.
+var indicVowelSigns = map[rune]string{
+	// Sinhala
+	'්': "", 'ා': "aa", 'ැ': "ae", 'ෑ': "aee", 'ි': "i", 'ී': "ii", 'ු': "u", 'ූ': "uu",
+	'ෘ': "ru", 'ෲ': "ruu", 'ෙ': "e", 'ේ': "e", 'ෛ': "ai", 'ො': "o", 'ෝ': "o", 'ෞ': "au",
+	// Tamil
+	'்': "", 'ா': "aa", 'ி': "i", 'ீ': "ii", 'ு': "u", 'ூ': "uu",
+	'ெ': "e", 'ே': "e", 'ை': "ai", 'ொ': "o", 'ோ': "o", 'ௌ': "au",
+}
+
+// indicLetters romanizes the independent vowels and the signs that stand
+// on their own.
+var indicLetters = map[rune]string{
+	// Sinhala
+	'අ': "a", 'ආ': "aa", 'ඇ': "ae", 'ඈ': "aee", 'ඉ': "i", 'ඊ': "ii", 'උ': "u", 'ඌ': "uu",
+	'ඍ': "ru", 'එ': "e", 'ඒ': "e", 'ඓ': "ai", 'ඔ': "o", 'ඕ': "o", 'ඖ': "au",
+	'ං': "n", 'ඃ': "h",
+	// Tamil
+	'அ': "a", 'ஆ': "aa", 'இ': "i", 'ஈ': "ii", 'உ': "u", 'ஊ': "uu",
+	'எ': "e", 'ஏ': "e", 'ஐ': "ai", 'ஒ': "o", 'ஓ': "o", 'ஔ': "au", 'ஃ': "",
+}
@@ -0,0 +1,74 @@
+package translit_test
+
+import (
+	"testing"
+
+	"go-mysql-backend/internal/translit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	// ො typed as ෙ followed by ා.
+	assert.Equal(t, "කොළඹ", translit.Normalize("කොළඹ"))
+	assert.Equal(t, "ශ්‍රී", translit.Normalize("ශ්‍රී"), "keeps the zero-width joiner")
+}
+
+func TestIsRomanized(t *testing.T) {
+	assert.True(t, translit.IsRomanized("sukhasevana amathyanshaya"))
+	assert.False(t, translit.IsRomanized("සෞඛ්‍ය"))
+	assert.False(t, translit.IsRomanized("Ministry of සෞඛ්‍ය"))
+	assert.False(t, translit.IsRomanized("2024"))
+}
+
+func TestToSinhala(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"amathyanshaya", "අමත්‍යන්ශය"},
+		{"sewa", "සෙව"},
+		{"kolamba", "කොලම්බ"},
+		{"shree lankaa", "ශ්‍රී ලන්කා"},
+		{"Colombo 05", "චොලොම්බො 05"},
+		{"සෞඛ්‍ය sewa", "සෞඛ්‍ය සෙව"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, translit.ToSinhala(tt.in), tt.in)
+	}
+}
+
+func TestToTamil(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"sugathaara", "சுகதார"},
+		{"amaichu", "அமைசு"},
+		{"naadu", "நாடு"},
+		{"kalvi", "கல்வி"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, translit.ToTamil(tt.in), tt.in)
+	}
+}
+
+func TestKeyMatchesRomanizedSpellings(t *testing.T) {
+	tests := []struct {
+		native    string
+		romanized []string
+	}{
+		{"සෞඛ්‍ය සේවා අමාත්‍යාංශය", []string{"saukya seva amatyansaya", "saukhya sewa amathyanshaya", "SAUKHYA SEWAA AMAATHYAANSHAYA"}},
+		{"මෝටර් රථ ප්‍රවාහන දෙපාර්තමේන්තුව", []string{"motar ratha prawahana departhamenthuwa", "motar rata pravaahana dhepaarthamenthuva"}},
+		{"சுகாதார அமைச்சு", []string{"sukathara amaichu", "sugathaara amaissu"}},
+		{"கல்வி அமைச்சு", []string{"kalvi amaichchu", "kalwi amaisu"}},
+	}
+	for _, tt := range tests {
+		want := translit.Key(tt.native)
+		for _, romanized := range tt.romanized {
+			assert.Equal(t, want, translit.Key(romanized), "%s ~ %s", romanized, tt.native)
+		}
+	}
+}
+
+func TestKeyFoldsVariants(t *testing.T) {
+	assert.Equal(t, "kolampa", translit.Key("Kolamba"))
+	assert.Equal(t, translit.Key("Kolamba"), translit.Key("කොළඹ"))
+	assert.Equal(t, translit.Key("motor"), translit.Key("mōṭor"), "accents are dropped")
+	assert.Equal(t, []string{"tepartment", "of", "motor"}, translit.Keys("Department of, Motor!"))
+	assert.Empty(t, translit.Key("... ---"))
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"go-mysql-backend/internal/handlers"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func SetupAdminAreaRoutes(router *mux.Router, handler *handlers.AdminAreaHandler) {
+	v1 := router.PathPrefix("/api/v1").Subrouter()
+
+	v1.HandleFunc("/areas.geojson", handler.GetAreas).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/areas/{id}.geojson", handler.GetAreaByID).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/areas/{id}/children.geojson", handler.GetChildAreas).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/areas/{id}/departments.geojson", handler.GetAreaDepartments).Methods(http.MethodGet, http.MethodOptions)
+
+	areas := v1.PathPrefix("/areas").Subrouter()
+	areas.HandleFunc("", handler.GetAreas).Methods(http.MethodGet, http.MethodOptions)
+	areas.HandleFunc("/{id}", handler.GetAreaByID).Methods(http.MethodGet, http.MethodOptions)
+	areas.HandleFunc("/{id}/children", handler.GetChildAreas).Methods(http.MethodGet, http.MethodOptions)
+	areas.HandleFunc("/{id}/departments", handler.GetAreaDepartments).Methods(http.MethodGet, http.MethodOptions)
+
+	v1.HandleFunc("/departments/{id}/area", handler.SetDepartmentArea).Methods(http.MethodPut, http.MethodOptions)
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"go-mysql-backend/internal/handlers"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func SetupJurisdictionRoutes(router *mux.Router, handler *handlers.JurisdictionHandler) {
+	v1 := router.PathPrefix("/api/v1").Subrouter()
+	v1.HandleFunc("/jurisdiction", handler.Lookup).Methods(http.MethodGet, http.MethodOptions)
+}
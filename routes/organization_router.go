@@ -2,16 +2,106 @@ package routes
 
 import (
 	"go-mysql-backend/internal/handlers"
+	"net/http"
 
 	"github.com/gorilla/mux"
 )
 
-func SetupOrgRoutes(router *mux.Router, OrganizationHandler *handlers.OrganizationHandler) {
+// Legacy is the server whose unprefixed routes older clients were written
+// against. The Postgres and Neo4j servers of older releases answered
+// GET /ministries/{id} differently, and each keeps its answer.
+type Legacy int
+
+const (
+	// LegacyPostgres returns the ministry without its departments.
+	LegacyPostgres Legacy = iota
+	// LegacyNeo4j returns the ministry with its departments.
+	LegacyNeo4j
+)
+
+// SetupOrgRoutes registers the organisation endpoints: the unprefixed
+// routes kept for older clients, then /api/v1, which is the same for every
+// backend.
+func SetupOrgRoutes(router *mux.Router, OrganizationHandler *handlers.OrganizationHandler, legacy Legacy) {
+	getMinistry := OrganizationHandler.GetMinistryByID
+	if legacy == LegacyNeo4j {
+		getMinistry = OrganizationHandler.GetMinistryByIDWithDepartments
+	}
+
+	// GeoJSON variants; registered first so {id} does not swallow "1.geojson"
+	router.HandleFunc("/ministries.geojson", OrganizationHandler.GetMinistriesWithDepartments).Methods("GET")
+	router.HandleFunc("/ministries/{id}.geojson", OrganizationHandler.GetMinistryByIDWithDepartments).Methods("GET")
+	router.HandleFunc("/departments.geojson", OrganizationHandler.GetAllDepartments).Methods("GET")
+	router.HandleFunc("/departments/nearby.geojson", OrganizationHandler.FindDepartmentsNearby).Methods("GET")
+	router.HandleFunc("/departments/{id}.geojson", OrganizationHandler.GetDepartmentByID).Methods("GET")
+
 	router.HandleFunc("/ministries", OrganizationHandler.GetMinistriesWithDepartments).Methods("GET")
 	router.HandleFunc("/ministries", OrganizationHandler.CreateMinistry).Methods("POST")
 	router.HandleFunc("/departments", OrganizationHandler.CreateDepartment).Methods("POST")
 	router.HandleFunc("/departments", OrganizationHandler.GetAllDepartments).Methods("GET")
-	router.HandleFunc("/ministries/{id}", OrganizationHandler.GetMinistryByID).Methods("GET")
+	router.HandleFunc("/ministries/{id}", getMinistry).Methods("GET")
+	router.HandleFunc("/departments/nearby", OrganizationHandler.FindDepartmentsNearby).Methods("GET")
 	router.HandleFunc("/departments/{id}", OrganizationHandler.GetDepartmentByID).Methods("GET")
+	router.HandleFunc("/ministries/{id}", OrganizationHandler.UpdateMinistry).Methods("PUT")
+	router.HandleFunc("/ministries/{id}", OrganizationHandler.PatchMinistry).Methods("PATCH")
+	router.HandleFunc("/ministries/{id}", OrganizationHandler.DeleteMinistry).Methods("DELETE")
+	router.HandleFunc("/departments/{id}", OrganizationHandler.UpdateDepartment).Methods("PUT")
+	router.HandleFunc("/departments/{id}", OrganizationHandler.PatchDepartment).Methods("PATCH")
+	router.HandleFunc("/departments/{id}", OrganizationHandler.DeleteDepartment).Methods("DELETE")
+	router.HandleFunc("/seed", OrganizationHandler.SeedDummyData).Methods("POST")
+
+	// Create a subrouter for API v1
+	v1 := router.PathPrefix("/api/v1").Subrouter()
+
+	// GeoJSON variants; registered first so {id} does not swallow "1.geojson"
+	v1.HandleFunc("/ministries.geojson", OrganizationHandler.GetMinistriesWithDepartments).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/ministries/{id}.geojson", OrganizationHandler.GetMinistryByIDWithDepartments).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/departments.geojson", OrganizationHandler.GetAllDepartments).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/departments/nearby.geojson", OrganizationHandler.FindDepartmentsNearby).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/departments/{id}.geojson", OrganizationHandler.GetDepartmentByID).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/departments/{id}/offices.geojson", OrganizationHandler.GetDepartmentOffices).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/offices/nearby.geojson", OrganizationHandler.FindOfficesNearby).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/offices/{id}.geojson", OrganizationHandler.GetOfficeByID).Methods(http.MethodGet, http.MethodOptions)
+
+	// Ministries routes
+	ministries := v1.PathPrefix("/ministries").Subrouter()
+	ministries.HandleFunc("", OrganizationHandler.GetMinistriesWithDepartments).Methods(http.MethodGet, http.MethodOptions)
+	ministries.HandleFunc("/paginated", OrganizationHandler.GetMinistriesPage).Methods(http.MethodGet, http.MethodOptions)
+	ministries.HandleFunc("", OrganizationHandler.CreateMinistry).Methods(http.MethodPost, http.MethodOptions)
+	ministries.HandleFunc("/{id}", OrganizationHandler.GetMinistryByIDWithDepartments).Methods(http.MethodGet, http.MethodOptions)
+	ministries.HandleFunc("/{id}", OrganizationHandler.UpdateMinistry).Methods(http.MethodPut, http.MethodOptions)
+	ministries.HandleFunc("/{id}", OrganizationHandler.PatchMinistry).Methods(http.MethodPatch, http.MethodOptions)
+	ministries.HandleFunc("/{id}", OrganizationHandler.DeleteMinistry).Methods(http.MethodDelete, http.MethodOptions)
+
+	// Departments routes
+	departments := v1.PathPrefix("/departments").Subrouter()
+	departments.HandleFunc("", OrganizationHandler.GetAllDepartments).Methods(http.MethodGet, http.MethodOptions)
+	departments.HandleFunc("", OrganizationHandler.CreateDepartment).Methods(http.MethodPost, http.MethodOptions)
+	departments.HandleFunc("/nearby", OrganizationHandler.FindDepartmentsNearby).Methods(http.MethodGet, http.MethodOptions)
+	departments.HandleFunc("/{id}", OrganizationHandler.GetDepartmentByID).Methods(http.MethodGet, http.MethodOptions)
+	departments.HandleFunc("/{id}", OrganizationHandler.UpdateDepartment).Methods(http.MethodPut, http.MethodOptions)
+	departments.HandleFunc("/{id}", OrganizationHandler.PatchDepartment).Methods(http.MethodPatch, http.MethodOptions)
+	departments.HandleFunc("/{id}", OrganizationHandler.DeleteDepartment).Methods(http.MethodDelete, http.MethodOptions)
+
+	// Offices of a department
+	departments.HandleFunc("/{id}/offices", OrganizationHandler.GetDepartmentOffices).Methods(http.MethodGet, http.MethodOptions)
+	departments.HandleFunc("/{id}/offices", OrganizationHandler.CreateOffice).Methods(http.MethodPost, http.MethodOptions)
+	offices := v1.PathPrefix("/offices").Subrouter()
+	offices.HandleFunc("/nearby", OrganizationHandler.FindOfficesNearby).Methods(http.MethodGet, http.MethodOptions)
+	offices.HandleFunc("/{id}", OrganizationHandler.GetOfficeByID).Methods(http.MethodGet, http.MethodOptions)
+	offices.HandleFunc("/{id}", OrganizationHandler.UpdateOffice).Methods(http.MethodPut, http.MethodOptions)
+	offices.HandleFunc("/{id}", OrganizationHandler.DeleteOffice).Methods(http.MethodDelete, http.MethodOptions)
+
+	// Organisation hierarchy
+	v1.HandleFunc("/organizations/{id}/tree", OrganizationHandler.GetOrganizationTree).Methods(http.MethodGet, http.MethodOptions)
+
+	// Gazette change sets
+	v1.HandleFunc("/changesets", OrganizationHandler.ApplyChangeSet).Methods(http.MethodPost, http.MethodOptions)
+
+	// Bulk import of ministries, departments and offices
+	v1.HandleFunc("/import", OrganizationHandler.Import).Methods(http.MethodPost, http.MethodOptions)
 
+	// Full-text search and typeahead
+	v1.HandleFunc("/search", OrganizationHandler.Search).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/autocomplete", OrganizationHandler.Autocomplete).Methods(http.MethodGet, http.MethodOptions)
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"go-mysql-backend/internal/handlers"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func SetupPositionRoutes(router *mux.Router, handler *handlers.PositionHandler) {
+	v1 := router.PathPrefix("/api/v1").Subrouter()
+
+	people := v1.PathPrefix("/people").Subrouter()
+	people.HandleFunc("", handler.CreatePerson).Methods(http.MethodPost, http.MethodOptions)
+	people.HandleFunc("/{id}", handler.GetPerson).Methods(http.MethodGet, http.MethodOptions)
+
+	positions := v1.PathPrefix("/positions").Subrouter()
+	positions.HandleFunc("", handler.CreatePosition).Methods(http.MethodPost, http.MethodOptions)
+	positions.HandleFunc("/{id}", handler.EndPosition).Methods(http.MethodPatch, http.MethodOptions)
+
+	v1.HandleFunc("/organizations/{id}/office-holders", handler.GetOfficeHolders).Methods(http.MethodGet, http.MethodOptions)
+	v1.HandleFunc("/organizations/{id}/positions", handler.GetPositionHistory).Methods(http.MethodGet, http.MethodOptions)
+}
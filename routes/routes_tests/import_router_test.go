@@ -0,0 +1,38 @@
+package routes_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-mysql-backend/routes"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImport_TooLarge(t *testing.T) {
+	router := newRouter(t, routes.LegacyPostgres)
+
+	csv := "entity,name\n" + strings.Repeat("ministry,Ministry of Ports\n", 11<<20/26)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/import?dry_run=true", bytes.NewBufferString(csv))
+	req.Header.Set("Content-Type", "text/csv")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, rec.Body.String())
+}
+
+func TestImport_DryRun(t *testing.T) {
+	router := newRouter(t, routes.LegacyPostgres)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/import?dry_run=true",
+		strings.NewReader("entity,name,ministry\ndepartment,Ports Authority,Ministry of Health\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"valid":true`)
+}
@@ -0,0 +1,54 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-mysql-backend/routes"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearby_InvalidPoint(t *testing.T) {
+	router := newRouter(t, routes.LegacyPostgres)
+
+	for _, path := range []string{
+		"/api/v1/departments/nearby?lat=NaN&lon=NaN",
+		"/api/v1/departments/nearby?lat=6.93&lon=nan",
+		"/api/v1/departments/nearby?lat=6.93&lon=79.85&radius_km=NaN",
+		"/api/v1/departments/nearby?lat=91&lon=79.85",
+		"/api/v1/offices/nearby?lat=NaN&lon=79.85",
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, path)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/departments/nearby?lat=6.93&lon=79.85", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func TestViewport_InvalidBBox(t *testing.T) {
+	router := newRouter(t, routes.LegacyPostgres)
+
+	for _, path := range []string{
+		"/api/v1/departments?bbox=NaN,5.9,81.9,9.9",
+		"/api/v1/departments?bbox=79.5,nan,81.9,9.9",
+		"/api/v1/ministries?bbox=79.5,5.9,81.9,NaN",
+		"/api/v1/departments?bbox=79.5,5.9,81.9,9.9&limit=0",
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, path)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/departments?bbox=79.5,5.9,81.9,9.9&limit=x", nil))
+	assert.Contains(t, rec.Body.String(), "limit must be a positive integer")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/departments?bbox=79.5,5.9,81.9,9.9&limit=1000", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,55 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-mysql-backend/internal/handlers"
+	"go-mysql-backend/internal/models"
+	"go-mysql-backend/internal/repository"
+	"go-mysql-backend/internal/service"
+	"go-mysql-backend/routes"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRouter(t *testing.T, legacy routes.Legacy) *mux.Router {
+	t.Helper()
+	repo := repository.NewMemoryRepository(repository.NewMemoryStore())
+	ministryID, err := repo.CreateMinistry(models.Ministry{Name: "Ministry of Health"})
+	require.NoError(t, err)
+	_, err = repo.CreateDepartment(models.Department{Name: "Department of Health Services", MinistryID: ministryID})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	routes.SetupOrgRoutes(router, handlers.NewOrganizationHandler(service.NewOrganizationService(repo)), legacy)
+	return router
+}
+
+func getMinistry(t *testing.T, router *mux.Router, path string) map[string]interface{} {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body
+}
+
+func TestSetupOrgRoutes_LegacyMinistry(t *testing.T) {
+	postgres := newRouter(t, routes.LegacyPostgres)
+	assert.Equal(t, "Ministry of Health", getMinistry(t, postgres, "/ministries/1")["name"])
+	assert.NotContains(t, getMinistry(t, postgres, "/ministries/1"), "Departments")
+
+	neo4j := newRouter(t, routes.LegacyNeo4j)
+	assert.Len(t, getMinistry(t, neo4j, "/ministries/1")["Departments"], 1)
+
+	// /api/v1 is the same on both.
+	for _, router := range []*mux.Router{postgres, neo4j} {
+		assert.Len(t, getMinistry(t, router, "/api/v1/ministries/1")["Departments"], 1)
+	}
+}